@@ -35,6 +35,28 @@ type selection struct {
 	*Msgs
 }
 
+// String is an implementation of Stringer interface. It renders the
+// messages gathered while querying.
+func (s *selection) String() string {
+	return s.Msgs.String()
+}
+
+// Format is an implementation of fmt.Formatter interface. It renders as
+// returned by String instead of the Format of embedded messages.
+func (s *selection) Format(f fmt.State, verb rune) {
+	formatString(f, verb, s.String, s.GoString)
+}
+
+// formatString writes the provided renderings as per the verb and flags
+// i.e. %#v writes the go syntax form while rest write the string form
+func formatString(f fmt.State, verb rune, str, gostring func() string) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprint(f, gostring())
+		return
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, verb), str())
+}
+
 // Selection returns a new instance of selection
 func Selection(alias, path string) *selection {
 	return &selection{
@@ -165,6 +187,18 @@ type jsonpath struct {
 	*Msgs
 }
 
+// String is an implementation of Stringer interface. It renders the
+// messages gathered while querying.
+func (j *jsonpath) String() string {
+	return j.Msgs.String()
+}
+
+// Format is an implementation of fmt.Formatter interface. It renders as
+// returned by String instead of the Format of embedded messages.
+func (j *jsonpath) Format(f fmt.State, verb rune) {
+	formatString(f, verb, j.String, j.GoString)
+}
+
 // JSONPath returns a new jsonpath instance
 func JSONPath(name string) (j *jsonpath) {
 	return &jsonpath{
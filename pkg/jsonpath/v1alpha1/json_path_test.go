@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -311,3 +312,18 @@ func TestQueryAllJivaPayload(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectionFormat(t *testing.T) {
+	s := Selection("name", "{.name}")
+	s.AddWarn("w1")
+	j := JSONPath("test")
+	j.AddWarn("w1")
+	for _, format := range []string{"%s", "%v", "%+v"} {
+		if actual := fmt.Sprintf(format, s); actual != s.Msgs.String() {
+			t.Fatalf("Test failed: expected '%s' to render the messages of selection: actual '%s'", format, actual)
+		}
+		if actual := fmt.Sprintf(format, j); actual != j.Msgs.String() {
+			t.Fatalf("Test failed: expected '%s' to render the messages of jsonpath: actual '%s'", format, actual)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// AdmissionWarningsOptions represents the options used while exporting
+// messages as warnings of an admission webhook response
+type AdmissionWarningsOptions struct {
+	// MaxLen is the maximum length in characters of a warning; longer
+	// warnings are truncated with an ellipsis. Unlimited if not positive.
+	MaxLen int
+	// MaxCount is the maximum number of warnings; the rest is summarized
+	// by one more warning. Unlimited if not positive.
+	MaxCount int
+}
+
+// DefaultAdmissionWarningsOptions limits warnings to what kubectl
+// practically displays
+var DefaultAdmissionWarningsOptions = AdmissionWarningsOptions{MaxLen: 256, MaxCount: 20}
+
+// AdmissionWarnings returns the descriptions of WarnMsg and
+// DeprecationMsg messages as warnings of an admission webhook response
+// using DefaultAdmissionWarningsOptions
+func (m Msgs) AdmissionWarnings() []string {
+	return m.AdmissionWarningsWithOptions(DefaultAdmissionWarningsOptions)
+}
+
+// AdmissionWarningsWithOptions returns the descriptions of WarnMsg and
+// DeprecationMsg messages as warnings of an admission webhook response.
+// Control characters are dropped and warnings left empty are skipped.
+// Warnings beyond MaxCount are summarized by a final warning e.g.
+// '…and 3 more warnings'.
+func (m Msgs) AdmissionWarningsWithOptions(o AdmissionWarningsOptions) (warnings []string) {
+	var omitted int
+	for _, item := range m.Items {
+		if item == nil || (item.Mtype != WarnMsg && item.Mtype != DeprecationMsg) {
+			continue
+		}
+		w := admissionWarning(item.Description(), o.MaxLen)
+		if len(w) == 0 {
+			continue
+		}
+		if o.MaxCount > 0 && len(warnings) == o.MaxCount {
+			omitted++
+			continue
+		}
+		warnings = append(warnings, w)
+	}
+	if omitted != 0 {
+		warnings = append(warnings, fmt.Sprintf("…and %d more warnings", omitted))
+	}
+	return
+}
+
+// admissionWarning returns the provided description without control
+// characters and with at most max characters including the ellipsis
+func admissionWarning(desc string, max int) string {
+	w := strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, desc))
+	if max <= 0 || utf8.RuneCountInString(w) <= max {
+		return w
+	}
+	runes := []rune(w)
+	return string(runes[:max-1]) + "…"
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMsgsAdmissionWarnings(t *testing.T) {
+	tests := map[string]struct {
+		m        *Msgs
+		o        AdmissionWarningsOptions
+		expected []string
+	}{
+		"101": {(&Msgs{}).AddInfo("i1").AddWarn("w1").AddError(errors.New("e1")).AddDeprecation("d1"),
+			DefaultAdmissionWarningsOptions, []string{"w1", "d1"}},
+		"102": {(&Msgs{}).AddWarn("abcdef").AddWarn("abcde").AddWarn("ab世界cd"),
+			AdmissionWarningsOptions{MaxLen: 5}, []string{"abcd…", "abcde", "ab世界…"}},
+		"103": {(&Msgs{}).AddWarn("w1").AddWarn("w2").AddDeprecation("d1").AddWarn("w3").AddInfo("i1"),
+			AdmissionWarningsOptions{MaxCount: 2}, []string{"w1", "w2", "…and 2 more warnings"}},
+		"104": {(&Msgs{}).AddWarn("w1").AddWarn("w2"), AdmissionWarningsOptions{MaxCount: 2}, []string{"w1", "w2"}},
+		"105": {(&Msgs{}).AddWarn("line1\nline2\t\x1b[31mred\x1b[0m\x00").AddWarn("\n\r\t").AddWarn("w2"),
+			AdmissionWarningsOptions{}, []string{"line1line2[31mred[0m", "w2"}},
+		"106": {(&Msgs{}).AddInfo("i1"), DefaultAdmissionWarningsOptions, nil},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := mock.m.AdmissionWarningsWithOptions(mock.o)
+			if strings.Join(actual, "|") != strings.Join(mock.expected, "|") || len(actual) != len(mock.expected) {
+				t.Fatalf("Test '%s' failed: expected '%v': actual '%v'", name, mock.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMsgsAdmissionWarningsDefaults(t *testing.T) {
+	m := &Msgs{}
+	for i := 0; i < 25; i++ {
+		m.AddWarn(strings.Repeat("x", 300))
+	}
+	warnings := m.AdmissionWarnings()
+	if len(warnings) != 21 || warnings[20] != "…and 5 more warnings" {
+		t.Fatalf("Test failed: expected 20 warnings and a summary: actual %d '%s'", len(warnings), warnings[len(warnings)-1])
+	}
+	if w := warnings[0]; len([]rune(w)) != 256 || !strings.HasSuffix(w, "…") {
+		t.Fatalf("Test failed: expected a warning of 256 characters: actual %d", len([]rune(w)))
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// Merge returns a new AllMsgs whose every bucket holds the messages of
+// the receiver followed by the messages of the passed one for the same
+// MsgType. Neither the receiver nor the passed one is modified.
+func (a AllMsgs) Merge(other AllMsgs) (merged AllMsgs) {
+	merged = AllMsgs{}
+	for _, src := range []AllMsgs{a, other} {
+		for mtype, m := range src {
+			bucket := merged[mtype]
+			bucket.Items = append(append([]*msg(nil), bucket.Items...), m.Items...)
+			merged[mtype] = bucket
+		}
+	}
+	return
+}
+
+// Filter returns a new AllMsgs holding the messages of every bucket
+// that match the provided predicate. Buckets left without any message
+// are dropped. The receiver is not modified.
+func (a AllMsgs) Filter(p msgPredicate) (f AllMsgs) {
+	f = AllMsgs{}
+	for mtype, m := range a {
+		filtered := m.Filter(p)
+		if len(filtered.Items) == 0 {
+			continue
+		}
+		f[mtype] = filtered
+	}
+	return
+}
+
+// Only returns a new AllMsgs holding just the buckets of the provided
+// message types. The receiver is not modified.
+func (a AllMsgs) Only(types ...MsgType) (f AllMsgs) {
+	f = AllMsgs{}
+	for _, mtype := range types {
+		m, found := a[mtype]
+		if !found {
+			continue
+		}
+		f[mtype] = Msgs{Items: append([]*msg(nil), m.Items...)}
+	}
+	return
+}
+
+// yamlString returns the non empty buckets as a yaml formatted string
+// prefixed with a newline
+func (a AllMsgs) yamlString() string {
+	y, err := a.YAML()
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("\n%s", y)
+}
+
+// YAML returns the non empty buckets as a yaml formatted string.
+// Buckets are rendered in a fixed order, see ToMsgs, such that the
+// output is deterministic.
+func (a AllMsgs) YAML() (string, error) {
+	var b strings.Builder
+	for _, mtype := range a.types() {
+		m := a[mtype]
+		if len(m.Items) == 0 {
+			continue
+		}
+		y, err := yaml.Marshal(map[MsgType]detailedMsgs{mtype: m.detailed()})
+		if err != nil {
+			return "", fmt.Errorf("%s: failed to format 'allmsgs' as yaml string", err)
+		}
+		b.Write(y)
+	}
+	return b.String(), nil
+}
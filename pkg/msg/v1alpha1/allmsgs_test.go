@@ -0,0 +1,261 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAllMsgsMerge(t *testing.T) {
+	tests := map[string]struct {
+		a, other AllMsgs
+		expected map[MsgType]int
+	}{
+		"101": {(&Msgs{}).AddInfo("i1").AllMsgs(), (&Msgs{}).AddInfo("i2").AddError(errors.New("e1")).AllMsgs(),
+			map[MsgType]int{InfoMsg: 2, ErrMsg: 1, WarnMsg: 0, SkipMsg: 0, DeprecationMsg: 0}},
+		"102": {AllMsgs{InfoMsg: mockMsgsFromType([]MsgType{InfoMsg})},
+			AllMsgs{"custom": mockMsgsFromType([]MsgType{"custom", "custom"})},
+			map[MsgType]int{InfoMsg: 1, "custom": 2}},
+		"103": {nil, AllMsgs{WarnMsg: mockMsgsFromType([]MsgType{WarnMsg})}, map[MsgType]int{WarnMsg: 1}},
+		"104": {AllMsgs{WarnMsg: mockMsgsFromType([]MsgType{WarnMsg})}, nil, map[MsgType]int{WarnMsg: 1}},
+		"105": {nil, nil, map[MsgType]int{}},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			before := len(mock.a[InfoMsg].Items)
+			merged := mock.a.Merge(mock.other)
+			if len(merged) != len(mock.expected) {
+				t.Fatalf("Test '%s' failed: expected buckets %d: actual buckets %d", name, len(mock.expected), len(merged))
+			}
+			for mtype, count := range mock.expected {
+				if len(merged[mtype].Items) != count {
+					t.Fatalf("Test '%s' failed: expected %d '%s' messages: actual %d", name, count, mtype, len(merged[mtype].Items))
+				}
+			}
+			if len(mock.a[InfoMsg].Items) != before {
+				t.Fatalf("Test '%s' failed: expected receiver to be unmodified", name)
+			}
+		})
+	}
+}
+
+func TestAllMsgsFilter(t *testing.T) {
+	a := (&Msgs{}).AddInfo("pool i1").AddInfo("i2").AddWarn("pool w1").AddError(errors.New("e1")).AllMsgs()
+	isPool := func(given *msg) bool { return strings.HasPrefix(given.Description(), "pool") }
+
+	tests := map[string]struct {
+		a        AllMsgs
+		expected map[MsgType]string
+	}{
+		"101": {a, map[MsgType]string{InfoMsg: "pool i1", WarnMsg: "pool w1"}},
+		"102": {AllMsgs{}, map[MsgType]string{}},
+		"103": {nil, map[MsgType]string{}},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := mock.a.Filter(isPool)
+			if len(f) != len(mock.expected) {
+				t.Fatalf("Test '%s' failed: expected buckets %d: actual buckets %d", name, len(mock.expected), len(f))
+			}
+			for mtype, expected := range mock.expected {
+				actual := f[mtype]
+				if descs(&actual) != expected {
+					t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, expected, descs(&actual))
+				}
+			}
+		})
+	}
+	if infos := a[InfoMsg]; len(infos.Items) != 2 {
+		t.Fatalf("Test failed: expected receiver to be unmodified: actual '%s'", descs(&infos))
+	}
+}
+
+func TestAllMsgsOnly(t *testing.T) {
+	a := (&Msgs{}).AddInfo("i1").AddWarn("w1").AddError(errors.New("e1")).AllMsgs()
+
+	tests := map[string]struct {
+		a        AllMsgs
+		types    []MsgType
+		expected []MsgType
+	}{
+		"101": {a, []MsgType{WarnMsg, ErrMsg}, []MsgType{WarnMsg, ErrMsg}},
+		"102": {a, []MsgType{"unknown"}, nil},
+		"103": {a, []MsgType{InfoMsg, "unknown"}, []MsgType{InfoMsg}},
+		"104": {a, nil, nil},
+		"105": {AllMsgs{}, []MsgType{InfoMsg}, nil},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := mock.a.Only(mock.types...)
+			if len(f) != len(mock.expected) {
+				t.Fatalf("Test '%s' failed: expected buckets %d: actual buckets %d", name, len(mock.expected), len(f))
+			}
+			for _, mtype := range mock.expected {
+				if len(f[mtype].Items) != len(mock.a[mtype].Items) {
+					t.Fatalf("Test '%s' failed: expected bucket '%s' to be retained", name, mtype)
+				}
+			}
+		})
+	}
+	if len(a) != 5 {
+		t.Fatalf("Test failed: expected receiver to be unmodified: actual buckets %d", len(a))
+	}
+}
+
+func TestAllMsgsCustomBuckets(t *testing.T) {
+	tests := map[string]struct {
+		a             AllMsgs
+		expectedEmpty bool
+		expectedTypes string
+	}{
+		"101": {AllMsgs{"custom": mockMsgsFromType([]MsgType{"custom"})}, false, "custom"},
+		"102": {AllMsgs{
+			"zeta":   mockMsgsFromType([]MsgType{"zeta"}),
+			InfoMsg:  mockMsgsFromType([]MsgType{InfoMsg}),
+			"custom": mockMsgsFromType([]MsgType{"custom", "custom"}),
+			ErrMsg:   mockMsgsFromType([]MsgType{ErrMsg}),
+		}, false, "error,info,custom,custom,zeta"},
+		"103": {AllMsgs{"custom": Msgs{}, InfoMsg: Msgs{}}, true, ""},
+		"104": {AllMsgs{}, true, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if mock.a.IsEmpty() != mock.expectedEmpty {
+				t.Fatalf("Test '%s' failed: expected empty '%t': actual '%t'", name, mock.expectedEmpty, !mock.expectedEmpty)
+			}
+			var types []string
+			for _, item := range mock.a.ToMsgs().Items {
+				types = append(types, string(item.Mtype))
+			}
+			if strings.Join(types, ",") != mock.expectedTypes {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expectedTypes, strings.Join(types, ","))
+			}
+		})
+	}
+}
+
+func TestAllMsgsString(t *testing.T) {
+	a := (&Msgs{}).AddSkip("s1").AddInfo("i2").AddInfo("i1").AddWarn("w1").AddError(errors.New("e1")).AllMsgs()
+	a["zeta"] = mockMsgsFromType([]MsgType{"zeta"})
+	a["custom"] = mockMsgsFromType([]MsgType{"custom"})
+
+	expected := `
+error:
+  items:
+  - desc: e1
+    err: e1
+    type: error
+warn:
+  items:
+  - desc: w1
+    type: warn
+info:
+  items:
+  - desc: i2
+    type: info
+  - desc: i1
+    type: info
+skip:
+  items:
+  - desc: s1
+    type: skip
+custom:
+  items:
+  - desc: ""
+    type: custom
+zeta:
+  items:
+  - desc: ""
+    type: zeta
+`
+	for i := 0; i < 100; i++ {
+		if actual := a.String(); actual != expected {
+			t.Fatalf("Test '%d' failed: expected '%s': actual '%s'", i, expected, actual)
+		}
+	}
+}
+
+func TestMsgsAllMsgs(t *testing.T) {
+	m := &Msgs{}
+	m.AddInfo("i1").AddError(errors.New("e1")).AddWarn("w1").AddInfo("i2").AddSkip("s1").AddDeprecation("d1")
+	m.Items = append(m.Items, nil, &msg{Mtype: "audit", Desc: "a1"}, &msg{Mtype: ProgressMsg, Desc: "p1"}, &msg{Mtype: "audit", Desc: "a2"})
+	all := m.AllMsgs()
+	expected := map[MsgType]string{
+		InfoMsg:        "i1,i2",
+		ErrMsg:         "e1",
+		WarnMsg:        "w1",
+		SkipMsg:        "s1",
+		DeprecationMsg: "d1",
+		ProgressMsg:    "p1",
+		"audit":        "a1,a2",
+	}
+	if len(all) != len(expected) {
+		t.Fatalf("Test failed: expected %d buckets: actual %d", len(expected), len(all))
+	}
+	for mtype, d := range expected {
+		bucket := all[mtype]
+		if descs(&bucket) != d {
+			t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", mtype, d, descs(&bucket))
+		}
+	}
+	for mtype, bucket := range (Msgs{}).AllMsgs() {
+		if bucket.Items != nil || mtype == ProgressMsg {
+			t.Fatalf("Test '%s' failed: expected an empty bucket of a declared type: actual '%v'", mtype, bucket.Items)
+		}
+	}
+	if len((Msgs{}).AllMsgs()) != 5 {
+		t.Fatalf("Test failed: expected 5 empty buckets: actual %d", len((Msgs{}).AllMsgs()))
+	}
+}
+
+// allMsgsByFilters returns messages by MsgType key by filtering once per
+// declared message type i.e. how AllMsgs used to be built
+func allMsgsByFilters(m Msgs) AllMsgs {
+	return map[MsgType]Msgs{
+		InfoMsg: m.Infos(),
+		ErrMsg:  m.Errors(),
+		WarnMsg: m.Warns(),
+		SkipMsg: m.Skips(),
+
+		DeprecationMsg: m.Deprecations(),
+	}
+}
+
+func BenchmarkMsgsAllMsgs(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000} {
+		m := mockLargeMsgs(n)
+		b.Run(fmt.Sprintf("Filters/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				allMsgsByFilters(*m)
+			}
+		})
+		b.Run(fmt.Sprintf("Grouped/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				m.AllMsgs()
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"time"
+)
+
+// isZero returns true if the provided view has neither a type, a
+// description nor an error
+func (v MsgView) isZero() bool {
+	return len(v.Type) == 0 && len(v.Desc) == 0 && v.Err == nil
+}
+
+// toMsg returns a message holding a copy of the provided view
+func (v MsgView) toMsg() *msg {
+	m := &msg{
+		Mtype:    v.Type,
+		Desc:     v.Desc,
+		Err:      v.Err,
+		ErrCode:  v.ErrCode,
+		ID:       v.ID,
+		Reason:   v.Reason,
+		Priority: v.Priority,
+		Category: v.Category,
+		Fields:   copyFields(v.Fields),
+		Object:   objectPtr(v.Object),
+		Code:     v.Code,
+		Source:   v.Source,
+		Labels:   mergeLabels(nil, v.Labels),
+		Caller:   v.Caller,
+		Percent:  v.Percent,
+		Time:     timePtr(v.Time),
+		Stack:    append([]string(nil), v.Stack...),
+
+		LastSeen: timePtr(v.LastSeen),
+	}
+	if v.Count > 1 {
+		m.Count = v.Count
+	}
+	if len(m.Desc) == 0 && m.Err != nil {
+		m.Desc = m.Err.Error()
+	}
+	if m.Mtype == ErrMsg && m.Err == nil {
+		m.Err = errors.New(m.Desc)
+	}
+	return m
+}
+
+// timePtr returns a pointer to the provided time or nil if it is the
+// zero time
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// Append appends the provided prebuilt messages e.g. ones obtained via
+// View. Zero value messages are skipped. Every property is preserved
+// as given while the ones that are not set are stamped as per the
+// options of the messages. Appended messages are subject to the limit
+// and fire the OnAdd hooks.
+//
+// A message whose type is not valid, see RegisterMsgType, is dropped
+// and an ErrMsg stating the invalid type is appended instead.
+func (m *Msgs) Append(items ...MsgView) (u *Msgs) {
+	m.mustNotBeNil("Append")
+	for _, item := range items {
+		if item.isZero() {
+			continue
+		}
+		if !item.Type.IsValid() {
+			m.AddError(invalidMsgTypeError(string(item.Type)))
+			continue
+		}
+		m.add(item.toMsg())
+	}
+	return m
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMsgsAppend(t *testing.T) {
+	RegisterMsgType("audit")
+	defer func() {
+		msgTypesMu.Lock()
+		delete(msgTypes, "audit")
+		msgTypesMu.Unlock()
+	}()
+	at := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		items    []MsgView
+		expected string
+	}{
+		"101": {[]MsgView{{Type: InfoMsg, Desc: "i1"}, {Type: "audit", Desc: "a1"}}, "i1,a1"},
+		"102": {[]MsgView{{}, {Type: WarnMsg, Desc: "w1"}, {}}, "w1"},
+		"103": {[]MsgView{{Type: ErrMsg, Err: errors.New("e1")}}, "e1"},
+		"104": {[]MsgView{{Type: "bogus", Desc: "b1"}, {Type: SkipMsg, Desc: "s1"}},
+			"invalid message type 'bogus': must be one of audit, deprecation, error, info, progress, skip, warn,s1"},
+		"105": {[]MsgView{{Type: InfoMsg, Desc: "i1", Time: at, Code: "C1", ID: "x-1", Caller: "a.go:1"}}, "i1"},
+		"106": {nil, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := &Msgs{}
+			if m.Append(mock.items...) != m || descs(m) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(m))
+			}
+		})
+	}
+}
+
+func TestMsgsAppendPreservesFields(t *testing.T) {
+	at := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	src := NewMsgs(WithClock(func() time.Time { return at }), WithTimestamps(), WithIDs(), WithSource("agent"))
+	src.AddWarnCode("PoolFull", "w1").AddSkipReason(AlreadyExists, "s1").AddError(errors.New("e1"))
+	src.Items[0].Labels = map[string]string{"pool": "p1"}
+
+	later := at.Add(time.Hour)
+	m := NewMsgs(WithClock(func() time.Time { return later }), WithTimestamps(), WithSource("operator"))
+	m.Append(src.View()...).AddInfo("i1")
+	if !src.EqualStrict(Msgs{Items: m.Items[:3]}) {
+		t.Fatalf("Test failed: expected appended messages to preserve fields: actual '%s'", m)
+	}
+	if !m.Items[3].Time.Equal(later) || m.Items[3].Source != "operator" {
+		t.Fatalf("Test failed: expected added message to be stamped: actual '%s'", m.Items[3])
+	}
+	if !errors.Is(m.Items[2].Err, src.Items[2].Err) {
+		t.Fatalf("Test failed: expected error to be preserved: actual '%v'", m.Items[2].Err)
+	}
+	src.Items[0].Labels["pool"] = "p2"
+	if m.Items[0].Labels["pool"] != "p1" {
+		t.Fatalf("Test failed: expected labels to be copied: actual '%v'", m.Items[0].Labels)
+	}
+}
+
+func TestMsgsAppendLimitsAndHooks(t *testing.T) {
+	var fired []string
+	m := (&Msgs{}).WithLimit(2, DropNewest).OnAdd(func(t MsgType, desc string, err error) {
+		fired = append(fired, desc)
+	})
+	m.Append(MsgView{Type: InfoMsg, Desc: "i1"}, MsgView{Type: WarnMsg, Desc: "w1"}, MsgView{Type: InfoMsg, Desc: "i2"})
+	if !strings.HasPrefix(descs(m), "i1,w1") || strings.Contains(descs(m), "i2") || strings.Join(fired, ",") != "i1,w1" {
+		t.Fatalf("Test failed: expected limit and hooks to apply: actual '%s' '%v'", descs(m), fired)
+	}
+	m = &Msgs{}
+	m.AddInfoOnce("i1").Append(MsgView{Type: InfoMsg, Desc: "i2"}).AddInfoOnce("i2")
+	if descs(m) != "i1,i2" {
+		t.Fatalf("Test failed: expected once semantics to account for appended messages: actual '%s'", descs(m))
+	}
+}
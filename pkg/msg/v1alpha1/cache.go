@@ -0,0 +1,66 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// lastGen is the most recently assigned generation of any list of
+// messages. A global counter ensures copies of a list that are changed
+// independently never end up with the same generation.
+var lastGen uint64
+
+// touch assigns a new generation to the list of messages, invalidating
+// its cached rendering
+func (m *Msgs) touch() {
+	m.gen = atomic.AddUint64(&lastGen, 1)
+	if m.cache == nil {
+		m.cache = &stringCache{}
+	}
+}
+
+// stringCache caches the rendering of a list of messages for a given
+// generation. Lists that are not changed via Add*, Merge or Reset do
+// not have a cache. Items appended directly are detected via the
+// length of the list while other direct changes are not.
+type stringCache struct {
+	mu    sync.Mutex
+	valid bool
+	gen   uint64
+	n     int
+	rgen  uint64 // generation of auto redaction, see EnableAutoRedaction
+	s     string
+}
+
+// get returns the cached rendering if it belongs to the provided
+// generation and length as well as to the current auto redaction; it
+// renders and caches otherwise
+func (c *stringCache) get(gen uint64, n int, render func() string) string {
+	if c == nil {
+		return render()
+	}
+	rgen := atomic.LoadUint64(&redactionGen)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.valid && c.gen == gen && c.n == n && c.rgen == rgen {
+		return c.s
+	}
+	c.s, c.gen, c.n, c.rgen, c.valid = render(), gen, n, rgen, true
+	return c.s
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMsgsStringCache(t *testing.T) {
+	tests := map[string]struct {
+		change   func(m *Msgs)
+		expected []string
+		absent   []string
+	}{
+		"101": {func(m *Msgs) { m.AddInfo("i2") }, []string{"i1", "i2"}, nil},
+		"102": {func(m *Msgs) { m.AddWarn("w1") }, []string{"i1", "w1"}, nil},
+		"103": {func(m *Msgs) { m.AddSkip("s1") }, []string{"i1", "s1"}, nil},
+		"104": {func(m *Msgs) { m.AddError(errors.New("e1")) }, []string{"i1", "e1"}, nil},
+		"105": {func(m *Msgs) { m.Merge((&Msgs{}).AddInfo("i3")) }, []string{"i1", "i3"}, nil},
+		"106": {func(m *Msgs) { m.Reset() }, nil, []string{"i1"}},
+		"107": {func(m *Msgs) { m.Reset().AddInfo("i4") }, []string{"i4"}, []string{"i1"}},
+		"108": {func(m *Msgs) { m.Items = append(m.Items, &msg{Mtype: InfoMsg, Desc: "i5"}) }, []string{"i1", "i5"}, nil},
+		"109": {func(m *Msgs) { m.AddInfo("i0").WithLimit(1, DropOldest) }, []string{"dropped 1 older messages", "i0"}, []string{"i1"}},
+		"110": {func(m *Msgs) { m.AddDeprecation("d1") }, []string{"i1", "d1"}, nil},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := (&Msgs{}).AddInfo("i1")
+			before := m.String()
+			if before != m.String() {
+				t.Fatalf("Test '%s' failed: expected cached string to be same", name)
+			}
+			mock.change(m)
+			after := m.String()
+			for _, e := range mock.expected {
+				if !strings.Contains(after, e) {
+					t.Fatalf("Test '%s' failed: expected '%s' in '%s'", name, e, after)
+				}
+			}
+			for _, e := range mock.absent {
+				if strings.Contains(after, e) {
+					t.Fatalf("Test '%s' failed: expected '%s' not in '%s'", name, e, after)
+				}
+			}
+		})
+	}
+}
+
+func TestMsgsStringCacheCopies(t *testing.T) {
+	m := (&Msgs{}).AddInfo("i1")
+	c := *m
+	m.AddInfo("i2")
+	c.AddInfo("i3")
+	if s := m.String(); !strings.Contains(s, "i2") || strings.Contains(s, "i3") {
+		t.Fatalf("Test failed: expected copy to not share rendering: actual '%s'", s)
+	}
+	if s := c.String(); !strings.Contains(s, "i3") || strings.Contains(s, "i2") {
+		t.Fatalf("Test failed: expected copy to not share rendering: actual '%s'", s)
+	}
+}
+
+func TestMsgsStringCacheConcurrent(t *testing.T) {
+	m := (&Msgs{}).AddInfo("i1")
+	expected := YamlString("msgs", *m)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if s := m.String(); s != expected {
+				t.Errorf("Test failed: expected '%s': actual '%s'", expected, s)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// mockLargeMsgs returns a list of n messages of mixed types
+func mockLargeMsgs(n int) *Msgs {
+	m := &Msgs{}
+	for i := 0; i < n; i++ {
+		switch i % 4 {
+		case 0:
+			m.AddInfo(fmt.Sprintf("info %d", i))
+		case 1:
+			m.AddWarn(fmt.Sprintf("warn %d", i))
+		case 2:
+			m.AddSkip(fmt.Sprintf("skip %d", i))
+		default:
+			m.AddError(fmt.Errorf("error %d", i))
+		}
+	}
+	return m
+}
+
+func BenchmarkMsgsString(b *testing.B) {
+	m := mockLargeMsgs(1000)
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = m.String()
+		}
+	})
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = YamlString("msgs", *m)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"sync"
+	"text/template"
+)
+
+var (
+	catalogsMu sync.RWMutex
+	// catalogs holds the translated description templates by code per
+	// language. A nil template represents a translation that could not
+	// be parsed.
+	catalogs = map[string]map[string]*template.Template{}
+)
+
+// RegisterCatalog registers the provided translations of descriptions
+// for the provided language. A translation is a text/template keyed by
+// the code of the messages it describes that refers to the fields of
+// these messages e.g.
+//
+//	RegisterCatalog("de", map[string]string{"CreateFailed": "{{.kind}} {{.name}} konnte nicht erstellt werden"})
+//
+// Translations are merged with the ones registered earlier for the
+// same language where a translation registered again replaces the
+// earlier one.
+func RegisterCatalog(lang string, catalog map[string]string) {
+	parsed := make(map[string]*template.Template, len(catalog))
+	for code, tmpl := range catalog {
+		t, err := template.New(code).Option("missingkey=error").Parse(tmpl)
+		if err != nil {
+			t = nil
+		}
+		parsed[code] = t
+	}
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	if catalogs[lang] == nil {
+		catalogs[lang] = map[string]*template.Template{}
+	}
+	for code, t := range parsed {
+		catalogs[lang][code] = t
+	}
+}
+
+// translate returns the description of the provided message rendered
+// from its translation in the provided language and true if there is
+// one that renders with the fields of the message
+func translate(lang string, given *msg) (string, bool) {
+	if len(given.Code) == 0 {
+		return "", false
+	}
+	catalogsMu.RLock()
+	t := catalogs[lang][given.Code]
+	catalogsMu.RUnlock()
+	if t == nil {
+		return "", false
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, given.Fields); err != nil {
+		return "", false
+	}
+	return b.String(), true
+}
+
+// Localized returns a copy of the messages whose descriptions are
+// rendered from their translations in the provided language, see
+// RegisterCatalog. A message keeps its original description if it has
+// no code, if there is no translation of its code or if the
+// translation can not be rendered with its fields. Errors are not
+// translated.
+func (m Msgs) Localized(lang string) (l Msgs) {
+	l = m.Clone()
+	for _, item := range l.Items {
+		if item == nil {
+			continue
+		}
+		if desc, ok := translate(lang, item); ok {
+			item.Desc, item.FullDesc, item.lazy = desc, "", nil
+		}
+	}
+	l.touch()
+	return
+}
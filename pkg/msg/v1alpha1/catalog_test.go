@@ -0,0 +1,70 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMsgsLocalized(t *testing.T) {
+	RegisterTemplate("test.l10n.Created", "created {{.kind}} {{.name}}")
+	RegisterTemplate("test.l10n.Deleted", "deleted {{.kind}}")
+	RegisterTemplate("test.l10n.CreateFailed", "failed to create {{.kind}}")
+	RegisterCatalog("test.de", map[string]string{
+		"test.l10n.Created":      "{{.kind}} {{.name}} erstellt",
+		"test.l10n.Deleted":      "{{.kind}} gelöscht",
+		"test.l10n.CreateFailed": "{{.kind}} konnte nicht erstellt werden",
+		"test.l10n.Degraded":     "Pool ist beeinträchtigt",
+	})
+	RegisterCatalog("test.fr", map[string]string{
+		"test.l10n.Created": "{{.kind}} {{.name}} créé",
+		"test.l10n.Deleted": "{{.unknown}} supprimé",
+	})
+	m := (&Msgs{}).
+		AddInfoT("test.l10n.Created", map[string]interface{}{"kind": "pod", "name": "p1"}).
+		AddInfoT("test.l10n.Deleted", map[string]interface{}{"kind": "pvc"}).
+		AddErrorT("test.l10n.CreateFailed", map[string]interface{}{"kind": "pod"}, errors.New("forbidden")).
+		AddWarnCode("test.l10n.Degraded", "pool is degraded").
+		AddSkip("s1")
+
+	tests := map[string]struct {
+		lang     string
+		expected string
+	}{
+		"101": {"test.de", "pod p1 erstellt,pvc gelöscht,pod konnte nicht erstellt werden,Pool ist beeinträchtigt,s1"},
+		"102": {"test.fr", "pod p1 créé,deleted pvc,failed to create pod,pool is degraded,s1"},
+		"103": {"test.unknown", "created pod p1,deleted pvc,failed to create pod,pool is degraded,s1"},
+		"104": {"", "created pod p1,deleted pvc,failed to create pod,pool is degraded,s1"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			l := m.Localized(mock.lang)
+			if actual := strings.Join(l.Descriptions(), ","); actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, actual)
+			}
+			if l.Items[2].Err.Error() != "forbidden" {
+				t.Fatalf("Test '%s' failed: expected error to be retained: actual '%v'", name, l.Items[2].Err)
+			}
+			if actual := strings.Join(m.Descriptions(), ","); actual != tests["103"].expected {
+				t.Fatalf("Test '%s' failed: expected receiver to be unmodified: actual '%s'", name, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sync"
+)
+
+// ErrorCategory represents the kind of an error with respect to
+// retrying the operation that failed
+type ErrorCategory string
+
+const (
+	// TransientError represents an error that may go away on a retry
+	TransientError ErrorCategory = "Transient"
+	// PermanentError represents an error that a retry can not fix
+	PermanentError ErrorCategory = "Permanent"
+	// ConflictError represents an error due to a concurrent change that
+	// may go away on a retry against the latest state
+	ConflictError ErrorCategory = "Conflict"
+	// UnknownError represents an error that was not classified
+	UnknownError ErrorCategory = "Unknown"
+)
+
+// ErrorClassifier returns the category of the provided error or
+// UnknownError if it can not classify it
+type ErrorClassifier func(err error) ErrorCategory
+
+var (
+	classifiersMu sync.RWMutex
+	// classifiers are applied in the order of registration
+	classifiers []ErrorClassifier
+)
+
+// RegisterErrorClassifier registers the provided classifier that
+// categorizes errors added without a category e.g. via AddError. The
+// first registered classifier to return a category other than
+// UnknownError wins.
+func RegisterErrorClassifier(c ErrorClassifier) {
+	if c == nil {
+		return
+	}
+	classifiersMu.Lock()
+	defer classifiersMu.Unlock()
+	classifiers = append(classifiers, c)
+}
+
+// classify returns the category of the provided error as per the
+// registered classifiers or an empty category if none classified it
+func classify(err error) ErrorCategory {
+	if err == nil {
+		return ""
+	}
+	classifiersMu.RLock()
+	defer classifiersMu.RUnlock()
+	for _, c := range classifiers {
+		if cat := c(err); len(cat) != 0 && cat != UnknownError {
+			return cat
+		}
+	}
+	return ""
+}
+
+// ErrorCategory returns the category of this message if it is an error
+// and UnknownError if the error was not classified
+func (m *msg) ErrorCategory() ErrorCategory {
+	if len(m.Category) == 0 {
+		return UnknownError
+	}
+	return m.Category
+}
+
+// AddErrorCategorized appends a new ErrMsg of the provided category.
+// The registered classifiers are not applied.
+func (m *Msgs) AddErrorCategorized(cat ErrorCategory, e error) (u *Msgs) {
+	m.mustNotBeNil("AddErrorCategorized")
+	if e == nil {
+		return m
+	}
+	return m.add(m.newMsg(msg{Mtype: ErrMsg, Desc: e.Error(), Err: e, Category: cat}))
+}
+
+// ErrorsByCategory returns the error messages keyed by their category.
+// Errors that were not classified are keyed by UnknownError.
+func (m Msgs) ErrorsByCategory() (cats map[ErrorCategory]Msgs) {
+	cats = map[ErrorCategory]Msgs{}
+	for _, item := range m.Items {
+		if !IsErr(item) {
+			continue
+		}
+		c := cats[item.ErrorCategory()]
+		c.Items = append(c.Items, item)
+		cats[item.ErrorCategory()] = c
+	}
+	return
+}
+
+// IsRetryable returns true if there is at least one error message and
+// every error message is either a TransientError or a ConflictError
+func (a AllMsgs) IsRetryable() bool {
+	var found bool
+	for _, item := range a[ErrMsg].Items {
+		if item == nil {
+			continue
+		}
+		switch item.ErrorCategory() {
+		case TransientError, ConflictError:
+			found = true
+		default:
+			return false
+		}
+	}
+	return found
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var (
+	errMockTimeout  = errors.New("timeout")
+	errMockConflict = errors.New("object has been modified")
+)
+
+// mockClassifier classifies the mock errors
+func mockClassifier(err error) ErrorCategory {
+	switch {
+	case errors.Is(err, errMockTimeout):
+		return TransientError
+	case strings.Contains(err.Error(), "modified"):
+		return ConflictError
+	default:
+		return UnknownError
+	}
+}
+
+// withMockClassifier registers the mock classifier for the duration of
+// the provided function
+func withMockClassifier(fn func()) {
+	RegisterErrorClassifier(func(error) ErrorCategory { return UnknownError })
+	RegisterErrorClassifier(mockClassifier)
+	defer func() {
+		classifiersMu.Lock()
+		classifiers = nil
+		classifiersMu.Unlock()
+	}()
+	fn()
+}
+
+func TestMsgsErrorsByCategory(t *testing.T) {
+	withMockClassifier(func() {
+		m := (&Msgs{}).AddError(errMockTimeout).AddError(errMockConflict).AddError(errors.New("invalid spec")).
+			AddErrorCategorized(PermanentError, errMockTimeout).AddErrorCode("E1", errMockTimeout).AddWarn("w1")
+		tests := map[string]struct {
+			cat      ErrorCategory
+			expected string
+		}{
+			"101": {TransientError, "timeout,timeout"},
+			"102": {ConflictError, "object has been modified"},
+			"103": {PermanentError, "timeout"},
+			"104": {UnknownError, "invalid spec"},
+		}
+
+		for name, mock := range tests {
+			t.Run(name, func(t *testing.T) {
+				c := m.ErrorsByCategory()[mock.cat]
+				if descs(&c) != mock.expected {
+					t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(&c))
+				}
+			})
+		}
+		if len(m.ErrorsByCategory()) != 4 {
+			t.Fatalf("Test failed: expected 4 categories: actual '%v'", m.ErrorsByCategory())
+		}
+	})
+	if c := (&Msgs{}).AddError(errMockTimeout).Items[0].ErrorCategory(); c != UnknownError {
+		t.Fatalf("Test failed: expected no classification without classifiers: actual '%s'", c)
+	}
+}
+
+func TestAllMsgsIsRetryable(t *testing.T) {
+	withMockClassifier(func() {
+		tests := map[string]struct {
+			m        *Msgs
+			expected bool
+		}{
+			"101": {(&Msgs{}).AddError(errMockTimeout).AddError(errMockTimeout), true},
+			"102": {(&Msgs{}).AddError(errMockTimeout).AddError(errMockConflict).AddWarn("w1"), true},
+			"103": {(&Msgs{}).AddError(errMockTimeout).AddErrorCategorized(PermanentError, errors.New("e1")), false},
+			"104": {(&Msgs{}).AddError(errMockTimeout).AddError(errors.New("unclassified")), false},
+			"105": {(&Msgs{}).AddWarn("w1"), false},
+			"106": {(&Msgs{}).AddErrorCategorized(ConflictError, errors.New("e1")), true},
+		}
+
+		for name, mock := range tests {
+			t.Run(name, func(t *testing.T) {
+				if actual := mock.m.AllMsgs().IsRetryable(); actual != mock.expected {
+					t.Fatalf("Test '%s' failed: expected '%t': actual '%t'", name, mock.expected, actual)
+				}
+			})
+		}
+	})
+}
+
+func TestMsgsCategorySerialization(t *testing.T) {
+	m := (&Msgs{}).AddErrorCategorized(TransientError, errors.New("e1")).AddErrorCategorized("Throttled", errors.New("e2"))
+	if m.GoString() != `(&v1alpha1.Msgs{}).AddErrorCategorized(v1alpha1.TransientError, errors.New("e1")).`+
+		`AddErrorCategorized(v1alpha1.ErrorCategory("Throttled"), errors.New("e2"))` {
+		t.Fatalf("Test failed: expected category in go syntax: actual '%s'", m.GoString())
+	}
+	for name, roundTrip := range mockRoundTrips() {
+		actual, err := roundTrip(m)
+		if err != nil || !m.EqualStrict(*actual) || actual.Items[0].Category != TransientError {
+			t.Fatalf("Test '%s' failed: expected category to round trip: actual '%v' '%v'", name, actual, err)
+		}
+	}
+}
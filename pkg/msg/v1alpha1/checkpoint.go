@@ -0,0 +1,50 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Checkpoint marks a position in a list of messages, see
+// Msgs.Checkpoint
+type Checkpoint struct {
+	epoch uint64
+	n     int
+}
+
+// removed invalidates the checkpoints taken so far since messages were
+// removed or moved
+func (m *Msgs) removed() {
+	m.epoch++
+}
+
+// Checkpoint returns a checkpoint of the messages added so far. Any
+// number of checkpoints may be taken and used independently.
+func (m Msgs) Checkpoint() Checkpoint {
+	return Checkpoint{epoch: m.epoch, n: len(m.Items)}
+}
+
+// SinceCheckpoint returns the non nil messages appended after the
+// provided checkpoint was taken e.g. via Add* or Merge. Messages are
+// shared with the receiver.
+//
+// A checkpoint is invalidated once messages are removed e.g. via Reset,
+// FilterInPlace, PruneOlderThan or a limit. Every message is returned
+// for an invalidated checkpoint as well as for the zero Checkpoint.
+func (m Msgs) SinceCheckpoint(c Checkpoint) (f Msgs) {
+	if c.epoch != m.epoch || c.n > len(m.Items) {
+		c.n = 0
+	}
+	return Msgs{Items: m.Items[c.n:]}.Filter(func(*msg) bool { return true })
+}
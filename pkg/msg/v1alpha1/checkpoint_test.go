@@ -0,0 +1,90 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMsgsSinceCheckpoint(t *testing.T) {
+	m := &Msgs{}
+	zero := m.Checkpoint()
+	m.AddInfo("i1")
+	c1 := m.Checkpoint()
+	m.AddWarn("w1").AddError(errors.New("e1"))
+	c2 := m.Checkpoint()
+	m.Merge((&Msgs{}).AddSkip("s1").AddInfo("i2"))
+	c3 := m.Checkpoint()
+
+	tests := map[string]struct {
+		c        Checkpoint
+		expected string
+	}{
+		"101": {zero, "i1,w1,e1,s1,i2"},
+		"102": {c1, "w1,e1,s1,i2"},
+		"103": {c2, "s1,i2"},
+		"104": {c3, ""},
+		"105": {Checkpoint{}, "i1,w1,e1,s1,i2"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := m.SinceCheckpoint(mock.c)
+			if descs(&f) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(&f))
+			}
+		})
+	}
+	m.AddInfo("i3")
+	if f := m.SinceCheckpoint(c3); descs(&f) != "i3" {
+		t.Fatalf("Test failed: expected 'i3' after an earlier checkpoint was used: actual '%s'", descs(&f))
+	}
+}
+
+func TestMsgsSinceCheckpointInvalidated(t *testing.T) {
+	tests := map[string]struct {
+		remove   func(m *Msgs)
+		expected string
+	}{
+		"101": {func(m *Msgs) { m.Reset() }, ""},
+		"102": {func(m *Msgs) { m.Reset().AddWarn("w9") }, "w9"},
+		"103": {func(m *Msgs) { m.FilterInPlace(IsInfo) }, "i1,i2"},
+		"104": {func(m *Msgs) { m.FilterInPlace(IsNotInfo) }, "w1"},
+		"105": {func(m *Msgs) { m.WithLimit(2, DropOldest) }, "dropped 1 older messages,w1,i2"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := &Msgs{}
+			m.AddInfo("i1").AddWarn("w1")
+			c := m.Checkpoint()
+			m.AddInfo("i2")
+			mock.remove(m)
+			f := m.SinceCheckpoint(c)
+			if descs(&f) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(&f))
+			}
+		})
+	}
+	m := (&Msgs{}).AddInfo("i1")
+	c := m.Checkpoint()
+	m.FilterInPlace(IsInfo).AddInfo("i2")
+	if f := m.SinceCheckpoint(c); descs(&f) != "i2" {
+		t.Fatalf("Test failed: expected a filter that removes nothing to keep the checkpoint: actual '%s'", descs(&f))
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+)
+
+// Formatter renders messages e.g. Msgs.String renders them as yaml
+type Formatter func(m Msgs) string
+
+// JSONFormatter renders messages as json
+func JSONFormatter(m Msgs) string {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err.Error()
+	}
+	return string(b)
+}
+
+// Chunk splits the non nil messages into consecutive chunks of at most
+// the provided number of messages. The whole list is a single chunk if
+// size is not positive. Messages are shared with the receiver; use
+// Clone before chunking if chunks are modified.
+func (m Msgs) Chunk(size int) (chunks []Msgs) {
+	items := m.nonNil()
+	if size <= 0 {
+		size = len(items)
+	}
+	for len(items) != 0 {
+		n := size
+		if n > len(items) {
+			n = len(items)
+		}
+		chunks = append(chunks, Msgs{Items: items[:n:n]})
+		items = items[n:]
+	}
+	return
+}
+
+// ChunkBySerializedSize splits the non nil messages into consecutive
+// chunks whose rendering via the provided formatter is at most maxBytes
+// long. Chunks are packed greedily. A message whose rendering alone is
+// longer than maxBytes becomes a chunk of its own. Messages are shared
+// with the receiver as in Chunk.
+//
+// The rendered length is assumed to grow with the number of messages.
+// Each chunk is found via a doubling followed by a binary search so
+// that messages are rendered O(log n) times per chunk.
+func (m Msgs) ChunkBySerializedSize(maxBytes int, f Formatter) (chunks []Msgs) {
+	if f == nil {
+		f = Msgs.String
+	}
+	items := m.nonNil()
+	fits := func(n int) bool {
+		return len(f(Msgs{Items: items[:n:n]})) <= maxBytes
+	}
+	for len(items) != 0 {
+		// fit is the largest count known to fit and over is the least
+		// count known to not fit
+		fit, over := 1, len(items)+1
+		for n := 2; n < over; n *= 2 {
+			if !fits(n) {
+				over = n
+				break
+			}
+			fit = n
+		}
+		for over-fit > 1 {
+			mid := fit + (over-fit)/2
+			if fits(mid) {
+				fit = mid
+			} else {
+				over = mid
+			}
+		}
+		chunks = append(chunks, Msgs{Items: items[:fit:fit]})
+		items = items[fit:]
+	}
+	return
+}
+
+// nonNil returns the non nil messages. The receiver's items are
+// returned as is if none of them is nil.
+func (m Msgs) nonNil() []*msg {
+	for _, item := range m.Items {
+		if item == nil {
+			return m.Filter(func(*msg) bool { return true }).Items
+		}
+	}
+	return m.Items
+}
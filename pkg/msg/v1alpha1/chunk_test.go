@@ -0,0 +1,114 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+)
+
+// chunkDescs returns the descriptions of every chunk separated by '|'
+func chunkDescs(chunks []Msgs) string {
+	var all []string
+	for _, c := range chunks {
+		all = append(all, descs(&c))
+	}
+	return strings.Join(all, "|")
+}
+
+func TestMsgsChunk(t *testing.T) {
+	tests := map[string]struct {
+		descs    []string
+		size     int
+		expected string
+	}{
+		"101": {[]string{"i1", "i2", "i3", "i4"}, 2, "i1,i2|i3,i4"},
+		"102": {[]string{"i1", "i2", "i3", "i4", "i5"}, 2, "i1,i2|i3,i4|i5"},
+		"103": {[]string{"i1", "i2"}, 5, "i1,i2"},
+		"104": {[]string{"i1", "i2", "i3"}, 1, "i1|i2|i3"},
+		"105": {[]string{"i1", "i2", "i3"}, 0, "i1,i2,i3"},
+		"106": {nil, 2, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := FromStrings(InfoMsg, mock.descs...)
+			m.Items = append(m.Items, nil)
+			chunks := m.Chunk(mock.size)
+			if chunkDescs(chunks) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, chunkDescs(chunks))
+			}
+		})
+	}
+}
+
+func TestMsgsChunkDoesNotClobber(t *testing.T) {
+	m := FromStrings(InfoMsg, "i1", "i2", "i3")
+	chunks := m.Chunk(2)
+	chunks[0].AddInfo("x")
+	if descs(m) != "i1,i2,i3" {
+		t.Fatalf("Test failed: expected 'i1,i2,i3': actual '%s'", descs(m))
+	}
+}
+
+func TestMsgsChunkBySerializedSize(t *testing.T) {
+	joined := func(m Msgs) string { return strings.Join(m.Descriptions(), ",") }
+	tests := map[string]struct {
+		descs    []string
+		max      int
+		expected string
+	}{
+		"101": {[]string{"aa", "bb", "cc", "dd"}, 5, "aa,bb|cc,dd"},
+		"102": {[]string{"aa", "bb", "cc", "dd", "ee"}, 5, "aa,bb|cc,dd|ee"},
+		"103": {[]string{"aa", "bb", "cc"}, 100, "aa,bb,cc"},
+		"104": {[]string{"a", "bbbbbbbbbb", "c", "d"}, 5, "a|bbbbbbbbbb|c,d"},
+		"105": {[]string{"bbbbbbbbbb"}, 5, "bbbbbbbbbb"},
+		"106": {[]string{"a", "bb", "cccc", "d", "e", "f", "gggggg"}, 6, "a,bb|cccc,d|e,f|gggggg"},
+		"107": {nil, 5, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			chunks := FromStrings(InfoMsg, mock.descs...).ChunkBySerializedSize(mock.max, joined)
+			if chunkDescs(chunks) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, chunkDescs(chunks))
+			}
+		})
+	}
+}
+
+func TestMsgsChunkBySerializedSizeJSON(t *testing.T) {
+	m := mockLargeMsgs(500)
+	const max = 2048
+	chunks := m.ChunkBySerializedSize(max, JSONFormatter)
+	var total int
+	for i, c := range chunks {
+		if l := len(JSONFormatter(c)); l > max && len(c.Items) > 1 {
+			t.Fatalf("Test failed: expected chunk %d to be at most %d bytes: actual %d", i, max, l)
+		}
+		if i < len(chunks)-1 {
+			next := Msgs{Items: append(append([]*msg(nil), c.Items...), chunks[i+1].Items[0])}
+			if len(JSONFormatter(next)) <= max {
+				t.Fatalf("Test failed: expected chunk %d to be packed greedily", i)
+			}
+		}
+		total += len(c.Items)
+	}
+	if total != 500 || len(chunks) < 2 {
+		t.Fatalf("Test failed: expected 500 messages in several chunks: actual %d in %d", total, len(chunks))
+	}
+}
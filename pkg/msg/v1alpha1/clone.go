@@ -0,0 +1,90 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// clone returns a deep copy of the provided message. Errors are shared
+// since they can not be copied in general.
+func (m *msg) clone() *msg {
+	if m == nil {
+		return nil
+	}
+	c := *m
+	c.Labels = mergeLabels(nil, m.Labels)
+	c.Fields = copyFields(m.Fields)
+	c.Object = objectPtr(objectOf(m))
+	c.Stack = append([]string(nil), m.Stack...)
+	if m.Time != nil {
+		t := *m.Time
+		c.Time = &t
+	}
+	if m.LastSeen != nil {
+		t := *m.LastSeen
+		c.LastSeen = &t
+	}
+	return &c
+}
+
+// cloneItems returns deep copies of the provided messages
+func cloneItems(items []*msg) []*msg {
+	if items == nil {
+		return nil
+	}
+	c := make([]*msg, len(items))
+	for i, item := range items {
+		c[i] = item.clone()
+	}
+	return c
+}
+
+// Clone returns a deep copy of the messages along with their options
+// such that changes to either do not affect the other. Errors are
+// shared between the copies.
+func (m Msgs) Clone() (c Msgs) {
+	c = m
+	c.Items = cloneItems(m.Items)
+	c.hooks = append([]AddHook(nil), m.hooks...)
+	c.labels = mergeLabels(nil, m.labels)
+	c.sampler = m.sampler.clone()
+	if m.arena != nil {
+		// the copies must not fill the same chunk
+		c.arena = &msgArena{}
+	}
+	// a clone of a scoped list is detached from the parent
+	c.scope = nil
+	c.cache = nil
+	c.touch()
+	return
+}
+
+// MergeCopy merges deep copies of passed messages with receiver
+// messages. Unlike Merge, later changes to messages of either list do
+// not affect the other.
+func (m *Msgs) MergeCopy(s *Msgs) (u *Msgs) {
+	m.mustNotBeNil("MergeCopy")
+	if s == nil {
+		return m
+	}
+	return m.Merge(&Msgs{Items: cloneItems(s.Items)})
+}
+
+// FilterCopy is same as Filter but returns deep copies of the matching
+// messages. It is meant for callers that change the results.
+func (m Msgs) FilterCopy(p msgPredicate) (f Msgs) {
+	f = m.Filter(p)
+	f.Items = cloneItems(f.Items)
+	return
+}
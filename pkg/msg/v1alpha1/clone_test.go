@@ -0,0 +1,81 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMsgsClone(t *testing.T) {
+	src := NewMsgs(WithTimestamps(), WithSource("pool")).WithLabels(map[string]string{"a": "1"}).
+		AddInfo("i1").AddError(errors.New("e1"))
+	src.Items = append(src.Items, nil)
+	before := src.String()
+
+	c := src.Clone()
+	if !c.EqualStrict(*src) {
+		t.Fatalf("Test failed: expected clone to be equal: actual '%s'", c)
+	}
+	c.Items[0].Desc = "changed"
+	c.Items[0].Labels["a"] = "2"
+	*c.Items[0].Time = c.Items[0].Time.Add(1)
+	c.AddWarn("w1")
+	if src.String() != before || len(src.Items) != 3 {
+		t.Fatalf("Test failed: expected source to be untouched: actual '%s'", src)
+	}
+	if c.Items[1].Err != src.Items[1].Err {
+		t.Fatalf("Test failed: expected errors to be shared")
+	}
+	if c.Items[3].Source != "pool" || c.Items[3].Labels["a"] != "1" {
+		t.Fatalf("Test failed: expected clone to retain options: actual '%#v'", c.Items[3])
+	}
+}
+
+func TestMsgsMergeCopy(t *testing.T) {
+	other := (&Msgs{}).AddInfo("i1")
+	aliased := (&Msgs{}).Merge(other)
+	copied := (&Msgs{}).MergeCopy(other)
+	aliased.Items[0].Desc = "changed"
+	if other.Items[0].Desc != "changed" {
+		t.Fatalf("Test failed: expected merge to alias the merged messages")
+	}
+	copied.Items[0].Desc = "copied"
+	if other.Items[0].Desc != "changed" || copied.Items[0].Desc != "copied" {
+		t.Fatalf("Test failed: expected merge copy to not alias: actual '%s'", other)
+	}
+	if m := (&Msgs{}).MergeCopy(nil); len(m.Items) != 0 {
+		t.Fatalf("Test failed: expected merge copy of nil to be a no-op")
+	}
+}
+
+func TestMsgsFilterCopy(t *testing.T) {
+	m := (&Msgs{}).AddInfo("i1").AddWarn("w1")
+	aliased := m.Filter(IsWarn)
+	copied := m.FilterCopy(IsWarn)
+	copied.Items[0].Desc = "copied"
+	if m.Items[1].Desc != "w1" {
+		t.Fatalf("Test failed: expected filter copy to not alias: actual '%s'", m)
+	}
+	aliased.Items[0].Desc = "changed"
+	if m.Items[1].Desc != "changed" {
+		t.Fatalf("Test failed: expected filter to alias the filtered messages")
+	}
+	if f := m.FilterCopy(IsErr); f.Items != nil {
+		t.Fatalf("Test failed: expected no matches: actual '%s'", f)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// CodeIs returns a predicate that is true for messages having the
+// provided code
+func CodeIs(code string) msgPredicate {
+	return func(given *msg) bool {
+		if given == nil {
+			return false
+		}
+		return given.Code == code
+	}
+}
+
+// AddErrorCode appends a new ErrMsg to messages and initializes it
+// with the passed code and error
+func (m *Msgs) AddErrorCode(code string, e error) (u *Msgs) {
+	m.mustNotBeNil("AddErrorCode")
+	if e == nil {
+		return m
+	}
+	return m.add(m.newMsg(msg{Mtype: ErrMsg, Desc: e.Error(), Err: e, Code: code}))
+}
+
+// AddWarnCode appends a new WarnMsg to messages and initializes it
+// with the passed code and description
+func (m *Msgs) AddWarnCode(code, w string) (u *Msgs) {
+	m.mustNotBeNil("AddWarnCode")
+	if len(w) == 0 {
+		return m
+	}
+	return m.add(m.newMsg(msg{Mtype: WarnMsg, Desc: w, Code: code}))
+}
+
+// HasCode returns true if at least one message has the provided code
+func (m Msgs) HasCode(code string) bool {
+	return len(m.Filter(CodeIs(code)).Items) != 0
+}
+
+// HasCode returns true if at least one message has the provided code
+func (a AllMsgs) HasCode(code string) bool {
+	for _, m := range a {
+		if m.HasCode(code) {
+			return true
+		}
+	}
+	return false
+}
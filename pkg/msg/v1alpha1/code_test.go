@@ -0,0 +1,76 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+)
+
+func TestMsgsCodeIs(t *testing.T) {
+	m := (&Msgs{}).
+		AddErrorCode("PoolNotFound", errors.New("e1")).
+		AddWarnCode("PoolNotFound", "w1").
+		AddWarnCode("PoolDegraded", "w2").
+		AddInfo("i1")
+	m.Merge((&Msgs{}).AddErrorCode("PoolNotFound", errors.New("e2")))
+
+	tests := map[string]struct {
+		code          string
+		expectedDescs string
+		hasCode       bool
+	}{
+		"101": {"PoolNotFound", "e1,w1,e2", true},
+		"102": {"PoolDegraded", "w2", true},
+		"103": {"Unknown", "", false},
+		"104": {"", "i1", true},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := m.Filter(CodeIs(mock.code))
+			if descs(&f) != mock.expectedDescs {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expectedDescs, descs(&f))
+			}
+			if m.HasCode(mock.code) != mock.hasCode {
+				t.Fatalf("Test '%s' failed: expected has code %t: actual %t", name, mock.hasCode, !mock.hasCode)
+			}
+			if m.AllMsgs().HasCode(mock.code) != mock.hasCode {
+				t.Fatalf("Test '%s' failed: expected all msgs has code %t: actual %t", name, mock.hasCode, !mock.hasCode)
+			}
+		})
+	}
+}
+
+func TestMsgsCodeYaml(t *testing.T) {
+	m := (&Msgs{}).AddWarnCode("PoolDegraded", "w1").AddWarn("w2")
+	s := m.String()
+	if !strings.Contains(s, "code: PoolDegraded") {
+		t.Fatalf("Test failed: expected code in yaml: actual '%s'", s)
+	}
+
+	var actual Msgs
+	if err := yaml.Unmarshal([]byte(s), &actual); err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	if len(actual.Items) != 2 || actual.Items[0].Code != "PoolDegraded" || actual.Items[1].Code != "" {
+		t.Fatalf("Test failed: expected codes to survive yaml round trip: actual '%s'", actual)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sync"
+)
+
+// Collector fans in messages sent from several goroutines into a single
+// list of messages. Messages are appended by a background goroutine in
+// the order they are received.
+//
+// The underlying channel is not exposed, since sending to it after close
+// would panic. Send and its helpers instead return false once the
+// collector is closed.
+type Collector struct {
+	ch   chan *Msgs
+	done chan struct{}
+
+	// mu guards closed; senders hold it for reading while sending so
+	// that the channel is never closed in the middle of a send
+	mu     sync.RWMutex
+	closed bool
+
+	msgs Msgs
+}
+
+// NewCollector returns a new collector whose channel can buffer the
+// provided number of pending sends
+func NewCollector(buffer int) *Collector {
+	if buffer < 0 {
+		buffer = 0
+	}
+	c := &Collector{
+		ch:   make(chan *Msgs, buffer),
+		done: make(chan struct{}),
+	}
+	go c.collect()
+	return c
+}
+
+// collect appends every received list of messages till the channel is
+// closed
+func (c *Collector) collect() {
+	defer close(c.done)
+	for m := range c.ch {
+		c.msgs.Merge(m)
+	}
+}
+
+// Send sends the provided messages to the collector. It returns false
+// if the collector was already closed, in which case the messages are
+// discarded.
+func (c *Collector) Send(m *Msgs) (ok bool) {
+	if m == nil {
+		return true
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return false
+	}
+	c.ch <- m
+	return true
+}
+
+// Info sends a new InfoMsg to the collector
+func (c *Collector) Info(i string) (ok bool) {
+	return c.Send((&Msgs{}).AddInfo(i))
+}
+
+// Warn sends a new WarnMsg to the collector
+func (c *Collector) Warn(w string) (ok bool) {
+	return c.Send((&Msgs{}).AddWarn(w))
+}
+
+// Skip sends a new SkipMsg to the collector
+func (c *Collector) Skip(s string) (ok bool) {
+	return c.Send((&Msgs{}).AddSkip(s))
+}
+
+// Error sends a new ErrMsg to the collector
+func (c *Collector) Error(e error) (ok bool) {
+	return c.Send((&Msgs{}).AddError(e))
+}
+
+// CloseAndDrain closes the collector, waits for all pending sends to be
+// collected and returns the collected messages. It is safe to invoke it
+// more than once; every invocation returns the same messages.
+func (c *Collector) CloseAndDrain() (m Msgs) {
+	c.mu.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.ch)
+	}
+	c.mu.Unlock()
+	<-c.done
+	return Msgs{Items: append([]*msg(nil), c.msgs.Items...)}
+}
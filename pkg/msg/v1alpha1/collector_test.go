@@ -0,0 +1,105 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestCollectorConcurrentSends(t *testing.T) {
+	const workers, perWorker = 50, 100
+
+	c := NewCollector(10)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				switch i % 4 {
+				case 0:
+					c.Info(fmt.Sprintf("info %d-%d", w, i))
+				case 1:
+					c.Warn(fmt.Sprintf("warn %d-%d", w, i))
+				case 2:
+					c.Skip(fmt.Sprintf("skip %d-%d", w, i))
+				default:
+					c.Error(fmt.Errorf("error %d-%d", w, i))
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	m := c.CloseAndDrain()
+	if len(m.Items) != workers*perWorker {
+		t.Fatalf("Test failed: expected %d messages: actual %d", workers*perWorker, len(m.Items))
+	}
+	for _, f := range []Msgs{m.Infos(), m.Warns(), m.Skips(), m.Errors()} {
+		if len(f.Items) != workers*perWorker/4 {
+			t.Fatalf("Test failed: expected %d messages per type: actual %d", workers*perWorker/4, len(f.Items))
+		}
+	}
+}
+
+func TestCollectorCloseAndDrain(t *testing.T) {
+	c := NewCollector(0)
+	if !c.Send((&Msgs{}).AddInfo("i1").AddWarn("w1")) {
+		t.Fatalf("Test failed: expected send before close to succeed")
+	}
+	if !c.Send(nil) {
+		t.Fatalf("Test failed: expected nil send to be ignored")
+	}
+
+	first := c.CloseAndDrain()
+	if len(first.Items) != 2 {
+		t.Fatalf("Test failed: expected 2 messages: actual %d", len(first.Items))
+	}
+
+	// send after close is discarded
+	if c.Error(errors.New("late")) {
+		t.Fatalf("Test failed: expected send after close to fail")
+	}
+
+	// double close returns the same messages
+	second := c.CloseAndDrain()
+	if len(second.Items) != len(first.Items) {
+		t.Fatalf("Test failed: expected %d messages on second close: actual %d", len(first.Items), len(second.Items))
+	}
+}
+
+func TestCollectorConcurrentClose(t *testing.T) {
+	c := NewCollector(1)
+	var wg sync.WaitGroup
+	for w := 0; w < 10; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				c.Info("i")
+			}
+		}()
+	}
+	m := c.CloseAndDrain()
+	wg.Wait()
+	if again := c.CloseAndDrain(); len(again.Items) != len(m.Items) {
+		t.Fatalf("Test failed: expected no messages collected after close: actual %d more", len(again.Items)-len(m.Items))
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// MergeAll appends the messages of all the provided lists in order as
+// done by Merge. Nil lists are skipped. Unless a limit is set, the
+// storage of the receiver grows at most once.
+func (m *Msgs) MergeAll(others ...*Msgs) (u *Msgs) {
+	m.mustNotBeNil("MergeAll")
+	if m.limit.max > 0 {
+		for _, o := range others {
+			m.Merge(o)
+		}
+		return m
+	}
+	var n int
+	for _, o := range others {
+		if o != nil {
+			n += len(o.Items)
+		}
+	}
+	if n == 0 {
+		return m
+	}
+	defer m.touch()
+	if free := cap(m.Items) - len(m.Items); free < n {
+		grown := make([]*msg, len(m.Items), len(m.Items)+n)
+		copy(grown, m.Items)
+		m.Items = grown
+	}
+	for _, o := range others {
+		if o == nil {
+			continue
+		}
+		m.Items = append(m.Items, o.Items...)
+		for _, item := range o.Items {
+			if item != nil {
+				m.fire(item)
+			}
+		}
+	}
+	return m
+}
+
+// Combine returns a new list holding the messages of all the provided
+// lists in order, see MergeAll
+func Combine(others ...*Msgs) (m *Msgs) {
+	return (&Msgs{}).MergeAll(others...)
+}
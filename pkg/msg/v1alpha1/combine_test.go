@@ -0,0 +1,96 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMsgsMergeAll(t *testing.T) {
+	tests := map[string]struct {
+		m        *Msgs
+		others   []*Msgs
+		expected string
+	}{
+		"101": {(&Msgs{}).AddInfo("i1"), []*Msgs{(&Msgs{}).AddWarn("w1"), (&Msgs{}).AddSkip("s1").AddInfo("i2")}, "i1,w1,s1,i2"},
+		"102": {&Msgs{}, []*Msgs{nil, (&Msgs{}).AddWarn("w1"), nil}, "w1"},
+		"103": {(&Msgs{}).AddInfo("i1"), []*Msgs{{}, {}}, "i1"},
+		"104": {(&Msgs{}).AddInfo("i1"), nil, "i1"},
+		"105": {NewMsgs(WithCapacity(8)).AddInfo("i1"), []*Msgs{(&Msgs{}).AddError(errors.New("e1"))}, "i1,e1"},
+		"106": {(&Msgs{}).WithLimit(2, DropNewest), []*Msgs{(&Msgs{}).AddInfo("i1"), (&Msgs{}).AddInfo("i2").AddInfo("i3")},
+			"i1,i2,dropped 1 newer messages"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			before := mock.m.String()
+			u := mock.m.MergeAll(mock.others...)
+			if u != mock.m || descs(u) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(u))
+			}
+			if mock.expected != "i1" && u.String() == before {
+				t.Fatalf("Test '%s' failed: expected cached string to be refreshed", name)
+			}
+		})
+	}
+}
+
+func TestMsgsMergeAllGrowsOnce(t *testing.T) {
+	var fired []string
+	m := (&Msgs{}).AddInfo("i0").OnAdd(func(t MsgType, desc string, err error) {
+		fired = append(fired, desc)
+	})
+	m.MergeAll(FromStrings(InfoMsg, "i1", "i2"), FromStrings(WarnMsg, "w1"))
+	if len(m.Items) != 4 || cap(m.Items) != 4 {
+		t.Fatalf("Test failed: expected 4 messages with exact capacity: actual %d %d", len(m.Items), cap(m.Items))
+	}
+	if fmt.Sprint(fired) != "[i1 i2 w1]" {
+		t.Fatalf("Test failed: expected hooks to fire in order: actual '%v'", fired)
+	}
+	if c := Combine(nil, FromStrings(SkipMsg, "s1"), m); descs(c) != "s1,i0,i1,i2,w1" || descs(Combine()) != "" {
+		t.Fatalf("Test failed: expected combined 's1,i0,i1,i2,w1': actual '%s'", descs(c))
+	}
+}
+
+// mockReplicaMsgs returns n lists of m messages each
+func mockReplicaMsgs(n, m int) (lists []*Msgs) {
+	for i := 0; i < n; i++ {
+		lists = append(lists, mockLargeMsgs(m))
+	}
+	return
+}
+
+func BenchmarkMsgsMergeAll(b *testing.B) {
+	lists := mockReplicaMsgs(100, 100)
+	b.Run("Merge", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m := &Msgs{}
+			for _, l := range lists {
+				m.Merge(l)
+			}
+		}
+	})
+	b.Run("MergeAll", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			(&Msgs{}).MergeAll(lists...)
+		}
+	})
+}
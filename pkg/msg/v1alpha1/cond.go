@@ -0,0 +1,98 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+)
+
+// AddInfoIf appends a new InfoMsg if the provided condition is true
+func (m *Msgs) AddInfoIf(cond bool, i string) (u *Msgs) {
+	m.mustNotBeNil("AddInfoIf")
+	if !cond {
+		return m
+	}
+	return m.AddInfo(i)
+}
+
+// AddWarnIf appends a new WarnMsg if the provided condition is true
+func (m *Msgs) AddWarnIf(cond bool, w string) (u *Msgs) {
+	m.mustNotBeNil("AddWarnIf")
+	if !cond {
+		return m
+	}
+	return m.AddWarn(w)
+}
+
+// AddSkipIf appends a new SkipMsg if the provided condition is true
+func (m *Msgs) AddSkipIf(cond bool, s string) (u *Msgs) {
+	m.mustNotBeNil("AddSkipIf")
+	if !cond {
+		return m
+	}
+	return m.AddSkip(s)
+}
+
+// AddErrorIf appends a new ErrMsg if the provided condition is true
+func (m *Msgs) AddErrorIf(cond bool, e error) (u *Msgs) {
+	m.mustNotBeNil("AddErrorIf")
+	if !cond {
+		return m
+	}
+	return m.AddError(e)
+}
+
+// AddInfoIff appends a new InfoMsg formatted as per the provided format
+// specifier if the provided condition is true
+func (m *Msgs) AddInfoIff(cond bool, format string, args ...interface{}) (u *Msgs) {
+	m.mustNotBeNil("AddInfoIff")
+	if !cond {
+		return m
+	}
+	return m.AddInfo(fmt.Sprintf(format, args...))
+}
+
+// AddWarnIff appends a new WarnMsg formatted as per the provided format
+// specifier if the provided condition is true
+func (m *Msgs) AddWarnIff(cond bool, format string, args ...interface{}) (u *Msgs) {
+	m.mustNotBeNil("AddWarnIff")
+	if !cond {
+		return m
+	}
+	return m.AddWarn(fmt.Sprintf(format, args...))
+}
+
+// AddSkipIff appends a new SkipMsg formatted as per the provided format
+// specifier if the provided condition is true
+func (m *Msgs) AddSkipIff(cond bool, format string, args ...interface{}) (u *Msgs) {
+	m.mustNotBeNil("AddSkipIff")
+	if !cond {
+		return m
+	}
+	return m.AddSkip(fmt.Sprintf(format, args...))
+}
+
+// AddErrorIff appends a new ErrMsg formatted as per the provided format
+// specifier if the provided condition is true. The error wraps any
+// error argument as per fmt.Errorf.
+func (m *Msgs) AddErrorIff(cond bool, format string, args ...interface{}) (u *Msgs) {
+	m.mustNotBeNil("AddErrorIff")
+	if !cond {
+		return m
+	}
+	return m.AddError(fmt.Errorf(format, args...))
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMsgsAddIf(t *testing.T) {
+	errNoPool := errors.New("no pool")
+	tests := map[string]struct {
+		cond     bool
+		add      func(m *Msgs, cond bool) *Msgs
+		expected string
+	}{
+		"101": {true, func(m *Msgs, c bool) *Msgs { return m.AddInfoIf(c, "i1") }, "info:i1"},
+		"102": {false, func(m *Msgs, c bool) *Msgs { return m.AddInfoIf(c, "i1") }, ""},
+		"103": {true, func(m *Msgs, c bool) *Msgs { return m.AddWarnIf(c, "w1") }, "warn:w1"},
+		"104": {false, func(m *Msgs, c bool) *Msgs { return m.AddWarnIf(c, "w1") }, ""},
+		"105": {true, func(m *Msgs, c bool) *Msgs { return m.AddSkipIf(c, "s1") }, "skip:s1"},
+		"106": {false, func(m *Msgs, c bool) *Msgs { return m.AddSkipIf(c, "s1") }, ""},
+		"107": {true, func(m *Msgs, c bool) *Msgs { return m.AddErrorIf(c, errNoPool) }, "error:no pool"},
+		"108": {false, func(m *Msgs, c bool) *Msgs { return m.AddErrorIf(c, errNoPool) }, ""},
+		"109": {true, func(m *Msgs, c bool) *Msgs { return m.AddErrorIf(c, nil) }, ""},
+		"110": {true, func(m *Msgs, c bool) *Msgs { return m.AddInfoIff(c, "size %d", 10) }, "info:size 10"},
+		"111": {false, func(m *Msgs, c bool) *Msgs { return m.AddInfoIff(c, "size %d", 10) }, ""},
+		"112": {true, func(m *Msgs, c bool) *Msgs { return m.AddWarnIff(c, "size %d > %d", 20, 10) }, "warn:size 20 > 10"},
+		"113": {false, func(m *Msgs, c bool) *Msgs { return m.AddWarnIff(c, "size %d > %d", 20, 10) }, ""},
+		"114": {true, func(m *Msgs, c bool) *Msgs { return m.AddSkipIff(c, "pool %s", "p1") }, "skip:pool p1"},
+		"115": {false, func(m *Msgs, c bool) *Msgs { return m.AddSkipIff(c, "pool %s", "p1") }, ""},
+		"116": {true, func(m *Msgs, c bool) *Msgs { return m.AddErrorIff(c, "create: %w", errNoPool) }, "error:create: no pool"},
+		"117": {false, func(m *Msgs, c bool) *Msgs { return m.AddErrorIff(c, "create: %w", errNoPool) }, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := &Msgs{}
+			if u := mock.add(m, mock.cond); u != m {
+				t.Fatalf("Test '%s' failed: expected receiver to be returned", name)
+			}
+			var actual string
+			if len(m.Items) == 1 {
+				actual = string(m.Items[0].Mtype) + ":" + m.Items[0].Desc
+			}
+			if len(m.Items) > 1 || actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, m)
+			}
+		})
+	}
+	m := (&Msgs{}).AddErrorIff(true, "create: %w", errNoPool)
+	if !errors.Is(m.Items[0].Err, errNoPool) {
+		t.Fatalf("Test failed: expected error to wrap '%s'", errNoPool)
+	}
+}
+
+func TestMsgsAddIfChain(t *testing.T) {
+	size, max := 20, 10
+	var pool *Msgs
+	m := (&Msgs{}).AddWarnIf(size > max, "oversized").AddErrorIf(pool == nil, errors.New("no pool")).
+		AddInfoIf(size < max, "fits").AddSkipIff(pool != nil, "pool %v", pool)
+	if descs(m) != "oversized,no pool" {
+		t.Fatalf("Test failed: expected 'oversized,no pool': actual '%s'", descs(m))
+	}
+}
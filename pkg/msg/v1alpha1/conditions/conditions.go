@@ -0,0 +1,111 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conditions derives Kubernetes style status conditions from
+// messages
+package conditions
+
+import (
+	"fmt"
+	"time"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition mirrors metav1.Condition which is not available in the
+// vendored version of apimachinery
+type Condition struct {
+	Type               string                 `json:"type"`
+	Status             corev1.ConditionStatus `json:"status"`
+	ObservedGeneration int64                  `json:"observedGeneration,omitempty"`
+	LastTransitionTime metav1.Time            `json:"lastTransitionTime"`
+	Reason             string                 `json:"reason"`
+	Message            string                 `json:"message"`
+}
+
+// Config controls how messages are mapped to conditions. Reasons are
+// used only if the message that sets the condition has no code.
+type Config struct {
+	DegradedType      string // condition type set if errors are present
+	DegradedReason    string // reason of a true degraded condition
+	WarningType       string // condition type set if warnings are present
+	WarningReason     string // reason of a true warning condition
+	ProgressingType   string // condition type set if progress is present
+	ProgressingReason string // reason of the progressing condition
+	HealthyReason     string // reason of a false condition
+}
+
+// DefaultConfig maps errors, warnings and progress to 'Degraded',
+// 'Warning' and 'Progressing' conditions respectively
+var DefaultConfig = Config{
+	DegradedType:      "Degraded",
+	DegradedReason:    "Error",
+	WarningType:       "Warning",
+	WarningReason:     "Warning",
+	ProgressingType:   "Progressing",
+	ProgressingReason: "InProgress",
+	HealthyReason:     "AsExpected",
+}
+
+// ToConditions returns the conditions derived from the provided
+// messages using DefaultConfig
+func ToConditions(m msg.Msgs, observedGeneration int64, now time.Time) []Condition {
+	return ToConditionsWithConfig(m, observedGeneration, now, DefaultConfig)
+}
+
+// ToConditionsWithConfig returns the conditions derived from the
+// provided messages. The degraded and warning conditions are always
+// returned and are true if at least one error or warning respectively
+// is present; the message of such a condition is the description of
+// the first error or warning. The progressing condition is returned
+// only if progress messages are present and holds the latest progress.
+func ToConditionsWithConfig(m msg.Msgs, observedGeneration int64, now time.Time, c Config) []Condition {
+	at := metav1.NewTime(now)
+	condition := func(ctype string, first msg.Msgs, reason string) Condition {
+		cond := Condition{
+			Type:               ctype,
+			Status:             corev1.ConditionFalse,
+			ObservedGeneration: observedGeneration,
+			LastTransitionTime: at,
+			Reason:             c.HealthyReason,
+		}
+		if len(first.Items) == 0 {
+			return cond
+		}
+		cond.Status, cond.Reason, cond.Message = corev1.ConditionTrue, reason, first.Items[0].Description()
+		if len(first.Items[0].Code) != 0 {
+			cond.Reason = first.Items[0].Code
+		}
+		return cond
+	}
+	conds := []Condition{
+		condition(c.DegradedType, m.Errors(), c.DegradedReason),
+		condition(c.WarningType, m.Filter(msg.IsWarn), c.WarningReason),
+	}
+	if desc, pct, ok := m.LatestProgress(); ok {
+		conds = append(conds, Condition{
+			Type:               c.ProgressingType,
+			Status:             corev1.ConditionTrue,
+			ObservedGeneration: observedGeneration,
+			LastTransitionTime: at,
+			Reason:             c.ProgressingReason,
+			Message:            fmt.Sprintf("%s (%g%%)", desc, pct),
+		})
+	}
+	return conds
+}
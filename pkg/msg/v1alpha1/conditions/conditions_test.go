@@ -0,0 +1,80 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestToConditions(t *testing.T) {
+	now := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	at := metav1.NewTime(now)
+	healthy := func(ctype string) Condition {
+		return Condition{Type: ctype, Status: corev1.ConditionFalse, ObservedGeneration: 3,
+			LastTransitionTime: at, Reason: "AsExpected"}
+	}
+	tests := map[string]struct {
+		msgs     *msg.Msgs
+		expected []Condition
+	}{
+		"101": {&msg.Msgs{}, []Condition{healthy("Degraded"), healthy("Warning")}},
+		"102": {(&msg.Msgs{}).AddInfo("i1").AddSkip("s1"), []Condition{healthy("Degraded"), healthy("Warning")}},
+		"103": {(&msg.Msgs{}).AddWarn("w1").AddError(errors.New("e1")).AddError(errors.New("e2")).
+			AddProgress("copying", 20).AddWarnCode("ReplicaLagging", "w2").AddProgress("copying", 40),
+			[]Condition{
+				{Type: "Degraded", Status: corev1.ConditionTrue, ObservedGeneration: 3, LastTransitionTime: at,
+					Reason: "Error", Message: "e1"},
+				{Type: "Warning", Status: corev1.ConditionTrue, ObservedGeneration: 3, LastTransitionTime: at,
+					Reason: "Warning", Message: "w1"},
+				{Type: "Progressing", Status: corev1.ConditionTrue, ObservedGeneration: 3, LastTransitionTime: at,
+					Reason: "InProgress", Message: "copying (40%)"},
+			}},
+		"104": {(&msg.Msgs{}).AddErrorCode("PoolDown", errors.New("e1")).AddWarnCode("ReplicaLagging", "w1"),
+			[]Condition{
+				{Type: "Degraded", Status: corev1.ConditionTrue, ObservedGeneration: 3, LastTransitionTime: at,
+					Reason: "PoolDown", Message: "e1"},
+				{Type: "Warning", Status: corev1.ConditionTrue, ObservedGeneration: 3, LastTransitionTime: at,
+					Reason: "ReplicaLagging", Message: "w1"},
+			}},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := ToConditions(*mock.msgs, 3, now)
+			if !reflect.DeepEqual(actual, mock.expected) {
+				t.Fatalf("Test '%s' failed: expected '%+v': actual '%+v'", name, mock.expected, actual)
+			}
+		})
+	}
+}
+
+func TestToConditionsWithConfig(t *testing.T) {
+	c := Config{DegradedType: "Ready", DegradedReason: "Failed", WarningType: "Healthy",
+		WarningReason: "Degraded", ProgressingType: "Syncing", ProgressingReason: "Syncing", HealthyReason: "OK"}
+	conds := ToConditionsWithConfig(*(&msg.Msgs{}).AddError(errors.New("e1")).AddProgress("sync", 10), 1, time.Now(), c)
+	if len(conds) != 3 || conds[0].Type != "Ready" || conds[0].Reason != "Failed" ||
+		conds[1].Type != "Healthy" || conds[1].Reason != "OK" || conds[2].Type != "Syncing" {
+		t.Fatalf("Test failed: expected custom condition types and reasons: actual '%+v'", conds)
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+)
+
+// ConflictPolicy represents the way MergeWithPolicy resolves a merged
+// message that conflicts with a message already present
+type ConflictPolicy int
+
+const (
+	// KeepFirst keeps the message already present and drops the merged
+	// one
+	KeepFirst ConflictPolicy = iota
+	// KeepLast replaces the message already present with the merged one
+	KeepLast
+	// KeepBoth keeps both the messages as done by Merge
+	KeepBoth
+	// PreferError keeps the more severe of the messages e.g. an ErrMsg
+	// over a WarnMsg; the message already present is kept if both are
+	// equally severe
+	PreferError
+)
+
+// conflictPolicyNames holds the names of the conflict policies
+var conflictPolicyNames = map[ConflictPolicy]string{
+	KeepFirst:   "KeepFirst",
+	KeepLast:    "KeepLast",
+	KeepBoth:    "KeepBoth",
+	PreferError: "PreferError",
+}
+
+// String is an implementation of Stringer interface
+func (p ConflictPolicy) String() string {
+	if name, found := conflictPolicyNames[p]; found {
+		return name
+	}
+	return fmt.Sprintf("ConflictPolicy(%d)", int(p))
+}
+
+// conflictKey identifies messages that conflict with each other
+type conflictKey struct {
+	id    string
+	code  string
+	mtype MsgType
+	desc  string
+}
+
+// conflictKeyOf returns the key of the provided message i.e. its code
+// if set and its type along with its description otherwise. A message
+// with an id is keyed by its id, its type and its code or description
+// since ids are unique per list only e.g. as generated via WithIDs.
+func conflictKeyOf(given *msg) conflictKey {
+	switch {
+	case len(given.ID) != 0 && len(given.Code) != 0:
+		return conflictKey{id: given.ID, mtype: given.Mtype, code: given.Code}
+	case len(given.ID) != 0:
+		return conflictKey{id: given.ID, mtype: given.Mtype, desc: given.Description()}
+	case len(given.Code) != 0:
+		return conflictKey{code: given.Code}
+	default:
+		return conflictKey{mtype: given.Mtype, desc: given.Description()}
+	}
+}
+
+// keepsMerged returns true if the provided policy resolves a conflict
+// between the provided messages in favour of the merged one
+func (p ConflictPolicy) keepsMerged(present, merged *msg) bool {
+	switch p {
+	case KeepLast:
+		return true
+	case PreferError:
+		return Severity(merged.Mtype) > Severity(present.Mtype)
+	default:
+		return false
+	}
+}
+
+// MergeWithPolicy merges passed messages with receiver messages such
+// that a merged message that conflicts with a message already present
+// is resolved as per the provided policy. Messages conflict if they
+// have the same code, else the same type and description. Messages
+// with ids conflict only if they have the same id and type as well. A message that replaces another one takes its place.
+// An InfoMsg holding the count of resolved conflicts is added if there
+// were any. Registered add hooks are fired for appended messages only.
+func (m *Msgs) MergeWithPolicy(s *Msgs, p ConflictPolicy) (u *Msgs) {
+	m.mustNotBeNil("MergeWithPolicy")
+	if s == nil {
+		return m
+	}
+	present := map[conflictKey]int{}
+	for i, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		k := conflictKeyOf(item)
+		if _, found := present[k]; !found {
+			present[k] = i
+		}
+	}
+	var (
+		appended  []*msg
+		merged    = map[conflictKey]int{}
+		conflicts int
+		replaced  bool
+	)
+	for _, item := range s.Items {
+		if item == nil {
+			continue
+		}
+		k := conflictKeyOf(item)
+		i, inPresent := present[k]
+		j, inMerged := merged[k]
+		if !inPresent && !inMerged {
+			merged[k] = len(appended)
+			appended = append(appended, item)
+			continue
+		}
+		conflicts++
+		switch {
+		case p == KeepBoth:
+			appended = append(appended, item)
+		case inPresent && p.keepsMerged(m.Items[i], item):
+			m.Items[i], replaced = item, true
+		case inMerged && p.keepsMerged(appended[j], item):
+			appended[j] = item
+		}
+	}
+	if replaced {
+		m.removed()
+		m.touch()
+	}
+	m.Merge(&Msgs{Items: appended})
+	if conflicts != 0 {
+		m.add(m.newMsg(msg{Mtype: InfoMsg, Desc: fmt.Sprintf("resolved %d merge conflicts as per %s", conflicts, p)}))
+	}
+	return m
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMsgsMergeWithPolicy(t *testing.T) {
+	first := func() *Msgs {
+		return (&Msgs{}).AddWarnCode("POOL_FULL", "pool p1 is 90% full").AddInfo("i1").AddWarn("w1")
+	}
+	second := func() *Msgs {
+		return (&Msgs{}).AddErrorCode("POOL_FULL", errors.New("pool p1 is full")).AddWarn("w1").AddInfo("i2")
+	}
+
+	tests := map[string]struct {
+		policy         ConflictPolicy
+		expected       string
+		expectedHooked string
+	}{
+		"101": {KeepFirst, "pool p1 is 90% full,i1,w1,i2,resolved 2 merge conflicts as per KeepFirst",
+			"i2,resolved 2 merge conflicts as per KeepFirst"},
+		"102": {KeepLast, "pool p1 is full,i1,w1,i2,resolved 2 merge conflicts as per KeepLast",
+			"i2,resolved 2 merge conflicts as per KeepLast"},
+		"103": {KeepBoth, "pool p1 is 90% full,i1,w1,pool p1 is full,w1,i2,resolved 2 merge conflicts as per KeepBoth",
+			"pool p1 is full,w1,i2,resolved 2 merge conflicts as per KeepBoth"},
+		"104": {PreferError, "pool p1 is full,i1,w1,i2,resolved 2 merge conflicts as per PreferError",
+			"i2,resolved 2 merge conflicts as per PreferError"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := first()
+			var hooked []string
+			m.OnAdd(func(_ MsgType, desc string, _ error) { hooked = append(hooked, desc) })
+			s := second()
+			m.MergeWithPolicy(s, mock.policy)
+			if actual := strings.Join(m.Descriptions(), ","); actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, actual)
+			}
+			if strings.Join(s.Descriptions(), ",") != "pool p1 is full,w1,i2" {
+				t.Fatalf("Test '%s' failed: expected merged messages to be unmodified: actual '%s'", name, strings.Join(s.Descriptions(), ","))
+			}
+			if actual := strings.Join(hooked, ","); actual != mock.expectedHooked {
+				t.Fatalf("Test '%s' failed: expected hooks '%s': actual '%s'", name, mock.expectedHooked, actual)
+			}
+		})
+	}
+}
+
+func TestMsgsMergeWithPolicyKeys(t *testing.T) {
+	tests := map[string]struct {
+		m, other *Msgs
+		policy   ConflictPolicy
+		expected string
+	}{
+		// ids narrow down the codes and descriptions
+		"101": {&Msgs{Items: []*msg{{Mtype: WarnMsg, Desc: "w1", ID: "a", Code: "C1"}}},
+			&Msgs{Items: []*msg{{Mtype: WarnMsg, Desc: "w2", ID: "b", Code: "C1"}}},
+			KeepLast, "w1,w2"},
+		"102": {&Msgs{Items: []*msg{{Mtype: WarnMsg, Desc: "w1", ID: "a", Code: "C1"}}},
+			&Msgs{Items: []*msg{{Mtype: WarnMsg, Desc: "w2", ID: "a", Code: "C1"}}},
+			KeepLast, "w2,resolved 1 merge conflicts as per KeepLast"},
+		// codes take precedence over the type and description
+		"103": {&Msgs{Items: []*msg{{Mtype: WarnMsg, Desc: "w1", Code: "C1"}}},
+			&Msgs{Items: []*msg{{Mtype: WarnMsg, Desc: "w1", Code: "C2"}}},
+			KeepFirst, "w1,w1"},
+		"104": {&Msgs{Items: []*msg{{Mtype: WarnMsg, Desc: "w1"}}},
+			&Msgs{Items: []*msg{{Mtype: InfoMsg, Desc: "w1"}, {Mtype: WarnMsg, Desc: "w1"}}},
+			KeepLast, "w1,w1,resolved 1 merge conflicts as per KeepLast"},
+		// conflicts within the merged messages
+		"105": {&Msgs{},
+			&Msgs{Items: []*msg{{Mtype: WarnMsg, Desc: "w1", Code: "C1"}, nil, {Mtype: WarnMsg, Desc: "w2", Code: "C1"}}},
+			KeepLast, "w2,resolved 1 merge conflicts as per KeepLast"},
+		// equally severe messages keep the one already present
+		"106": {&Msgs{Items: []*msg{{Mtype: WarnMsg, Desc: "w1", Code: "C1"}}},
+			&Msgs{Items: []*msg{{Mtype: WarnMsg, Desc: "w2", Code: "C1"}, {Mtype: InfoMsg, Desc: "i1", Code: "C1"}}},
+			PreferError, "w1,resolved 2 merge conflicts as per PreferError"},
+		"107": {(&Msgs{}).AddInfo("i1"), (&Msgs{}).AddInfo("i2"), KeepFirst, "i1,i2"},
+		"108": {(&Msgs{}).AddInfo("i1"), nil, KeepFirst, "i1"},
+		// ids are unique per list only
+		"109": {NewMsgs(WithIDs()).AddWarn("pool a is full"), NewMsgs(WithIDs()).AddInfo("volume b created"),
+			KeepFirst, "pool a is full,volume b created"},
+		"110": {&Msgs{Items: []*msg{{Mtype: WarnMsg, Desc: "w1", ID: "a"}}},
+			&Msgs{Items: []*msg{{Mtype: ErrMsg, Desc: "w1", ID: "a", Err: errors.New("w1")}}},
+			PreferError, "w1,w1"},
+		"111": {&Msgs{Items: []*msg{{Mtype: WarnMsg, Desc: "w1", ID: "a"}}},
+			&Msgs{Items: []*msg{{Mtype: WarnMsg, Desc: "w1", ID: "a"}}},
+			KeepFirst, "w1,resolved 1 merge conflicts as per KeepFirst"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock.m.MergeWithPolicy(mock.other, mock.policy)
+			if actual := strings.Join(mock.m.Descriptions(), ","); actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, actual)
+			}
+		})
+	}
+	if ConflictPolicy(9).String() != "ConflictPolicy(9)" {
+		t.Fatalf("Test failed: expected an unknown policy: actual '%s'", ConflictPolicy(9))
+	}
+}
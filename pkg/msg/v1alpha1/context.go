@@ -0,0 +1,59 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+)
+
+// msgsKey is the context key under which messages are stored
+type msgsKey struct{}
+
+// NewContext returns a copy of the provided context that carries the
+// provided messages. The pointer is stored as is, i.e. messages added to
+// the instance retrieved via FromContext are visible to every holder of
+// the pointer.
+func NewContext(ctx context.Context, m *Msgs) context.Context {
+	return context.WithValue(ctx, msgsKey{}, m)
+}
+
+// FromContext returns the messages carried by the provided context
+func FromContext(ctx context.Context) (m *Msgs, ok bool) {
+	m, ok = ctx.Value(msgsKey{}).(*Msgs)
+	if m == nil {
+		return nil, false
+	}
+	return
+}
+
+// MustFromContext returns the messages carried by the provided context.
+// A new instance is returned if the context does not carry any messages;
+// messages added to this instance are not visible to anyone else.
+func MustFromContext(ctx context.Context) (m *Msgs) {
+	m, ok := FromContext(ctx)
+	if !ok {
+		return &Msgs{}
+	}
+	return
+}
+
+// WithFreshMsgs returns a copy of the provided context that carries a new
+// instance of messages along with the instance itself
+func WithFreshMsgs(ctx context.Context) (context.Context, *Msgs) {
+	m := &Msgs{}
+	return NewContext(ctx, m), m
+}
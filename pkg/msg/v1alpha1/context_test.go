@@ -0,0 +1,94 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// mockBuild, mockValidate and mockExecute represent a call chain that
+// records messages without passing them as arguments
+func mockBuild(ctx context.Context) {
+	MustFromContext(ctx).AddInfo("built")
+	mockValidate(ctx)
+}
+
+func mockValidate(ctx context.Context) {
+	MustFromContext(ctx).AddWarn("validated with warnings")
+	mockExecute(ctx)
+}
+
+func mockExecute(ctx context.Context) {
+	MustFromContext(ctx).AddError(errors.New("execute failed"))
+}
+
+func TestContextSharedAcrossFrames(t *testing.T) {
+	ctx, m := WithFreshMsgs(context.Background())
+	mockBuild(ctx)
+
+	if len(m.Items) != 3 {
+		t.Fatalf("Test failed: expected 3 messages: actual %d", len(m.Items))
+	}
+	if len(m.Errors().Items) != 1 || m.Errors().Items[0].Desc != "execute failed" {
+		t.Fatalf("Test failed: expected error added three frames deep: actual %v", m.Errors())
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	m := &Msgs{}
+	tests := map[string]struct {
+		ctx        context.Context
+		expectedOk bool
+	}{
+		"101": {NewContext(context.Background(), m), true},
+		"102": {context.Background(), false},
+		"103": {NewContext(context.Background(), nil), false},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, ok := FromContext(mock.ctx)
+			if ok != mock.expectedOk {
+				t.Fatalf("Test '%s' failed: expected ok '%t': actual ok '%t'", name, mock.expectedOk, ok)
+			}
+			if ok && actual != m {
+				t.Fatalf("Test '%s' failed: expected the stored pointer: actual %p", name, actual)
+			}
+		})
+	}
+}
+
+func TestMustFromContext(t *testing.T) {
+	ctx := context.Background()
+	m := MustFromContext(ctx)
+	if m == nil {
+		t.Fatalf("Test failed: expected fresh msgs: actual nil")
+	}
+	m.AddInfo("lost")
+	if n := MustFromContext(ctx); len(n.Items) != 0 {
+		t.Fatalf("Test failed: expected another fresh msgs: actual %d items", len(n.Items))
+	}
+
+	stored := &Msgs{}
+	ctx = NewContext(ctx, stored)
+	MustFromContext(ctx).AddInfo("kept")
+	if len(stored.Items) != 1 {
+		t.Fatalf("Test failed: expected message added via shared pointer: actual %d items", len(stored.Items))
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Occurrences returns the number of times this message occurred
+func (m *msg) Occurrences() int {
+	if m.Count < 1 {
+		return 1
+	}
+	return m.Count
+}
+
+// firstIndexes returns the index of the first non nil message per key
+func firstIndexes(items []*msg) map[msgKey]int {
+	index := map[msgKey]int{}
+	for i, item := range items {
+		if item == nil {
+			continue
+		}
+		if _, found := index[keyOf(item)]; !found {
+			index[keyOf(item)] = i
+		}
+	}
+	return index
+}
+
+// MergeCounted merges passed messages with receiver messages such that
+// a message matching an existing one by type, description and code
+// increments the count of the existing message instead of being
+// appended. LastSeen of every merged message is set to the current
+// time. Registered add hooks are fired for appended messages only.
+func (m *Msgs) MergeCounted(s *Msgs) (u *Msgs) {
+	m.mustNotBeNil("MergeCounted")
+	if s == nil {
+		return m
+	}
+	defer m.touch()
+	now := m.clock()
+	index := firstIndexes(m.Items)
+	for _, item := range s.Items {
+		if item == nil {
+			continue
+		}
+		k := keyOf(item)
+		if i, found := index[k]; found {
+			// existing messages may be shared with other lists
+			// and are hence replaced with an updated copy
+			c := *m.Items[i]
+			c.Count = c.Occurrences() + item.Occurrences()
+			c.LastSeen = &now
+			m.Items[i] = &c
+			continue
+		}
+		c := *item
+		c.Count = item.Occurrences()
+		c.LastSeen = &now
+		if !m.admit(&c) {
+			continue
+		}
+		m.Items = append(m.Items, &c)
+		if m.limit.max > 0 {
+			// admit may have evicted messages
+			index = firstIndexes(m.Items)
+		} else {
+			index[k] = len(m.Items) - 1
+		}
+		m.fire(&c)
+	}
+	return m
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockClock returns a clock that advances by a minute on every call
+func mockClock() func() time.Time {
+	t := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		t = t.Add(time.Minute)
+		return t
+	}
+}
+
+// counts returns the description and occurrences of every message
+func counts(m *Msgs) string {
+	var s []string
+	for _, item := range m.Items {
+		s = append(s, fmt.Sprintf("%s=%d", item.Desc, item.Occurrences()))
+	}
+	return strings.Join(s, ",")
+}
+
+func TestMsgsMergeCounted(t *testing.T) {
+	m := NewMsgs(WithClock(mockClock())).AddWarn("target pod not ready")
+	attempts := []*Msgs{
+		(&Msgs{}).AddWarn("target pod not ready").AddInfo("retrying"),
+		(&Msgs{}).AddWarn("target pod not ready").AddInfo("retrying").AddError(errors.New("timeout")),
+		(&Msgs{}).AddWarn("target pod not ready").AddWarn("target pod not ready"),
+	}
+	expected := []string{
+		"target pod not ready=2,retrying=1",
+		"target pod not ready=3,retrying=2,timeout=1",
+		"target pod not ready=5,retrying=2,timeout=1",
+	}
+	for i, attempt := range attempts {
+		m.MergeCounted(attempt)
+		if counts(m) != expected[i] {
+			t.Fatalf("Test failed: expected '%s' after merge %d: actual '%s'", expected[i], i+1, counts(m))
+		}
+	}
+	lastSeen := []string{"10:03", "10:02", "10:02"}
+	for i, item := range m.Items {
+		if item.LastSeen == nil || item.LastSeen.Format("15:04") != lastSeen[i] {
+			t.Fatalf("Test failed: expected '%s' last seen at %s: actual '%v'", item.Desc, lastSeen[i], item.LastSeen)
+		}
+	}
+	for _, attempt := range attempts {
+		for _, item := range attempt.Items {
+			if item.Count != 0 || item.LastSeen != nil {
+				t.Fatalf("Test failed: expected merged lists to be untouched: actual '%#v'", item)
+			}
+		}
+	}
+}
+
+func TestMsgsMergeCountedErrors(t *testing.T) {
+	m := (&Msgs{}).AddError(errors.New("pool not found"))
+	first := m.Items[0]
+	m.MergeCounted(FromErrors(fmt.Errorf("pool not found"), errors.New("pool not found")))
+	if counts(m) != "pool not found=3" {
+		t.Fatalf("Test failed: expected errors with same desc to be counted: actual '%s'", counts(m))
+	}
+	if m.Items[0].Err != first.Err || first.Count != 0 {
+		t.Fatalf("Test failed: expected the first error to be retained and untouched")
+	}
+}
+
+func TestMsgsMergeAppends(t *testing.T) {
+	m := (&Msgs{}).AddWarn("w1")
+	m.Merge((&Msgs{}).AddWarn("w1"))
+	if counts(m) != "w1=1,w1=1" {
+		t.Fatalf("Test failed: expected merge to append: actual '%s'", counts(m))
+	}
+	var nilMsgs *Msgs
+	if m.MergeCounted(nilMsgs) != m || len(m.Items) != 2 {
+		t.Fatalf("Test failed: expected merge of nil to be a no-op")
+	}
+}
+
+func TestMsgsMergeCountedLimit(t *testing.T) {
+	m := (&Msgs{}).WithLimit(2, DropOldest).AddInfo("i1").AddInfo("i2")
+	m.MergeCounted((&Msgs{}).AddInfo("i3").AddInfo("i3").AddInfo("i2"))
+	if counts(m) != "dropped 1 older messages=1,i2=2,i3=2" {
+		t.Fatalf("Test failed: expected limit to be honored: actual '%s'", counts(m))
+	}
+}
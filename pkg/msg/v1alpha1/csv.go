@@ -0,0 +1,73 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// csvHeader is the header row of messages written as csv
+var csvHeader = []string{"type", "time", "desc", "error", "code", "source", "labels", "caller", "id"}
+
+// csvRecord returns the provided message as a csv row. Time is
+// rendered as RFC3339Nano while labels are rendered as sorted 'key=value'
+// pairs separated by ';'.
+func csvRecord(given *msg) []string {
+	given = given.forOutput()
+	var t string
+	if given.Time != nil {
+		t = given.Time.Format(time.RFC3339Nano)
+	}
+	var labels []string
+	for k, v := range given.Labels {
+		labels = append(labels, k+"="+v)
+	}
+	sort.Strings(labels)
+	return []string{
+		string(given.Mtype), t, given.Description(), errString(given.Err), given.Code, given.Source,
+		strings.Join(labels, ";"), given.Caller, given.ID,
+	}
+}
+
+// WriteCSV writes a header row followed by a row for every non nil
+// message
+func (m Msgs) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		if err := cw.Write(csvRecord(item)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteCSV writes a header row followed by a row for every non nil
+// message. Buckets are written in the same order as ToMsgs.
+func (a AllMsgs) WriteCSV(w io.Writer) error {
+	return a.ToMsgs().WriteCSV(w)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMsgsWriteCSV(t *testing.T) {
+	ts := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	nano := time.Date(2018, 6, 1, 10, 0, 0, 123456789, time.FixedZone("IST", 19800))
+	tests := map[string]struct {
+		msgs     *Msgs
+		expected string
+	}{
+		"101": {&Msgs{}, "type,time,desc,error,code,source,labels,caller,id\n"},
+		"102": {(&Msgs{}).AddInfo("pool a, pool b"), "type,time,desc,error,code,source,labels,caller,id\ninfo,,\"pool a, pool b\",,,,,,\n"},
+		"103": {(&Msgs{}).AddWarn(`say "hi"`), "type,time,desc,error,code,source,labels,caller,id\nwarn,,\"say \"\"hi\"\"\",,,,,,\n"},
+		"104": {(&Msgs{}).AddError(errors.New("line1\nline2")), "type,time,desc,error,code,source,labels,caller,id\nerror,,\"line1\nline2\",\"line1\nline2\",,,,,\n"},
+		"105": {(&Msgs{}).WithSource("pool").AddWarnCode("Degraded", "w1").
+			WithLabels(map[string]string{"replica": "2", "phase": "provision"}).AddSkip("s1"),
+			"type,time,desc,error,code,source,labels,caller,id\nwarn,,w1,,Degraded,pool,,,\nskip,,s1,,,pool,phase=provision;replica=2,,\n"},
+		"106": {&Msgs{Items: []*msg{nil}}, "type,time,desc,error,code,source,labels,caller,id\n"},
+		"107": {NewMsgs(WithIDs()).AddInfo("i1"),
+			"type,time,desc,error,code,source,labels,caller,id\ninfo,,i1,,,,,,msg-000001\n"},
+		"108": {&Msgs{Items: []*msg{&msg{Mtype: InfoMsg, Desc: "i1", Time: &ts, Caller: "pool.go:12"}}},
+			"type,time,desc,error,code,source,labels,caller,id\ninfo,2018-06-01T10:00:00Z,i1,,,,,pool.go:12,\n"},
+		"109": {&Msgs{Items: []*msg{&msg{Mtype: InfoMsg, Desc: "i1", Time: &nano}}},
+			"type,time,desc,error,code,source,labels,caller,id\ninfo,2018-06-01T10:00:00.123456789+05:30,i1,,,,,,\n"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := mock.msgs.WriteCSV(&b); err != nil {
+				t.Fatalf("Test '%s' failed: expected no error: actual '%s'", name, err)
+			}
+			if b.String() != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%q': actual '%q'", name, mock.expected, b.String())
+			}
+			records, err := csv.NewReader(&b).ReadAll()
+			if err != nil || len(records) != len(mock.msgs.Filter(func(*msg) bool { return true }).Items)+1 {
+				t.Fatalf("Test '%s' failed: expected csv to be readable: actual '%v' '%v'", name, records, err)
+			}
+		})
+	}
+}
+
+func TestAllMsgsWriteCSV(t *testing.T) {
+	a := (&Msgs{}).AddInfo("i1").AddError(errors.New("e1")).AddWarn("w1").AllMsgs()
+	var b bytes.Buffer
+	if err := a.WriteCSV(&b); err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	expected := "type,time,desc,error,code,source,labels,caller,id\nerror,,e1,e1,,,,,\nwarn,,w1,,,,,,\ninfo,,i1,,,,,,\n"
+	if b.String() != expected {
+		t.Fatalf("Test failed: expected '%q': actual '%q'", expected, b.String())
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// IsDeprecation returns true if given message is of type DeprecationMsg
+func IsDeprecation(given *msg) (ok bool) {
+	if given == nil {
+		return
+	}
+	return given.Mtype == DeprecationMsg
+}
+
+// AddDeprecation appends a new DeprecationMsg to messages and
+// initializes it with the passed description
+func (m *Msgs) AddDeprecation(d string) (u *Msgs) {
+	m.mustNotBeNil("AddDeprecation")
+	if len(d) == 0 {
+		return m
+	}
+	return m.add(m.newMsg(msg{Mtype: DeprecationMsg, Desc: d}))
+}
+
+// Deprecations filters DeprecationMsg messages
+func (m Msgs) Deprecations() (f Msgs) {
+	return m.Filter(IsDeprecation)
+}
+
+// HasDeprecation returns true if at least one DeprecationMsg is present
+func (m Msgs) HasDeprecation() bool {
+	return len(m.Filter(IsDeprecation).Items) != 0
+}
+
+// HasDeprecation returns true if at least one DeprecationMsg is present
+func (a AllMsgs) HasDeprecation() (isdeprecation bool) {
+	deprecations := a[DeprecationMsg]
+	if len(deprecations.Items) == 0 {
+		return
+	}
+	return true
+}
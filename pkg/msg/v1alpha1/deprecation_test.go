@@ -0,0 +1,93 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestMsgsAddDeprecation(t *testing.T) {
+	tests := map[string]struct {
+		messages []string
+		expected int
+	}{
+		"101": {[]string{}, 0},
+		"102": {[]string{""}, 0},
+		"103": {[]string{"cas-type jiva annotation is deprecated, use spec.casType"}, 1},
+		"104": {[]string{"d1", "d2"}, 2},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			ml := &Msgs{}
+			for _, d := range mock.messages {
+				ml.AddDeprecation(d)
+			}
+			ml.AddWarn("w1")
+			if len(ml.Deprecations().Items) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%d': actual '%d'", name, mock.expected, len(ml.Deprecations().Items))
+			}
+			if ml.HasDeprecation() != (mock.expected != 0) {
+				t.Fatalf("Test '%s' failed: expected has deprecation '%t'", name, mock.expected != 0)
+			}
+			if ml.AllMsgs().HasDeprecation() != (mock.expected != 0) {
+				t.Fatalf("Test '%s' failed: expected all msgs has deprecation '%t'", name, mock.expected != 0)
+			}
+			if IsDeprecation(nil) {
+				t.Fatalf("Test '%s' failed: expected nil message not to be a deprecation", name)
+			}
+		})
+	}
+}
+
+func TestAllMsgsDeprecations(t *testing.T) {
+	m := (&Msgs{}).AddInfo("i1").AddDeprecation("d1").AddError(errors.New("e1")).AddSkip("s1").AddWarn("w1")
+	a := m.AllMsgs()
+	if len(a[DeprecationMsg].Items) != 1 {
+		t.Fatalf("Test failed: expected deprecation bucket: actual '%v'", a)
+	}
+	if actual := descs(a.ToMsgs()); actual != "e1,w1,d1,i1,s1" {
+		t.Fatalf("Test failed: expected 'e1,w1,d1,i1,s1': actual '%s'", actual)
+	}
+	if (&Msgs{}).AddDeprecation("d1").AllMsgs().IsEmpty() {
+		t.Fatalf("Test failed: expected deprecations not to be empty")
+	}
+
+	var lines []string
+	m.LogNonInfos(mockLogger(&lines))
+	if len(lines) != 4 {
+		t.Fatalf("Test failed: expected deprecation to be logged with non infos: actual '%v'", lines)
+	}
+}
+
+func TestMsgsDeprecationJSON(t *testing.T) {
+	m := (&Msgs{}).AddDeprecation("d1")
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	expected := `{"items":[{"type":"deprecation","desc":"d1"}]}`
+	if string(b) != expected {
+		t.Fatalf("Test failed: expected '%s': actual '%s'", expected, string(b))
+	}
+	var actual Msgs
+	if err := json.Unmarshal(b, &actual); err != nil || !actual.HasDeprecation() {
+		t.Fatalf("Test failed: expected deprecation to survive round trip: actual '%v' '%v'", actual, err)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// defaultMaxDescLen is the maximum length in bytes of descriptions of
+// messages added to instances that do not set their own maximum
+var defaultMaxDescLen int64
+
+// SetDefaultMaxDescLen sets the maximum length in bytes of descriptions
+// of messages added to instances that do not set their own maximum via
+// WithMaxDescLen. Zero or a negative length means unlimited which is
+// the default.
+func SetDefaultMaxDescLen(n int) {
+	if n < 0 {
+		n = 0
+	}
+	atomic.StoreInt64(&defaultMaxDescLen, int64(n))
+}
+
+// WithMaxDescLen sets the maximum length in bytes of descriptions of
+// messages subsequently added. Longer descriptions are truncated at a
+// rune boundary and suffixed with a note of the count of truncated
+// bytes e.g. '…(truncated 1048576 bytes)'. Zero or a negative length
+// means unlimited irrespective of SetDefaultMaxDescLen. Errors of
+// truncated messages are not modified.
+func (m *Msgs) WithMaxDescLen(n int) (u *Msgs) {
+	m.mustNotBeNil("WithMaxDescLen")
+	if n < 0 {
+		n = 0
+	}
+	m.maxDescLen = &n
+	return m
+}
+
+// WithFullDescs sets whether the full description of a truncated
+// message is retained. It is available via FullDescription and is not
+// serialized.
+func (m *Msgs) WithFullDescs(retain bool) (u *Msgs) {
+	m.mustNotBeNil("WithFullDescs")
+	m.fullDescs = retain
+	return m
+}
+
+// WithMaxDescLen limits the length of descriptions as per
+// Msgs.WithMaxDescLen
+func WithMaxDescLen(n int) Option {
+	return func(m *Msgs) {
+		m.WithMaxDescLen(n)
+	}
+}
+
+// WithFullDescs retains the full descriptions of truncated messages as
+// per Msgs.WithFullDescs
+func WithFullDescs() Option {
+	return func(m *Msgs) {
+		m.WithFullDescs(true)
+	}
+}
+
+// descLimit returns the maximum length of descriptions of messages
+// added to this instance; zero means unlimited
+func (m *Msgs) descLimit() int {
+	if m.maxDescLen != nil {
+		return *m.maxDescLen
+	}
+	return int(atomic.LoadInt64(&defaultMaxDescLen))
+}
+
+// limitDesc truncates the description of the provided message as per
+// the maximum length of descriptions. Lazy descriptions are truncated
+// when rendered and are never retained in full.
+func (m *Msgs) limitDesc(item *msg) {
+	max := m.descLimit()
+	if max == 0 {
+		return
+	}
+	if item.lazy != nil {
+		item.lazy.max = max
+		return
+	}
+	truncated, ok := truncateDesc(item.Desc, max)
+	if !ok {
+		return
+	}
+	if m.fullDescs {
+		item.FullDesc = item.Desc
+	}
+	item.Desc = truncated
+}
+
+// truncateDesc returns at most max bytes of the provided description
+// followed by a note of the count of truncated bytes. It returns false
+// if the description is not longer than max bytes.
+func truncateDesc(desc string, max int) (string, bool) {
+	if max <= 0 || len(desc) <= max {
+		return desc, false
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(desc[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s…(truncated %d bytes)", desc[:cut], len(desc)-cut), true
+}
+
+// FullDescription returns the full description of this message if it
+// was truncated and retained, see Msgs.WithFullDescs, and returns the
+// description otherwise
+func (m *msg) FullDescription() string {
+	if len(m.FullDesc) != 0 {
+		return m.FullDesc
+	}
+	return m.Description()
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMsgsWithMaxDescLen(t *testing.T) {
+	tests := map[string]struct {
+		max      int
+		desc     string
+		expected string
+	}{
+		"101": {0, "abcdef", "abcdef"},
+		"102": {6, "abcdef", "abcdef"},
+		"103": {5, "abcdef", "abcde…(truncated 1 bytes)"},
+		"104": {1, "abcdef", "a…(truncated 5 bytes)"},
+		"105": {-1, "abcdef", "abcdef"},
+		// 'é' is two bytes and is not split
+		"106": {4, "abcé", "abc…(truncated 2 bytes)"},
+		"107": {5, "abcé", "abcé"},
+		// '世' is three bytes and is not split
+		"108": {4, "ab世界", "ab…(truncated 6 bytes)"},
+		"109": {5, "ab世界", "ab世…(truncated 3 bytes)"},
+		"110": {1, "世", "…(truncated 3 bytes)"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := NewMsgs(WithMaxDescLen(mock.max))
+			m.AddInfo(mock.desc).AddWarn(mock.desc).AddSkip(mock.desc).AddError(errors.New(mock.desc))
+			m.AddInfoLazy(func() string { return mock.desc })
+			for _, d := range m.Descriptions() {
+				if d != mock.expected {
+					t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, d)
+				}
+			}
+			if m.Items[3].Err.Error() != mock.desc {
+				t.Fatalf("Test '%s' failed: expected error '%s': actual '%s'", name, mock.desc, m.Items[3].Err)
+			}
+			if m.Items[0].FullDescription() != mock.expected {
+				t.Fatalf("Test '%s' failed: expected full description to not be retained: actual '%s'", name, m.Items[0].FullDescription())
+			}
+		})
+	}
+}
+
+func TestMsgsWithFullDescs(t *testing.T) {
+	manifest := strings.Repeat("x", 100)
+	m := NewMsgs(WithMaxDescLen(10), WithFullDescs())
+	m.AddWarn(manifest).AddWarn("short")
+	if m.Items[0].Desc != "xxxxxxxxxx…(truncated 90 bytes)" || m.Items[0].FullDescription() != manifest {
+		t.Fatalf("Test failed: expected truncated and retained description: actual '%s' '%s'", m.Items[0].Desc, m.Items[0].FullDesc)
+	}
+	if len(m.Items[1].FullDesc) != 0 || m.Items[1].FullDescription() != "short" {
+		t.Fatalf("Test failed: expected short description to not be retained: actual '%s'", m.Items[1].FullDesc)
+	}
+	b, err := json.Marshal(m)
+	if err != nil || strings.Contains(string(b), manifest) || strings.Contains(m.String(), manifest) {
+		t.Fatalf("Test failed: expected full description to not be serialized: actual '%s' '%v'", string(b), err)
+	}
+	for name, roundTrip := range mockRoundTrips() {
+		actual, err := roundTrip(m)
+		if err != nil || len(actual.Items[0].FullDesc) != 0 {
+			t.Fatalf("Test '%s' failed: expected full description to not be serialized: actual '%v' '%v'", name, actual, err)
+		}
+	}
+}
+
+func TestSetDefaultMaxDescLen(t *testing.T) {
+	SetDefaultMaxDescLen(3)
+	defer SetDefaultMaxDescLen(0)
+	m := &Msgs{}
+	m.AddInfo("abcd")
+	u := (&Msgs{}).WithMaxDescLen(0).AddInfo("abcd")
+	if descs(m) != "abc…(truncated 1 bytes)" || descs(u) != "abcd" {
+		t.Fatalf("Test failed: expected default to apply unless overridden: actual '%s' '%s'", descs(m), descs(u))
+	}
+}
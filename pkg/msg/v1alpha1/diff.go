@@ -0,0 +1,70 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// unmatched returns the non nil messages of the provided list that are
+// not matched by the provided counts of keys as returned by key. Counts
+// are decremented as they are matched such that duplicates are treated
+// as distinct messages.
+func unmatched(items []*msg, counts map[msgKey]int, key func(*msg) msgKey) (u Msgs) {
+	return partition(items, counts, key, false)
+}
+
+// partition returns the non nil messages of the provided list that are
+// matched by the provided counts of keys as returned by key if matched
+// is true and the ones that are not matched otherwise. Counts are
+// decremented as they are matched.
+func partition(items []*msg, counts map[msgKey]int, key func(*msg) msgKey, matched bool) (p Msgs) {
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		k := key(item)
+		found := counts[k] > 0
+		if found {
+			counts[k]--
+		}
+		if found == matched {
+			p.Items = append(p.Items, item)
+		}
+	}
+	return
+}
+
+// countKeys returns the count of non nil messages per key as returned
+// by key
+func countKeys(items []*msg, key func(*msg) msgKey) map[msgKey]int {
+	counts := map[msgKey]int{}
+	for _, item := range items {
+		if item != nil {
+			counts[key(item)]++
+		}
+	}
+	return counts
+}
+
+// Diff returns the messages that were added to and removed from the
+// provided previous messages. Messages are matched by their type,
+// description and code. Duplicate messages are matched as many times as
+// they occur e.g. two identical warnings against one results in one
+// added warning. Added messages are in the order of receiver while
+// removed messages are in the order of previous.
+func (m Msgs) Diff(previous Msgs) (added Msgs, removed Msgs) {
+	added = unmatched(m.Items, countKeys(previous.Items, keyOf), keyOf)
+	removed = unmatched(previous.Items, countKeys(m.Items, keyOf), keyOf)
+	return
+}
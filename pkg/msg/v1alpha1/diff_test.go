@@ -0,0 +1,50 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func TestMsgsDiff(t *testing.T) {
+	tests := map[string]struct {
+		current, previous *Msgs
+		added, removed    string
+	}{
+		"101": {(&Msgs{}).AddInfo("i1").AddWarn("w1"), (&Msgs{}).AddInfo("i1").AddWarn("w1"), "", ""},
+		"102": {(&Msgs{}).AddInfo("i1").AddWarn("w1"), (&Msgs{}).AddInfo("i2").AddWarn("w2"), "i1,w1", "i2,w2"},
+		"103": {(&Msgs{}).AddWarn("w1").AddWarn("w1"), (&Msgs{}).AddWarn("w1"), "w1", ""},
+		"104": {(&Msgs{}).AddWarn("w1"), (&Msgs{}).AddWarn("w1").AddWarn("w1").AddWarn("w1"), "", "w1,w1"},
+		"105": {(&Msgs{}).AddInfo("i2").AddWarn("w1").AddInfo("i1"), &Msgs{}, "i2,w1,i1", ""},
+		"106": {&Msgs{}, (&Msgs{}).AddInfo("i1"), "", "i1"},
+		"107": {(&Msgs{}).AddInfo("x"), (&Msgs{}).AddWarn("x"), "x", "x"},
+		"108": {&Msgs{Items: []*msg{nil, &msg{Mtype: InfoMsg, Desc: "i1"}}}, &Msgs{Items: []*msg{nil}}, "i1", ""},
+		"109": {(&Msgs{}).AddInfo("i3").AddInfo("i1").AddInfo("i2"), (&Msgs{}).AddInfo("i2").AddInfo("i4"), "i3,i1", "i4"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			added, removed := mock.current.Diff(*mock.previous)
+			if descs(&added) != mock.added {
+				t.Fatalf("Test '%s' failed: expected added '%s': actual '%s'", name, mock.added, descs(&added))
+			}
+			if descs(&removed) != mock.removed {
+				t.Fatalf("Test '%s' failed: expected removed '%s': actual '%s'", name, mock.removed, descs(&removed))
+			}
+		})
+	}
+}
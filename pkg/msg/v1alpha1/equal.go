@@ -0,0 +1,84 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// errString returns the error message of the provided error or empty
+// string if nil
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// equal returns true if both the messages have same type, description
+// and error message. Errors of different identity but same message are
+// considered equal. A strict comparison compares all the other fields
+// as well. A nil message is equal to a nil message only.
+func (m *msg) equal(other *msg, strict bool) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	if m.Mtype != other.Mtype || m.Description() != other.Description() ||
+		errString(m.Err) != errString(other.Err) {
+		return false
+	}
+	if !strict {
+		return true
+	}
+	if m.Code != other.Code || m.Source != other.Source || m.Percent != other.Percent ||
+		m.Caller != other.Caller || m.ID != other.ID || m.Reason != other.Reason || m.Priority != other.Priority || m.Category != other.Category || m.Occurrences() != other.Occurrences() || len(m.Labels) != len(other.Labels) ||
+		!fieldsEqual(m.Fields, other.Fields) || objectOf(m) != objectOf(other) {
+		return false
+	}
+	if (m.Time == nil) != (other.Time == nil) || (m.Time != nil && !m.Time.Equal(*other.Time)) {
+		return false
+	}
+	for k, v := range m.Labels {
+		if ov, found := other.Labels[k]; !found || ov != v {
+			return false
+		}
+	}
+	return true
+}
+
+// equal returns true if both the lists have equal messages in the same
+// order
+func (m Msgs) equal(other Msgs, strict bool) bool {
+	if len(m.Items) != len(other.Items) {
+		return false
+	}
+	for i, item := range m.Items {
+		if !item.equal(other.Items[i], strict) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if both the lists have messages of same type,
+// description and error message in the same order. A nil Items is
+// equal to an empty Items.
+func (m Msgs) Equal(other Msgs) bool {
+	return m.equal(other, false)
+}
+
+// EqualStrict is same as Equal but additionally compares code, source,
+// labels, percent, time, caller, id, skip reason and occurrences of the messages
+func (m Msgs) EqualStrict(other Msgs) bool {
+	return m.equal(other, true)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMsgsEqual(t *testing.T) {
+	t1 := time.Date(2018, 6, 1, 10, 0, 0, 0, time.FixedZone("IST", 19800))
+	t1UTC, t2 := t1.UTC(), t1.Add(time.Second)
+	tests := map[string]struct {
+		m, other    *Msgs
+		equal       bool
+		strictEqual bool
+	}{
+		"101": {&Msgs{}, &Msgs{}, true, true},
+		"102": {&Msgs{}, &Msgs{Items: []*msg{}}, true, true},
+		"103": {(&Msgs{}).AddError(errors.New("e1")), (&Msgs{}).AddError(errors.New("e1")), true, true},
+		"104": {(&Msgs{}).AddInfo("i1").AddWarn("w1"), (&Msgs{}).AddWarn("w1").AddInfo("i1"), false, false},
+		"105": {(&Msgs{}).AddInfo("i1"), (&Msgs{}).AddWarn("i1"), false, false},
+		"106": {(&Msgs{}).AddInfo("i1"), (&Msgs{}).AddInfo("i1").AddInfo("i2"), false, false},
+		"107": {&Msgs{Items: []*msg{nil}}, &Msgs{Items: []*msg{nil}}, true, true},
+		"108": {&Msgs{Items: []*msg{nil}}, &Msgs{}, false, false},
+		"109": {&Msgs{Items: []*msg{nil}}, &Msgs{Items: []*msg{&msg{}}}, false, false},
+		"110": {(&Msgs{}).AddErrorCode("PoolDown", errors.New("e1")), (&Msgs{}).AddError(errors.New("e1")), true, false},
+		"111": {(&Msgs{}).WithSource("pool").AddInfo("i1"), (&Msgs{}).AddInfo("i1"), true, false},
+		"112": {(&Msgs{}).WithLabels(map[string]string{"a": "1"}).AddInfo("i1"),
+			(&Msgs{}).WithLabels(map[string]string{"a": "2"}).AddInfo("i1"), true, false},
+		"113": {(&Msgs{}).AddInfoLazy(func() string { return "i1" }), (&Msgs{}).AddInfo("i1"), true, true},
+		"114": {&Msgs{Items: []*msg{&msg{Mtype: ErrMsg, Desc: "e1", Err: errors.New("x")}}},
+			&Msgs{Items: []*msg{&msg{Mtype: ErrMsg, Desc: "e1"}}}, false, false},
+		"115": {&Msgs{Items: []*msg{&msg{Mtype: InfoMsg, Desc: "i1", Time: &t1}}},
+			&Msgs{Items: []*msg{&msg{Mtype: InfoMsg, Desc: "i1", Time: &t2}}}, true, false},
+		"116": {&Msgs{Items: []*msg{&msg{Mtype: InfoMsg, Desc: "i1", Time: &t1}}},
+			&Msgs{Items: []*msg{&msg{Mtype: InfoMsg, Desc: "i1"}}}, true, false},
+		"117": {&Msgs{Items: []*msg{&msg{Mtype: InfoMsg, Desc: "i1", Time: &t1}}},
+			&Msgs{Items: []*msg{&msg{Mtype: InfoMsg, Desc: "i1", Time: &t1UTC}}}, true, true},
+		"118": {&Msgs{Items: []*msg{&msg{Mtype: InfoMsg, Desc: "i1", Caller: "a.go:1"}}},
+			&Msgs{Items: []*msg{&msg{Mtype: InfoMsg, Desc: "i1", Caller: "b.go:1"}}}, true, false},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := mock.m.Equal(*mock.other); actual != mock.equal {
+				t.Fatalf("Test '%s' failed: expected equal %t: actual %t", name, mock.equal, actual)
+			}
+			if actual := mock.other.Equal(*mock.m); actual != mock.equal {
+				t.Fatalf("Test '%s' failed: expected symmetric equal %t: actual %t", name, mock.equal, actual)
+			}
+			if actual := mock.m.EqualStrict(*mock.other); actual != mock.strictEqual {
+				t.Fatalf("Test '%s' failed: expected strict equal %t: actual %t", name, mock.strictEqual, actual)
+			}
+		})
+	}
+}
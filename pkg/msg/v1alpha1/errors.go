@@ -0,0 +1,148 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+// registeredError is a sentinel error registered against a code
+type registeredError struct {
+	code     string
+	sentinel error
+}
+
+var (
+	registeredErrorsMu sync.RWMutex
+	// registeredErrors holds the registered errors in the order
+	// of registration
+	registeredErrors []registeredError
+)
+
+// RegisterError registers the provided sentinel error against the
+// provided code. Errors added as messages that match the sentinel as
+// per errors.Is are marshaled along with the code. Such errors are
+// unmarshaled as errors that wrap the sentinel so that errors.Is still
+// matches. Registering an existing code replaces its sentinel.
+func RegisterError(code string, sentinel error) {
+	if len(code) == 0 || sentinel == nil {
+		return
+	}
+	registeredErrorsMu.Lock()
+	defer registeredErrorsMu.Unlock()
+	for i, r := range registeredErrors {
+		if r.code == code {
+			registeredErrors[i].sentinel = sentinel
+			return
+		}
+	}
+	registeredErrors = append(registeredErrors, registeredError{code: code, sentinel: sentinel})
+}
+
+// registeredCode returns the code of the first registered sentinel
+// that matches the provided error
+func registeredCode(err error) string {
+	registeredErrorsMu.RLock()
+	defer registeredErrorsMu.RUnlock()
+	for _, r := range registeredErrors {
+		if errors.Is(err, r.sentinel) {
+			return r.code
+		}
+	}
+	return ""
+}
+
+// registeredSentinel returns the sentinel registered against the
+// provided code
+func registeredSentinel(code string) error {
+	registeredErrorsMu.RLock()
+	defer registeredErrorsMu.RUnlock()
+	for _, r := range registeredErrors {
+		if r.code == code {
+			return r.sentinel
+		}
+	}
+	return nil
+}
+
+// restoredError is an error restored from its message that wraps the
+// sentinel registered against its code
+type restoredError struct {
+	msg      string
+	sentinel error
+}
+
+// Error is an implementation of error interface
+func (e *restoredError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the registered sentinel
+func (e *restoredError) Unwrap() error {
+	return e.sentinel
+}
+
+// restoreError returns an error with the provided message that wraps
+// the sentinel registered against the provided code if any
+func restoreError(msg, code string) error {
+	if s := registeredSentinel(code); s != nil {
+		return &restoredError{msg: msg, sentinel: s}
+	}
+	return errors.New(msg)
+}
+
+// msgsError is the aggregate of the errors of messages
+type msgsError struct {
+	errs []error
+}
+
+// Error is an implementation of error interface. It joins the messages
+// of the aggregated errors e.g. 'e1; e2'.
+func (e *msgsError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the aggregated errors such that errors.Is and
+// errors.As match any of them
+func (e *msgsError) Unwrap() []error {
+	return e.errs
+}
+
+// Err returns the errors of the ErrMsg messages as a single error or
+// nil if there are none. A single error is returned as is while several
+// errors are aggregated in the order they were added.
+func (m Msgs) Err() error {
+	var errs []error
+	for _, item := range m.Items {
+		if IsErr(item) && item.Err != nil {
+			errs = append(errs, item.Err)
+		}
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return &msgsError{errs: errs}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/proto"
+	msgproto "github.com/openebs/maya/pkg/msg/v1alpha1/proto"
+)
+
+var (
+	errMockQuotaExceeded = errors.New("quota exceeded")
+	errMockUnregistered  = errors.New("unregistered")
+)
+
+func init() {
+	RegisterError("QuotaExceeded", errMockQuotaExceeded)
+}
+
+// mockRoundTrips round trips messages through the supported encodings
+func mockRoundTrips() map[string]func(m *Msgs) (*Msgs, error) {
+	return map[string]func(m *Msgs) (*Msgs, error){
+		"json": func(m *Msgs) (*Msgs, error) {
+			b, err := json.Marshal(m)
+			if err != nil {
+				return nil, err
+			}
+			var actual Msgs
+			return &actual, json.Unmarshal(b, &actual)
+		},
+		"yaml": func(m *Msgs) (*Msgs, error) {
+			var actual Msgs
+			return &actual, yaml.Unmarshal([]byte(m.String()), &actual)
+		},
+		"gob": func(m *Msgs) (*Msgs, error) {
+			b, err := EncodeGob(*m)
+			if err != nil {
+				return nil, err
+			}
+			return DecodeGob(b)
+		},
+		"proto": func(m *Msgs) (*Msgs, error) {
+			b, err := proto.Marshal(m.ToProto())
+			if err != nil {
+				return nil, err
+			}
+			p := &msgproto.MsgsProto{}
+			if err := proto.Unmarshal(b, p); err != nil {
+				return nil, err
+			}
+			return MsgsFromProto(p), nil
+		},
+	}
+}
+
+func TestRegisteredErrorRoundTrip(t *testing.T) {
+	m := (&Msgs{}).AddError(fmt.Errorf("pool p1: %w", errMockQuotaExceeded)).
+		AddError(errMockUnregistered).AddErrorCode("PoolDown", errMockQuotaExceeded)
+	if m.Items[0].ErrCode != "QuotaExceeded" || m.Items[1].ErrCode != "" || m.Items[2].ErrCode != "QuotaExceeded" {
+		t.Fatalf("Test failed: expected registered errors to be detected: actual '%s'", m)
+	}
+	for name, roundTrip := range mockRoundTrips() {
+		t.Run(name, func(t *testing.T) {
+			actual, err := roundTrip(m)
+			if err != nil {
+				t.Fatalf("Test '%s' failed: expected no error: actual '%s'", name, err)
+			}
+			if len(actual.Items) != 3 {
+				t.Fatalf("Test '%s' failed: expected 3 messages: actual '%s'", name, actual)
+			}
+			quota, unknown, coded := actual.Items[0].Err, actual.Items[1].Err, actual.Items[2].Err
+			if !errors.Is(quota, errMockQuotaExceeded) || quota.Error() != "pool p1: quota exceeded" {
+				t.Fatalf("Test '%s' failed: expected registered error to match: actual '%v'", name, quota)
+			}
+			if errors.Is(unknown, errMockUnregistered) || unknown.Error() != "unregistered" {
+				t.Fatalf("Test '%s' failed: expected unregistered error to fall back to its message: actual '%v'", name, unknown)
+			}
+			if !errors.Is(coded, errMockQuotaExceeded) || actual.Items[2].Code != "PoolDown" {
+				t.Fatalf("Test '%s' failed: expected coded error to match: actual '%#v'", name, actual.Items[2])
+			}
+		})
+	}
+}
+
+func TestRestoreErrorUnknownCode(t *testing.T) {
+	var m Msgs
+	if err := json.Unmarshal([]byte(`{"items":[{"type":"error","desc":"e1","err":"e1","errCode":"Unknown"}]}`), &m); err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	err := m.Items[0].Err
+	if err == nil || err.Error() != "e1" || errors.Is(err, errMockQuotaExceeded) || m.Items[0].ErrCode != "Unknown" {
+		t.Fatalf("Test failed: expected unknown code to fall back to its message: actual '%#v'", m.Items[0])
+	}
+}
+
+func TestRegisterError(t *testing.T) {
+	sentinel := errors.New("replaced")
+	RegisterError("Replaceable", errMockUnregistered)
+	RegisterError("Replaceable", sentinel)
+	RegisterError("", sentinel)
+	RegisterError("Nil", nil)
+	if code := registeredCode(sentinel); code != "Replaceable" {
+		t.Fatalf("Test failed: expected 'Replaceable': actual '%s'", code)
+	}
+	if code := registeredCode(errMockUnregistered); code != "" {
+		t.Fatalf("Test failed: expected replaced sentinel to not match: actual '%s'", code)
+	}
+	if s := registeredSentinel("Nil"); s != nil {
+		t.Fatalf("Test failed: expected nil sentinel to not be registered")
+	}
+}
+
+func TestMsgsErr(t *testing.T) {
+	e1 := errors.New("e1")
+	tests := map[string]struct {
+		m        *Msgs
+		expected string
+	}{
+		"101": {&Msgs{}, ""},
+		"102": {(&Msgs{}).AddInfo("i1").AddWarn("w1"), ""},
+		"103": {(&Msgs{}).AddInfo("i1").AddError(e1), "e1"},
+		"104": {(&Msgs{}).AddError(e1).AddWarn("w1").AddError(errors.New("e2")), "e1; e2"},
+		"105": {&Msgs{Items: []*msg{nil, {Mtype: ErrMsg, Desc: "e0"}}}, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := mock.m.Err()
+			if actual := errString(err); actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, actual)
+			}
+		})
+	}
+	if err := (&Msgs{}).AddError(e1).Err(); err != e1 {
+		t.Fatalf("Test failed: expected a single error as is: actual '%v'", err)
+	}
+}
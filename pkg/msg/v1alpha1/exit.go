@@ -0,0 +1,77 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// ExitCodeOptions represents the process exit codes derived from
+// messages
+type ExitCodeOptions struct {
+	OKCode    int  // used when no ErrMsg is present
+	ErrorCode int  // used when at least one ErrMsg is present
+	WarnCode  int  // used in strict mode when WarnMsg but no ErrMsg is present
+	Strict    bool // fails on warnings as well
+}
+
+// DefaultExitCodeOptions exits with 1 on errors and 0 otherwise
+var DefaultExitCodeOptions = ExitCodeOptions{OKCode: 0, ErrorCode: 1, WarnCode: 2}
+
+// ExitCodeWithOptions returns the exit code derived from the messages
+// as per the provided options
+func (a AllMsgs) ExitCodeWithOptions(o ExitCodeOptions) int {
+	switch {
+	case a.HasError():
+		return o.ErrorCode
+	case o.Strict && a.HasWarn():
+		return o.WarnCode
+	default:
+		return o.OKCode
+	}
+}
+
+// ExitCode returns 1 if at least one ErrMsg is present and 0 otherwise
+func (a AllMsgs) ExitCode() int {
+	return a.ExitCodeWithOptions(DefaultExitCodeOptions)
+}
+
+// ExitCodeStrict returns 1 if at least one ErrMsg is present, 2 if
+// WarnMsg but no ErrMsg is present and 0 otherwise
+func (a AllMsgs) ExitCodeStrict() int {
+	o := DefaultExitCodeOptions
+	o.Strict = true
+	return a.ExitCodeWithOptions(o)
+}
+
+// ExitCodeWithOptions returns the exit code derived from the messages
+// as per the provided options
+func (m Msgs) ExitCodeWithOptions(o ExitCodeOptions) int {
+	return m.AllMsgs().ExitCodeWithOptions(o)
+}
+
+// ExitCode returns 1 if at least one ErrMsg is present and 0 otherwise
+func (m Msgs) ExitCode() int {
+	return m.AllMsgs().ExitCode()
+}
+
+// ExitCodeStrict returns 1 if at least one ErrMsg is present, 2 if
+// WarnMsg but no ErrMsg is present and 0 otherwise
+func (m Msgs) ExitCodeStrict() int {
+	return m.AllMsgs().ExitCodeStrict()
+}
+
+// OK returns true if no ErrMsg is present
+func (m Msgs) OK() bool {
+	return len(m.Errors().Items) == 0
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMsgsExitCode(t *testing.T) {
+	tests := map[string]struct {
+		err, warn, info bool
+		expected        int
+		expectedStrict  int
+	}{
+		"101": {false, false, false, 0, 0},
+		"102": {false, false, true, 0, 0},
+		"103": {false, true, false, 0, 2},
+		"104": {false, true, true, 0, 2},
+		"105": {true, false, false, 1, 1},
+		"106": {true, false, true, 1, 1},
+		"107": {true, true, false, 1, 1},
+		"108": {true, true, true, 1, 1},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := &Msgs{}
+			if mock.err {
+				m.AddError(errors.New("e1"))
+			}
+			if mock.warn {
+				m.AddWarn("w1")
+			}
+			if mock.info {
+				m.AddInfo("i1")
+			}
+			if m.ExitCode() != mock.expected || m.AllMsgs().ExitCode() != mock.expected {
+				t.Fatalf("Test '%s' failed: expected exit code %d: actual %d", name, mock.expected, m.ExitCode())
+			}
+			if m.ExitCodeStrict() != mock.expectedStrict || m.AllMsgs().ExitCodeStrict() != mock.expectedStrict {
+				t.Fatalf("Test '%s' failed: expected strict exit code %d: actual %d", name, mock.expectedStrict, m.ExitCodeStrict())
+			}
+			if m.OK() == mock.err {
+				t.Fatalf("Test '%s' failed: expected ok '%t': actual '%t'", name, !mock.err, m.OK())
+			}
+		})
+	}
+}
+
+func TestMsgsExitCodeWithOptions(t *testing.T) {
+	o := ExitCodeOptions{OKCode: 10, ErrorCode: 20, WarnCode: 30, Strict: true}
+	tests := map[string]struct {
+		msgs     *Msgs
+		expected int
+	}{
+		"101": {(&Msgs{}).AddInfo("i1"), 10},
+		"102": {(&Msgs{}).AddWarn("w1"), 30},
+		"103": {(&Msgs{}).AddWarn("w1").AddError(errors.New("e1")), 20},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := mock.msgs.ExitCodeWithOptions(o); actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected exit code %d: actual %d", name, mock.expected, actual)
+			}
+		})
+	}
+}
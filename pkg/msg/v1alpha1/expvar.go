@@ -0,0 +1,42 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"expvar"
+	"strconv"
+)
+
+// summaryVar is an expvar.Var that renders the summary of a snapshot of
+// the guarded messages
+type summaryVar struct {
+	s *SafeMsgs
+}
+
+// String is an implementation of expvar.Var interface. It returns the
+// summary as a json string as required by expvar.
+func (v summaryVar) String() string {
+	return strconv.Quote(v.s.Snapshot().SummaryString())
+}
+
+// PublishExpvar publishes the summary of the guarded messages as an
+// expvar variable of the provided name e.g. to be served on
+// '/debug/vars'. It panics if the name is already in use as is the case
+// for expvar.Publish.
+func (s *SafeMsgs) PublishExpvar(name string) {
+	expvar.Publish(name, summaryVar{s: s})
+}
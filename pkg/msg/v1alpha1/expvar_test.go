@@ -0,0 +1,56 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"testing"
+)
+
+func TestSafeMsgsPublishExpvar(t *testing.T) {
+	s := NewSafeMsgs()
+	s.PublishExpvar("test.msgs")
+	v := expvar.Get("test.msgs")
+	if v == nil {
+		t.Fatalf("Test failed: expected a published variable")
+	}
+
+	tests := map[string]struct {
+		add      func()
+		expected string
+	}{
+		"101": {func() {}, "errors: 0, warns: 0, infos: 0, skips: 0"},
+		"102": {func() { s.AddWarn("w1").AddError(errors.New("e1")) }, "errors: 1, warns: 1, infos: 0, skips: 0"},
+		"103": {func() { s.AddInfo("i1") }, "errors: 1, warns: 1, infos: 1, skips: 0"},
+	}
+
+	for _, name := range []string{"101", "102", "103"} {
+		mock := tests[name]
+		t.Run(name, func(t *testing.T) {
+			mock.add()
+			var actual string
+			if err := json.Unmarshal([]byte(v.String()), &actual); err != nil {
+				t.Fatalf("Test '%s' failed: expected a json string: actual '%s'", name, v.String())
+			}
+			if actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, actual)
+			}
+		})
+	}
+}
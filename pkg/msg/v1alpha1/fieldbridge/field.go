@@ -0,0 +1,127 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fieldbridge converts messages to and from a field.ErrorList
+// so that validation errors of Kubernetes objects can be merged into
+// messages and messages can be reported as validation errors e.g. in an
+// admission response
+package fieldbridge
+
+import (
+	"errors"
+	"strings"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// FieldKey is the field of a message holding the path of the
+	// invalid field e.g. 'spec.replicas'
+	FieldKey = "field"
+	// ValueKey is the field of a message holding the invalid value
+	ValueKey = "value"
+	// DetailKey is the field of a message holding the detail of the
+	// validation error if any
+	DetailKey = "detail"
+)
+
+// errorType returns the validation error type of the provided code or
+// ErrorTypeInternal if the code is not a validation error type
+func errorType(code string) field.ErrorType {
+	switch t := field.ErrorType(code); t {
+	case field.ErrorTypeNotFound, field.ErrorTypeRequired, field.ErrorTypeDuplicate,
+		field.ErrorTypeInvalid, field.ErrorTypeNotSupported, field.ErrorTypeForbidden,
+		field.ErrorTypeTooLong, field.ErrorTypeInternal:
+		return t
+	default:
+		return field.ErrorTypeInternal
+	}
+}
+
+// FromFieldErrors returns the provided validation errors as ErrMsg
+// messages. The type of a validation error becomes the code of its
+// message e.g. 'FieldValueInvalid' while the field path, the invalid
+// value and the detail are held as the fields of the message, see
+// FieldKey, ValueKey and DetailKey. The description is the text of the
+// validation error and hence starts with the field path.
+func FromFieldErrors(el field.ErrorList) (m *msg.Msgs) {
+	m = &msg.Msgs{}
+	for _, e := range el {
+		if e == nil {
+			continue
+		}
+		fields := map[string]interface{}{FieldKey: e.Field, ValueKey: e.BadValue}
+		if len(e.Detail) != 0 {
+			fields[DetailKey] = e.Detail
+		}
+		m.Append(msg.MsgView{
+			Type:   msg.ErrMsg,
+			Desc:   e.Error(),
+			Err:    e,
+			Code:   string(e.Type),
+			Fields: fields,
+		})
+	}
+	return
+}
+
+// ToFieldErrors returns the ErrMsg messages as validation errors whose
+// field paths are relative to the provided path if any. Messages built
+// via FromFieldErrors, including the ones that were serialized since,
+// are restored as is. Any other error is returned as an internal error
+// of the provided path. Messages of other types are ignored.
+func ToFieldErrors(m msg.Msgs, basePath *field.Path) (el field.ErrorList) {
+	for _, item := range m.View() {
+		if item.Type != msg.ErrMsg {
+			continue
+		}
+		el = append(el, toFieldError(item, basePath))
+	}
+	return
+}
+
+// toFieldError returns the provided error message as a validation error
+// whose field path is relative to the provided path
+func toFieldError(item msg.MsgView, basePath *field.Path) *field.Error {
+	if path, ok := item.Fields[FieldKey].(string); ok {
+		detail, _ := item.Fields[DetailKey].(string)
+		return &field.Error{Type: errorType(item.Code), Field: join(basePath, path), BadValue: item.Fields[ValueKey], Detail: detail}
+	}
+	var fe *field.Error
+	if errors.As(item.Err, &fe) {
+		c := *fe
+		c.Field = join(basePath, fe.Field)
+		return &c
+	}
+	return &field.Error{Type: field.ErrorTypeInternal, Field: join(basePath, ""), Detail: item.Desc}
+}
+
+// join returns the provided field path relative to the provided path
+func join(basePath *field.Path, path string) string {
+	if basePath == nil {
+		return path
+	}
+	base := basePath.String()
+	switch {
+	case len(path) == 0:
+		return base
+	case strings.HasPrefix(path, "["):
+		return base + path
+	default:
+		return base + "." + path
+	}
+}
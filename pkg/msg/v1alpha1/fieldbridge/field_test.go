@@ -0,0 +1,125 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldbridge
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// mockErrorList returns validation errors of nested field paths
+func mockErrorList() field.ErrorList {
+	spec := field.NewPath("spec")
+	return field.ErrorList{
+		field.Required(spec.Child("storageClassName"), "storage class is needed"),
+		field.Invalid(spec.Child("replicas"), "-1", "must be positive"),
+		field.Forbidden(spec.Child("pools").Index(1).Child("disks").Key("sdb"), "disk is in use"),
+	}
+}
+
+func TestFromFieldErrors(t *testing.T) {
+	m := FromFieldErrors(append(mockErrorList(), nil))
+	tests := map[string]struct {
+		index int
+		code  string
+		field string
+		desc  string
+		value interface{}
+	}{
+		"101": {0, "FieldValueRequired", "spec.storageClassName", "spec.storageClassName: Required value: storage class is needed", ""},
+		"102": {1, "FieldValueInvalid", "spec.replicas", `spec.replicas: Invalid value: "-1": must be positive`, "-1"},
+		"103": {2, "FieldValueForbidden", "spec.pools[1].disks[sdb]", "spec.pools[1].disks[sdb]: Forbidden: disk is in use", ""},
+	}
+	if len(m.Items) != 3 || !m.AllMsgs().HasError() {
+		t.Fatalf("Test failed: expected 3 errors: actual '%s'", m)
+	}
+	views := m.View()
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := views[mock.index]
+			if v.Type != msg.ErrMsg || v.Code != mock.code || v.Fields[FieldKey] != mock.field || v.Desc != mock.desc {
+				t.Fatalf("Test '%s' failed: expected '%s' '%s' '%s': actual '%#v'", name, mock.code, mock.field, mock.desc, v)
+			}
+			if v.Fields[ValueKey] != mock.value {
+				t.Fatalf("Test '%s' failed: expected value '%v': actual '%#v'", name, mock.value, v.Fields)
+			}
+			var fe *field.Error
+			if !errors.As(v.Err, &fe) || fe != mockErrorListAt(m, mock.index) {
+				t.Fatalf("Test '%s' failed: expected original field error: actual '%v'", name, v.Err)
+			}
+		})
+	}
+}
+
+// mockErrorListAt returns the field error of the message at the
+// provided index
+func mockErrorListAt(m *msg.Msgs, i int) *field.Error {
+	fe, _ := m.Items[i].Err.(*field.Error)
+	return fe
+}
+
+func TestToFieldErrorsRoundTrip(t *testing.T) {
+	el := mockErrorList()
+	if actual := ToFieldErrors(*FromFieldErrors(el), nil); !reflect.DeepEqual(actual, el) {
+		t.Fatalf("Test failed: expected '%v': actual '%v'", el, actual)
+	}
+	b, err := json.Marshal(FromFieldErrors(el))
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	var m msg.Msgs
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	if actual := ToFieldErrors(m, nil); !reflect.DeepEqual(actual, el) {
+		t.Fatalf("Test failed: expected serialized round trip '%v': actual '%v'", el, actual)
+	}
+}
+
+func TestToFieldErrors(t *testing.T) {
+	base := field.NewPath("volumes").Index(0)
+	tests := map[string]struct {
+		msgs     *msg.Msgs
+		base     *field.Path
+		expected field.ErrorList
+	}{
+		"101": {&msg.Msgs{}, base, nil},
+		"102": {(&msg.Msgs{}).AddInfo("i1").AddWarn("w1").AddSkip("s1"), base, nil},
+		"103": {FromFieldErrors(field.ErrorList{field.Required(field.NewPath("size"), "")}), base,
+			field.ErrorList{field.Required(base.Child("size"), "")}},
+		"104": {FromFieldErrors(field.ErrorList{field.Invalid(field.NewPath("spec").Child("tags").Key("a"), "b", "bad")}), base,
+			field.ErrorList{field.Invalid(base.Child("spec").Child("tags").Key("a"), "b", "bad")}},
+		"105": {(&msg.Msgs{}).AddError(errors.New("pool is offline")), base,
+			field.ErrorList{field.InternalError(base, errors.New("pool is offline"))}},
+		"106": {(&msg.Msgs{}).AddError(errors.New("pool is offline")), nil,
+			field.ErrorList{{Type: field.ErrorTypeInternal, Detail: "pool is offline"}}},
+		"107": {(&msg.Msgs{}).AddError(field.Forbidden(field.NewPath("name"), "immutable")).AddInfo("i1"), base,
+			field.ErrorList{field.Forbidden(base.Child("name"), "immutable")}},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := ToFieldErrors(*mock.msgs, mock.base); !reflect.DeepEqual(actual, mock.expected) {
+				t.Fatalf("Test '%s' failed: expected '%v': actual '%v'", name, mock.expected, actual)
+			}
+		})
+	}
+}
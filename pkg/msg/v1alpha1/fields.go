@@ -0,0 +1,103 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// copyFields returns a shallow copy of the provided fields or nil if
+// there are none
+func copyFields(fields map[string]interface{}) map[string]interface{} {
+	if len(fields) == 0 {
+		return nil
+	}
+	c := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		c[k] = v
+	}
+	return c
+}
+
+// encodeFields returns the json form of the provided fields. Fields
+// are encoded as json by the binary formats since their values can be
+// of any type. Fields that can not be encoded as json are dropped.
+func encodeFields(fields map[string]interface{}) []byte {
+	if len(fields) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// decodeFields returns the fields represented by the provided json.
+// Numbers are restored as float64 as is the case for json.
+func decodeFields(b []byte) (fields map[string]interface{}) {
+	if len(b) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil
+	}
+	return
+}
+
+// fieldsEqual returns true if the provided fields have the same keys
+// and values. Values are compared by their json form so that fields
+// restored from a serialized form are equal to the original ones.
+func fieldsEqual(a, b map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	if len(a) == 0 || reflect.DeepEqual(a, b) {
+		return true
+	}
+	return bytes.Equal(encodeFields(a), encodeFields(b))
+}
+
+// Field returns the value of the provided field of this message and
+// true if the field is set
+func (m *msg) Field(key string) (v interface{}, found bool) {
+	if m == nil {
+		return nil, false
+	}
+	v, found = m.Fields[key]
+	return
+}
+
+// HasField returns a predicate that is true for messages having the
+// provided field
+func HasField(key string) msgPredicate {
+	return func(given *msg) bool {
+		_, found := given.Field(key)
+		return found
+	}
+}
+
+// FieldIs returns a predicate that is true for messages whose provided
+// field is equal to the provided value
+func FieldIs(key string, value interface{}) msgPredicate {
+	return func(given *msg) bool {
+		v, found := given.Field(key)
+		return found && reflect.DeepEqual(v, value)
+	}
+}
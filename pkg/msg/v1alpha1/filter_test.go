@@ -0,0 +1,103 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMsgsFilterInPlace(t *testing.T) {
+	tests := map[string]struct {
+		msgs      *Msgs
+		predicate msgPredicate
+		expected  string
+	}{
+		"101": {(&Msgs{}).AddInfo("i1").AddWarn("w1").AddInfo("i2").AddSkip("s1").AddInfo("i3"), IsInfo, "i1,i2,i3"},
+		"102": {(&Msgs{}).AddInfo("i1").AddInfo("i2"), IsInfo, "i1,i2"},
+		"103": {(&Msgs{}).AddInfo("i1").AddInfo("i2"), IsWarn, ""},
+		"104": {&Msgs{Items: []*msg{nil, &msg{Mtype: WarnMsg, Desc: "w1"}, nil}}, IsNotInfo, "w1"},
+		"105": {&Msgs{}, IsInfo, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			before := mock.msgs.String()
+			backing := mock.msgs.Items
+			mock.msgs.FilterInPlace(mock.predicate)
+			if descs(mock.msgs) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(mock.msgs))
+			}
+			for i := len(mock.msgs.Items); i < len(backing); i++ {
+				if backing[i] != nil {
+					t.Fatalf("Test '%s' failed: expected dropped message at %d to be released", name, i)
+				}
+			}
+			if len(mock.msgs.Items) != len(backing) && mock.msgs.String() == before {
+				t.Fatalf("Test '%s' failed: expected cached string to be invalidated", name)
+			}
+		})
+	}
+}
+
+func TestMsgsFilterCapacity(t *testing.T) {
+	m := mockLargeMsgs(100)
+	f := m.Filter(IsWarn)
+	if len(f.Items) != 25 || cap(f.Items) != 25 {
+		t.Fatalf("Test failed: expected 25 messages with exact capacity: actual %d %d", len(f.Items), cap(f.Items))
+	}
+	if f := m.Filter(func(*msg) bool { return false }); f.Items != nil {
+		t.Fatalf("Test failed: expected no allocation without matches: actual '%v'", f.Items)
+	}
+}
+
+// mockSparseMsgs returns n messages where every tenth one is a warning
+func mockSparseMsgs(n int) *Msgs {
+	m := &Msgs{}
+	for i := 0; i < n; i++ {
+		if i%10 == 0 {
+			m.AddWarn(fmt.Sprintf("warn %d", i))
+			continue
+		}
+		m.AddInfo(fmt.Sprintf("info %d", i))
+	}
+	return m
+}
+
+func BenchmarkMsgsFilter(b *testing.B) {
+	m := mockSparseMsgs(10000)
+	b.Run("Filter", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m.Filter(IsWarn)
+		}
+	})
+	b.Run("Chained", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m.NonErrors().Warns()
+		}
+	})
+	b.Run("FilterInPlace", func(b *testing.B) {
+		b.ReportAllocs()
+		items := make([]*msg, len(m.Items))
+		for i := 0; i < b.N; i++ {
+			copy(items, m.Items)
+			(&Msgs{Items: items}).FilterInPlace(IsWarn)
+		}
+	})
+}
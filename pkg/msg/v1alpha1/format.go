@@ -0,0 +1,76 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"io"
+)
+
+// format writes the provided renderings as per the verb and flags.
+// Verbs %s and %v write the compact form, %+v writes the detailed
+// form and %#v writes the go syntax form. Width and precision are
+// ignored.
+func format(f fmt.State, verb rune, compact, detailed, gostring func() string) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		io.WriteString(f, gostring())
+	case verb == 'v' && f.Flag('+'):
+		io.WriteString(f, detailed())
+	case verb == 'v' || verb == 's':
+		io.WriteString(f, compact())
+	default:
+		fmt.Fprintf(f, "%%!%c(%s)", verb, compact())
+	}
+}
+
+// compact returns this message as a single line e.g. 'warn: pool is
+// degraded'
+func (m *msg) compact() string {
+	if m == nil {
+		return "<nil>"
+	}
+	m = m.forOutput()
+	return fmt.Sprintf("%s: %s", m.Mtype, m.Description())
+}
+
+// Format is an implementation of fmt.Formatter interface. Verbs %s and
+// %v render a single line, %+v renders the yaml as returned by String
+// and %#v renders as returned by GoString.
+func (m *msg) Format(f fmt.State, verb rune) {
+	if m == nil {
+		io.WriteString(f, "<nil>")
+		return
+	}
+	format(f, verb, m.compact, m.String, m.GoString)
+}
+
+// Format is an implementation of fmt.Formatter interface. Verbs %s and
+// %v render the summary as returned by SummaryString, %+v renders the
+// yaml as returned by String and %#v renders as returned by GoString.
+// A struct embedding messages should implement Format as well in order
+// to be rendered via its own String.
+func (m Msgs) Format(f fmt.State, verb rune) {
+	format(f, verb, m.SummaryString, m.String, m.GoString)
+}
+
+// Format is an implementation of fmt.Formatter interface. Verbs %s and
+// %v render the summary of all the messages, %+v renders the yaml as
+// returned by String and %#v renders as returned by GoString.
+func (a AllMsgs) Format(f fmt.State, verb rune) {
+	format(f, verb, a.ToMsgs().SummaryString, a.String, a.GoString)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	m := (&Msgs{}).AddWarn("w1").AddError(errors.New("e1"))
+	a := m.AllMsgs()
+	summary := "errors: 1, warns: 1, infos: 0, skips: 0"
+
+	tests := map[string]struct {
+		format   string
+		o        interface{}
+		expected string
+	}{
+		"101": {"%v", m.Items[0], "warn: w1"},
+		"102": {"%s", m.Items[1], "error: e1"},
+		"103": {"%+v", m.Items[0], m.Items[0].String()},
+		"104": {"%#v", m.Items[0], m.Items[0].GoString()},
+		"105": {"%v", *m, summary},
+		"106": {"%s", *m, summary},
+		"107": {"%+v", *m, m.String()},
+		"108": {"%#v", *m, m.GoString()},
+		"109": {"%v", a, summary},
+		"110": {"%+v", a, a.String()},
+		"111": {"%#v", a, a.GoString()},
+		"112": {"%20.3s", *m, summary},
+		"113": {"%-8v", m.Items[0], "warn: w1"},
+		"114": {"%d", *m, "%!d(" + summary + ")"},
+		"115": {"%v", (*msg)(nil), "<nil>"},
+		"116": {"%v", m, summary},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := fmt.Sprintf(mock.format, mock.o)
+			if actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, actual)
+			}
+		})
+	}
+
+	if m.String() == summary || len(m.String()) == 0 {
+		t.Fatalf("Test failed: expected String to render yaml: actual '%s'", m.String())
+	}
+}
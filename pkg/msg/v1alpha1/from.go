@@ -0,0 +1,58 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+)
+
+// FromErrors returns the provided errors as error messages. Nil errors
+// are skipped.
+func FromErrors(errs ...error) (m *Msgs) {
+	m = &Msgs{}
+	for _, err := range errs {
+		m.AddError(err)
+	}
+	return
+}
+
+// FromStrings returns the provided descriptions as messages of the
+// provided type. Empty descriptions are skipped. An error message is
+// returned instead if the provided type is not a valid one.
+func FromStrings(t MsgType, descs ...string) (m *Msgs) {
+	m = &Msgs{}
+	if !t.IsValid() {
+		return m.AddError(invalidMsgTypeError(string(t)))
+	}
+	for _, d := range descs {
+		if len(d) == 0 {
+			continue
+		}
+		if t == ErrMsg {
+			m.AddError(errors.New(d))
+			continue
+		}
+		m.add(m.newMsg(msg{Mtype: t, Desc: d}))
+	}
+	return
+}
+
+// From returns all the provided messages as a single list. It is same
+// as ToMsgs.
+func From(all AllMsgs) *Msgs {
+	return all.ToMsgs()
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFromErrors(t *testing.T) {
+	tests := map[string]struct {
+		errs     []error
+		expected string
+	}{
+		"101": {nil, ""},
+		"102": {[]error{errors.New("e1"), nil, errors.New("e2")}, "e1,e2"},
+		"103": {[]error{nil, nil}, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := FromErrors(mock.errs...)
+			if m == nil {
+				t.Fatalf("Test '%s' failed: expected non nil msgs", name)
+			}
+			if descs(m) != mock.expected || len(m.Errors().Items) != len(m.Items) {
+				t.Fatalf("Test '%s' failed: expected errors '%s': actual '%s'", name, mock.expected, m)
+			}
+		})
+	}
+}
+
+func TestFromStrings(t *testing.T) {
+	tests := map[string]struct {
+		mtype    MsgType
+		descs    []string
+		expected string
+		count    int
+	}{
+		"101": {WarnMsg, []string{"w1", "", "w2"}, "w1,w2", 2},
+		"102": {InfoMsg, nil, "", 0},
+		"103": {ErrMsg, []string{"e1"}, "e1", 1},
+		"104": {"Warn", []string{"w1"},
+			"invalid message type 'Warn': must be one of deprecation, error, info, progress, skip, warn", 0},
+		"105": {"fatal", []string{"f1"},
+			"invalid message type 'fatal': must be one of deprecation, error, info, progress, skip, warn", 0},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := FromStrings(mock.mtype, mock.descs...)
+			if m == nil {
+				t.Fatalf("Test '%s' failed: expected non nil msgs", name)
+			}
+			if descs(m) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(m))
+			}
+			if n := len(m.Filter(func(given *msg) bool { return given.Mtype == mock.mtype }).Items); n != mock.count {
+				t.Fatalf("Test '%s' failed: expected %d messages of type '%s': actual %d", name, mock.count, mock.mtype, n)
+			}
+		})
+	}
+	if m := FromStrings(ErrMsg, "e1"); m.Items[0].Err == nil {
+		t.Fatalf("Test failed: expected error message to hold an error")
+	}
+}
+
+func TestFrom(t *testing.T) {
+	if m := From(nil); m == nil || len(m.Items) != 0 {
+		t.Fatalf("Test failed: expected empty msgs: actual '%v'", m)
+	}
+	m := From((&Msgs{}).AddInfo("i1").AddError(errors.New("e1")).AllMsgs()).AddWarn("w1")
+	if descs(m) != "e1,i1,w1" {
+		t.Fatalf("Test failed: expected 'e1,i1,w1': actual '%s'", descs(m))
+	}
+}
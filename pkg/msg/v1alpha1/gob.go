@@ -0,0 +1,132 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"encoding/gob"
+	"time"
+)
+
+// gobMsg is the gob representation of a message. The error is encoded
+// as its string form since an error interface can not be encoded.
+type gobMsg struct {
+	Nil      bool // true for a nil item
+	Type     MsgType
+	Desc     string
+	Err      string
+	HasErr   bool
+	ErrCode  string
+	Code     string
+	Source   string
+	Labels   map[string]string
+	Percent  float64
+	Time     *time.Time
+	Caller   string
+	ID       string
+	Reason   SkipReason
+	Priority int
+	Category ErrorCategory
+	Fields   []byte // json form of the fields
+	Object   string // compact form of the object
+	Count    int
+	LastSeen *time.Time
+}
+
+// EncodeGob returns the provided messages in gob format. Nil items are
+// preserved as nil items. It is a function rather than an
+// implementation of gob.GobEncoder since Msgs is embedded by structs
+// that are encoded as a whole.
+func EncodeGob(m Msgs) ([]byte, error) {
+	items := make([]gobMsg, 0, len(m.Items))
+	for _, item := range m.Items {
+		if item == nil {
+			items = append(items, gobMsg{Nil: true})
+			continue
+		}
+		item = item.forOutput()
+		g := gobMsg{
+			Type:     item.Mtype,
+			Desc:     item.Description(),
+			Code:     item.Code,
+			Source:   item.Source,
+			Labels:   item.Labels,
+			Percent:  item.Percent,
+			Time:     item.Time,
+			Caller:   item.Caller,
+			ID:       item.ID,
+			Reason:   item.Reason,
+			Priority: item.Priority,
+			Category: item.Category,
+			Fields:   encodeFields(item.Fields),
+			Object:   objectOf(item).String(),
+			Count:    item.Count,
+			LastSeen: item.LastSeen,
+		}
+		if item.Err != nil {
+			g.Err, g.HasErr, g.ErrCode = item.Err.Error(), true, item.ErrCode
+		}
+		items = append(items, g)
+	}
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(items); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// DecodeGob returns the messages encoded via EncodeGob. Errors are
+// restored such that they wrap the error registered against their code
+// if any.
+func DecodeGob(b []byte) (m *Msgs, err error) {
+	var items []gobMsg
+	if err = gob.NewDecoder(bytes.NewReader(b)).Decode(&items); err != nil {
+		return nil, err
+	}
+	m = &Msgs{Items: make([]*msg, 0, len(items))}
+	for _, g := range items {
+		if g.Nil {
+			m.Items = append(m.Items, nil)
+			continue
+		}
+		item := &msg{
+			Mtype:    g.Type,
+			Desc:     g.Desc,
+			Code:     g.Code,
+			Source:   g.Source,
+			Labels:   g.Labels,
+			Percent:  g.Percent,
+			Time:     g.Time,
+			Caller:   g.Caller,
+			ID:       g.ID,
+			Reason:   g.Reason,
+			Priority: g.Priority,
+			Category: g.Category,
+			Fields:   decodeFields(g.Fields),
+			Object:   parseObjectPtr(g.Object),
+			Count:    g.Count,
+			LastSeen: g.LastSeen,
+			seq:      nextSeq(),
+		}
+		if g.HasErr {
+			item.Err, item.ErrCode = restoreError(g.Err, g.ErrCode), g.ErrCode
+		}
+		m.Items = append(m.Items, item)
+	}
+	m.touch()
+	return m, nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+)
+
+func TestMsgsGob(t *testing.T) {
+	tests := map[string]struct {
+		msgs *Msgs
+	}{
+		"101": {&Msgs{}},
+		"102": {(&Msgs{}).AddInfo("i1").AddWarn("w1").AddSkip("s1").AddError(errors.New("e1"))},
+		"103": {&Msgs{Items: []*msg{nil, &msg{Mtype: ErrMsg, Desc: "e1"}, nil}}},
+		"104": {(&Msgs{}).WithSource("pool").AddErrorCode("PoolDown", errors.New("e1")).
+			WithLabels(map[string]string{"replica": "2"}).AddProgress("p1", 50).
+			AddInfoLazy(func() string { return "lazy" }).AddDeprecation("d1")},
+		"105": {&Msgs{Items: []*msg{&msg{Mtype: "custom", Desc: "c1"}}}},
+		"106": {NewMsgs(WithTimestamps(), WithCaller()).AddInfo("i1").AddWarn("w1")},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, err := EncodeGob(*mock.msgs)
+			if err != nil {
+				t.Fatalf("Test '%s' failed: expected no encode error: actual '%s'", name, err)
+			}
+			actual, err := DecodeGob(b)
+			if err != nil {
+				t.Fatalf("Test '%s' failed: expected no decode error: actual '%s'", name, err)
+			}
+			if len(actual.Items) != len(mock.msgs.Items) {
+				t.Fatalf("Test '%s' failed: expected %d items: actual %d items", name, len(mock.msgs.Items), len(actual.Items))
+			}
+			for i, e := range mock.msgs.Items {
+				a := actual.Items[i]
+				if e == nil || a == nil {
+					if e != a {
+						t.Fatalf("Test '%s' failed: expected item %d '%v': actual '%v'", name, i, e, a)
+					}
+					continue
+				}
+				if !a.equal(e, true) {
+					t.Fatalf("Test '%s' failed: expected item %d '%#v': actual '%#v'", name, i, e, a)
+				}
+				if (e.Err == nil) != (a.Err == nil) || (e.Err != nil && e.Err.Error() != a.Err.Error()) {
+					t.Fatalf("Test '%s' failed: expected item %d err '%v': actual '%v'", name, i, e.Err, a.Err)
+				}
+			}
+		})
+	}
+}
+
+func TestMsgsEmbeddedGob(t *testing.T) {
+	type command struct {
+		ID string
+		*Msgs
+	}
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(command{ID: "c1", Msgs: (&Msgs{}).AddWarn("w1")}); err != nil {
+		t.Fatalf("Test failed: expected no encode error: actual '%s'", err)
+	}
+	var actual command
+	if err := gob.NewDecoder(&b).Decode(&actual); err != nil {
+		t.Fatalf("Test failed: expected no decode error: actual '%s'", err)
+	}
+	if actual.ID != "c1" || descs(actual.Msgs) != "w1" {
+		t.Fatalf("Test failed: expected embedding struct to retain its fields: actual '%#v'", actual)
+	}
+}
+
+func TestDecodeGobInvalid(t *testing.T) {
+	if m, err := DecodeGob([]byte("not gob")); m != nil || err == nil {
+		t.Fatalf("Test failed: expected decode error: actual '%v' '%v'", m, err)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// goPkg qualifies the identifiers of this package in go syntax
+const goPkg = "v1alpha1."
+
+// goMsgTypes holds the names of the declared message types
+var goMsgTypes = map[MsgType]string{
+	InfoMsg:        "InfoMsg",
+	ErrMsg:         "ErrMsg",
+	WarnMsg:        "WarnMsg",
+	SkipMsg:        "SkipMsg",
+	ProgressMsg:    "ProgressMsg",
+	DeprecationMsg: "DeprecationMsg",
+}
+
+// goMsgType returns the provided message type in go syntax
+func goMsgType(t MsgType) string {
+	if name, found := goMsgTypes[t]; found {
+		return goPkg + name
+	}
+	return fmt.Sprintf("%sMsgType(%q)", goPkg, string(t))
+}
+
+// goSkipReason returns the provided skip reason in go syntax
+func goSkipReason(r SkipReason) string {
+	switch r {
+	case AlreadyExists, NotApplicable, Disabled, DependencyMissing:
+		return goPkg + string(r)
+	}
+	return fmt.Sprintf("%sSkipReason(%q)", goPkg, string(r))
+}
+
+// goErrorCategory returns the provided error category in go syntax
+func goErrorCategory(c ErrorCategory) string {
+	switch c {
+	case TransientError, PermanentError, ConflictError, UnknownError:
+		return goPkg + string(c) + "Error"
+	}
+	return fmt.Sprintf("%sErrorCategory(%q)", goPkg, string(c))
+}
+
+// goAdd returns the method call that adds this message in go syntax
+// e.g. '.AddWarn("w1")'. Properties that can not be set by the add
+// methods e.g. labels are left out.
+func (m *msg) goAdd() string {
+	m = m.forOutput()
+	d := strconv.Quote(m.Description())
+	if add := m.goAddFor(d); len(add) != 0 {
+		return add
+	}
+	switch m.Mtype {
+	case InfoMsg:
+		if m.Priority != 0 {
+			return fmt.Sprintf(".AddInfoP(%d, %s)", m.Priority, d)
+		}
+		return fmt.Sprintf(".AddInfo(%s)", d)
+	case WarnMsg:
+		if len(m.Code) != 0 {
+			return fmt.Sprintf(".AddWarnCode(%q, %s)", m.Code, d)
+		}
+		if m.Priority != 0 {
+			return fmt.Sprintf(".AddWarnP(%d, %s)", m.Priority, d)
+		}
+		return fmt.Sprintf(".AddWarn(%s)", d)
+	case SkipMsg:
+		if len(m.Reason) != 0 {
+			return fmt.Sprintf(".AddSkipReason(%s, %s)", goSkipReason(m.Reason), d)
+		}
+		return fmt.Sprintf(".AddSkip(%s)", d)
+	case ErrMsg:
+		e := fmt.Sprintf("errors.New(%s)", d)
+		if len(m.Code) != 0 {
+			return fmt.Sprintf(".AddErrorCode(%q, %s)", m.Code, e)
+		}
+		if m.Priority != 0 {
+			return fmt.Sprintf(".AddErrorP(%d, %s)", m.Priority, e)
+		}
+		if len(m.Category) != 0 {
+			return fmt.Sprintf(".AddErrorCategorized(%s, %s)", goErrorCategory(m.Category), e)
+		}
+		return fmt.Sprintf(".AddError(%s)", e)
+	case DeprecationMsg:
+		return fmt.Sprintf(".AddDeprecation(%s)", d)
+	case ProgressMsg:
+		return fmt.Sprintf(".AddProgress(%s, %s)", d, strconv.FormatFloat(m.Percent, 'g', -1, 64))
+	default:
+		return fmt.Sprintf(".Merge(%sFromStrings(%s, %s))", goPkg, goMsgType(m.Mtype), d)
+	}
+}
+
+// goAddFor returns the method call that adds this message about its
+// object in go syntax e.g. '.AddWarnFor(v1alpha1.ObjectRef{...}, "w1")'
+// or an empty string if it is not about an object
+func (m *msg) goAddFor(d string) string {
+	if m.Object == nil || m.Object.IsEmpty() {
+		return ""
+	}
+	o := fmt.Sprintf("%#v", *m.Object)
+	switch m.Mtype {
+	case InfoMsg:
+		return fmt.Sprintf(".AddInfoFor(%s, %s)", o, d)
+	case WarnMsg:
+		return fmt.Sprintf(".AddWarnFor(%s, %s)", o, d)
+	case SkipMsg:
+		return fmt.Sprintf(".AddSkipFor(%s, %s)", o, d)
+	case ErrMsg:
+		return fmt.Sprintf(".AddErrorFor(%s, errors.New(%s))", o, d)
+	}
+	return ""
+}
+
+// goAdds returns the method calls that add the provided messages in go
+// syntax. Nil messages are left out.
+func goAdds(items []*msg) string {
+	var b strings.Builder
+	for _, item := range items {
+		if item != nil {
+			b.WriteString(item.goAdd())
+		}
+	}
+	return b.String()
+}
+
+// goMsgs returns go syntax that builds the provided messages via the
+// add methods
+func goMsgs(items []*msg) string {
+	adds := goAdds(items)
+	if len(adds) == 0 {
+		return "&" + goPkg + "Msgs{}"
+	}
+	return "(&" + goPkg + "Msgs{})" + adds
+}
+
+// GoString is an implementation of GoStringer interface. It returns go
+// syntax that builds this message e.g. '(&v1alpha1.Msgs{}).AddWarn("w1").Items[0]'.
+func (m *msg) GoString() string {
+	if m == nil {
+		return "(*" + goPkg + "msg)(nil)"
+	}
+	return goMsgs([]*msg{m}) + ".Items[0]"
+}
+
+// GoString is an implementation of GoStringer interface. It returns go
+// syntax that builds these messages via the add methods e.g.
+// '(&v1alpha1.Msgs{}).AddWarn("w1").AddError(errors.New("e1"))'.
+// Nil messages and properties that can not be set by the add methods
+// e.g. labels are left out.
+func (m Msgs) GoString() string {
+	return goMsgs(m.Items)
+}
+
+// GoString is an implementation of GoStringer interface. It returns go
+// syntax of a composite literal whose buckets are built as in
+// Msgs.GoString. Buckets are rendered in the order of ToMsgs.
+func (a AllMsgs) GoString() string {
+	if a == nil {
+		return goPkg + "AllMsgs(nil)"
+	}
+	var buckets []string
+	for _, mtype := range a.types() {
+		syntax := "{}"
+		if adds := goAdds(a[mtype].Items); len(adds) != 0 {
+			syntax = "*(&" + goPkg + "Msgs{})" + adds
+		}
+		buckets = append(buckets, fmt.Sprintf("%s: %s", goMsgType(mtype), syntax))
+	}
+	return goPkg + "AllMsgs{" + strings.Join(buckets, ", ") + "}"
+}
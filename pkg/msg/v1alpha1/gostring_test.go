@@ -0,0 +1,70 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"go/parser"
+	"testing"
+)
+
+func TestGoString(t *testing.T) {
+	m := (&Msgs{}).AddInfo(`say "hi"`).AddWarnCode("PoolFull", "95% used\n").AddSkipReason(AlreadyExists, "s1").
+		AddSkipReason("Throttled", "s2").AddErrorCode("NotFound", errors.New("e1")).AddError(errors.New("e2")).
+		AddDeprecation("d1").AddProgress("p1", 42.5)
+	m.Items = append(m.Items, nil, &msg{Mtype: "audit", Desc: "a1"})
+
+	tests := map[string]struct {
+		o        fmt.GoStringer
+		expected string
+	}{
+		"101": {m, `(&v1alpha1.Msgs{}).AddInfo("say \"hi\"").AddWarnCode("PoolFull", "95% used\n").` +
+			`AddSkipReason(v1alpha1.AlreadyExists, "s1").AddSkipReason(v1alpha1.SkipReason("Throttled"), "s2").` +
+			`AddErrorCode("NotFound", errors.New("e1")).AddError(errors.New("e2")).AddDeprecation("d1").` +
+			`AddProgress("p1", 42.5).Merge(v1alpha1.FromStrings(v1alpha1.MsgType("audit"), "a1"))`},
+		"102": {&Msgs{}, `&v1alpha1.Msgs{}`},
+		"103": {Msgs{Items: []*msg{nil}}, `&v1alpha1.Msgs{}`},
+		"104": {m.Items[5], `(&v1alpha1.Msgs{}).AddError(errors.New("e2")).Items[0]`},
+		"105": {(*msg)(nil), `(*v1alpha1.msg)(nil)`},
+		"106": {(&Msgs{}).AddWarn("w1").AddError(errors.New("e1")).AllMsgs(),
+			`v1alpha1.AllMsgs{v1alpha1.ErrMsg: *(&v1alpha1.Msgs{}).AddError(errors.New("e1")), ` +
+				`v1alpha1.WarnMsg: *(&v1alpha1.Msgs{}).AddWarn("w1"), v1alpha1.DeprecationMsg: {}, ` +
+				`v1alpha1.InfoMsg: {}, v1alpha1.SkipMsg: {}}`},
+		"107": {AllMsgs{}, `v1alpha1.AllMsgs{}`},
+		"108": {AllMsgs(nil), `v1alpha1.AllMsgs(nil)`},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := mock.o.GoString()
+			if actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, actual)
+			}
+			if _, err := parser.ParseExpr(actual); err != nil {
+				t.Fatalf("Test '%s' failed: expected go syntax: actual '%s': %s", name, actual, err)
+			}
+			// a nil message is formatted as '<nil>' by every verb
+			if item, ok := mock.o.(*msg); ok && item == nil {
+				return
+			}
+			if fmt.Sprintf("%#v", mock.o) != actual {
+				t.Fatalf("Test '%s' failed: expected %%#v to match: actual '%#v'", name, mock.o)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+)
+
+// KeyFunc abstracts deriving a grouping key from a message
+type KeyFunc func(t MsgType, desc string) string
+
+// ByType groups messages by their type similar to AllMsgs
+func ByType(t MsgType, desc string) string {
+	return string(t)
+}
+
+// BySourcePrefix groups messages by the part of their description
+// before the provided separator e.g. 'pvc-1' for 'pvc-1: create failed'
+// with ':' as separator. Messages whose description does not contain
+// the separator are not grouped.
+func BySourcePrefix(sep string) KeyFunc {
+	return func(t MsgType, desc string) string {
+		i := strings.Index(desc, sep)
+		if i < 0 {
+			return ""
+		}
+		return strings.TrimSpace(desc[:i])
+	}
+}
+
+// GroupBy returns the non nil messages grouped by the key returned by
+// the provided function. Messages for which the key is empty are not
+// grouped. Messages retain their order within a group.
+func (m Msgs) GroupBy(key KeyFunc) (g map[string]Msgs) {
+	g = map[string]Msgs{}
+	for _, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		k := key(item.Mtype, item.Description())
+		if len(k) == 0 {
+			continue
+		}
+		b := g[k]
+		b.Items = append(b.Items, item)
+		g[k] = b
+	}
+	return
+}
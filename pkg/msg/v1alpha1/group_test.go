@@ -0,0 +1,75 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMsgsGroupBy(t *testing.T) {
+	m := (&Msgs{}).AddInfo("pvc-1: created").AddWarn("pvc-2: degraded").AddInfo("no volume").
+		AddError(errors.New("pvc-1: snapshot failed")).AddSkip("pvc-2 : skipped")
+	tests := map[string]struct {
+		key      KeyFunc
+		expected map[string]string
+	}{
+		"101": {BySourcePrefix(":"), map[string]string{
+			"pvc-1": "pvc-1: created,pvc-1: snapshot failed",
+			"pvc-2": "pvc-2: degraded,pvc-2 : skipped",
+		}},
+		"102": {ByType, map[string]string{
+			"info":  "pvc-1: created,no volume",
+			"warn":  "pvc-2: degraded",
+			"error": "pvc-1: snapshot failed",
+			"skip":  "pvc-2 : skipped",
+		}},
+		"103": {func(MsgType, string) string { return "all" }, map[string]string{
+			"all": "pvc-1: created,pvc-2: degraded,no volume,pvc-1: snapshot failed,pvc-2 : skipped",
+		}},
+		"104": {func(MsgType, string) string { return "" }, map[string]string{}},
+		"105": {BySourcePrefix("#"), map[string]string{}},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := m.GroupBy(mock.key)
+			if len(g) != len(mock.expected) {
+				t.Fatalf("Test '%s' failed: expected %d groups: actual %d groups", name, len(mock.expected), len(g))
+			}
+			for k, expected := range mock.expected {
+				b := g[k]
+				if descs(&b) != expected {
+					t.Fatalf("Test '%s' failed: expected group '%s' to be '%s': actual '%s'", name, k, expected, descs(&b))
+				}
+			}
+		})
+	}
+}
+
+func TestMsgsGroupByFilter(t *testing.T) {
+	m := (&Msgs{}).AddInfo("pvc-1: created").AddError(errors.New("pvc-1: snapshot failed")).
+		AddInfo("pvc-2: created")
+	g := m.GroupBy(BySourcePrefix(":"))
+	errs := g["pvc-1"].Filter(IsErr)
+	if descs(&errs) != "pvc-1: snapshot failed" {
+		t.Fatalf("Test failed: expected errors of pvc-1: actual '%s'", descs(&errs))
+	}
+	if errs := g["pvc-2"].Filter(IsErr); len(errs.Items) != 0 {
+		t.Fatalf("Test failed: expected no errors for pvc-2: actual '%s'", descs(&errs))
+	}
+}
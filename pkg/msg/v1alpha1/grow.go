@@ -0,0 +1,32 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Grow reserves room for at least n more messages such that as many
+// messages can be added or merged without growing the list again. It
+// is meant to be called ahead of a batch of known size e.g. similar to
+// strings.Builder.Grow. A count of zero or less is ignored.
+func (m *Msgs) Grow(n int) (u *Msgs) {
+	m.mustNotBeNil("Grow")
+	if n <= 0 || cap(m.Items)-len(m.Items) >= n {
+		return m
+	}
+	items := make([]*msg, len(m.Items), 2*cap(m.Items)+n)
+	copy(items, m.Items)
+	m.Items = items
+	return m
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// mockBatch returns a list of n messages of alternating types
+func mockBatch(n int) *Msgs {
+	m := &Msgs{}
+	for i := 0; i < n; i++ {
+		if i%2 == 0 {
+			m.AddWarn(fmt.Sprintf("w%d", i))
+			continue
+		}
+		m.AddInfo(fmt.Sprintf("i%d", i))
+	}
+	return m
+}
+
+func TestMsgsGrow(t *testing.T) {
+	tests := map[string]struct {
+		existing int
+		grow     int
+		minCap   int
+	}{
+		"101": {0, 0, 0},
+		"102": {0, -1, 0},
+		"103": {0, 10, 10},
+		"104": {3, 10, 13},
+		"105": {4, 1, 5},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			m := mockBatch(mock.existing)
+			descs := strings.Join(m.Descriptions(), ",")
+			m.Grow(mock.grow)
+			if cap(m.Items) < mock.minCap {
+				t.Fatalf("Test '%s' failed: expected capacity of at least %d: actual %d", name, mock.minCap, cap(m.Items))
+			}
+			if actual := strings.Join(m.Descriptions(), ","); actual != descs {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, descs, actual)
+			}
+		})
+	}
+}
+
+func TestMsgsGrowAvoidsAllocs(t *testing.T) {
+	m := &Msgs{}
+	m.Grow(100)
+	room := cap(m.Items)
+	allocs := testing.AllocsPerRun(10, func() {
+		m.Items = m.Items[:0]
+		for i := 0; i < 100; i++ {
+			m.AddError(errMockQuotaExceeded)
+		}
+	})
+	if cap(m.Items) != room {
+		t.Fatalf("Test failed: expected reserved room to be used: actual capacity %d", cap(m.Items))
+	}
+	if allocs > 100 {
+		t.Fatalf("Test failed: expected only messages to be allocated: actual %v allocs", allocs)
+	}
+}
+
+func TestMsgsMergeAllocs(t *testing.T) {
+	s := mockBatch(1000)
+	into := &Msgs{}
+	if allocs := testing.AllocsPerRun(10, func() { into = (&Msgs{}).Merge(s) }); allocs > 3 {
+		t.Fatalf("Test failed: expected merge into an empty list to allocate at once: actual %v allocs", allocs)
+	}
+	if len(into.Items) != 1000 || cap(into.Items) != 1000 {
+		t.Fatalf("Test failed: expected exact room for merged messages: actual %d of %d", len(into.Items), cap(into.Items))
+	}
+	acc := (&Msgs{}).Grow(11 * 1000)
+	acc.Merge(s)
+	if allocs := testing.AllocsPerRun(10, func() { acc.Merge(s) }); allocs != 0 {
+		t.Fatalf("Test failed: expected merge into reserved room to not allocate: actual %v allocs", allocs)
+	}
+	if len(acc.Items) != 12*1000 {
+		t.Fatalf("Test failed: expected %d messages: actual %d", 12*1000, len(acc.Items))
+	}
+}
+
+func TestMsgsMergeLimitedGrow(t *testing.T) {
+	m := (&Msgs{}).WithLimit(2, DropOldest)
+	m.Merge(mockBatch(1000))
+	if cap(m.Items) > 2*1000 || m.Dropped() != 998 {
+		t.Fatalf("Test failed: expected room up to limit only: actual capacity %d, dropped %d", cap(m.Items), m.Dropped())
+	}
+	if actual := strings.Join(m.Descriptions(), ","); actual != "dropped 998 older messages,w998,i999" {
+		t.Fatalf("Test failed: expected messages as per limit: actual '%s'", actual)
+	}
+}
+
+func TestAllMsgsToMsgsAllocs(t *testing.T) {
+	m := mockBatch(100).AddError(errors.New("e1")).AddSkip("s1")
+	a := m.AllMsgs()
+	types := testing.AllocsPerRun(10, func() { a.types() })
+	allocs := testing.AllocsPerRun(10, func() { a.ToMsgs() })
+	if allocs > types+2 {
+		t.Fatalf("Test failed: expected output to be allocated at once: actual %v allocs vs %v for types", allocs, types)
+	}
+	if c := cap(a.ToMsgs().Items); c != 102 {
+		t.Fatalf("Test failed: expected exact capacity of 102: actual %d", c)
+	}
+	if items := (AllMsgs{InfoMsg: Msgs{}}).ToMsgs().Items; items != nil {
+		t.Fatalf("Test failed: expected no items for empty buckets: actual '%v'", items)
+	}
+}
+
+func BenchmarkMsgsMergeRepeated(b *testing.B) {
+	s := mockBatch(1000)
+	b.Run("Merge", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			acc := &Msgs{}
+			for j := 0; j < 1000; j++ {
+				acc.Merge(s)
+			}
+		}
+	})
+	b.Run("GrowMerge", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			acc := (&Msgs{}).Grow(1000 * len(s.Items))
+			for j := 0; j < 1000; j++ {
+				acc.Merge(s)
+			}
+		}
+	})
+}
+
+func BenchmarkAllMsgsToMsgs(b *testing.B) {
+	a := mockBatch(1000).AddError(errors.New("e1")).AddSkip("s1").AllMsgs()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a.ToMsgs()
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package grpcbridge converts messages to and from a gRPC status so that
+// clients can recover the full list of messages from a gRPC response
+package grpcbridge
+
+import (
+	"errors"
+
+	"github.com/golang/protobuf/ptypes"
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CodeFunc abstracts picking a gRPC code from the given messages
+type CodeFunc func(a msg.AllMsgs) codes.Code
+
+// DefaultCode returns codes.Internal if at least one ErrMsg is present
+// and codes.OK otherwise
+func DefaultCode(a msg.AllMsgs) codes.Code {
+	if a.HasError() {
+		return codes.Internal
+	}
+	return codes.OK
+}
+
+// Status returns the provided messages as a gRPC status using DefaultCode
+func Status(a msg.AllMsgs) *status.Status {
+	return StatusWithCode(a, DefaultCode)
+}
+
+// StatusWithCode returns the provided messages as a gRPC status with the
+// code picked by the provided function.
+//
+// The description of the first error becomes the status message. All the
+// remaining messages are attached as a single errdetails.BadRequest whose
+// field violations hold the message type as Field and the message
+// description as Description.
+func StatusWithCode(a msg.AllMsgs, fn CodeFunc) *status.Status {
+	if fn == nil {
+		fn = DefaultCode
+	}
+	var (
+		message    string
+		firstErr   = true
+		violations []*errdetails.BadRequest_FieldViolation
+	)
+	for _, item := range a.ToMsgs().Items {
+		if item == nil {
+			continue
+		}
+		if firstErr && item.Mtype == msg.ErrMsg {
+			message, firstErr = item.Description(), false
+			continue
+		}
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       string(item.Mtype),
+			Description: item.Description(),
+		})
+	}
+	p := &spb.Status{Code: int32(fn(a)), Message: message}
+	if len(violations) != 0 {
+		// status.WithDetails refuses details on codes.OK, hence the
+		// details are attached to the underlying proto directly
+		d, err := ptypes.MarshalAny(&errdetails.BadRequest{FieldViolations: violations})
+		if err == nil {
+			p.Details = append(p.Details, d)
+		}
+	}
+	return status.FromProto(p)
+}
+
+// FromStatus reconstructs the messages from a gRPC status built via
+// Status or StatusWithCode. Messages of a MsgType other than ErrMsg,
+// WarnMsg, InfoMsg, SkipMsg or DeprecationMsg can not be reconstructed
+// and are dropped.
+func FromStatus(s *status.Status) (m *msg.Msgs) {
+	m = &msg.Msgs{}
+	if s == nil {
+		return
+	}
+	if len(s.Message()) != 0 {
+		m.AddError(errors.New(s.Message()))
+	}
+	for _, detail := range s.Details() {
+		br, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, v := range br.GetFieldViolations() {
+			switch msg.MsgType(v.GetField()) {
+			case msg.ErrMsg:
+				m.AddError(errors.New(v.GetDescription()))
+			case msg.WarnMsg:
+				m.AddWarn(v.GetDescription())
+			case msg.InfoMsg:
+				m.AddInfo(v.GetDescription())
+			case msg.SkipMsg:
+				m.AddSkip(v.GetDescription())
+			case msg.DeprecationMsg:
+				m.AddDeprecation(v.GetDescription())
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcbridge
+
+import (
+	"errors"
+	"testing"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockDescs returns the descriptions of the given messages per type
+func mockDescs(m msg.Msgs) map[msg.MsgType][]string {
+	descs := map[msg.MsgType][]string{}
+	for _, item := range m.Items {
+		descs[item.Mtype] = append(descs[item.Mtype], item.Desc)
+	}
+	return descs
+}
+
+func TestStatusRoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		msgs            *msg.Msgs
+		expectedCode    codes.Code
+		expectedMessage string
+		expectedDetails int
+	}{
+		"101": {(&msg.Msgs{}).AddError(errors.New("e1")), codes.Internal, "e1", 0},
+		"102": {(&msg.Msgs{}).AddError(errors.New("e1")).AddError(errors.New("e2")).AddWarn("w1"), codes.Internal, "e1", 2},
+		"103": {(&msg.Msgs{}).AddWarn("w1").AddInfo("i1").AddSkip("s1"), codes.OK, "", 3},
+		"104": {(&msg.Msgs{}).AddInfo("i1").AddError(errors.New("e1")).AddSkip("s1"), codes.Internal, "e1", 2},
+		"105": {&msg.Msgs{}, codes.OK, "", 0},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := Status(mock.msgs.AllMsgs())
+			if s.Code() != mock.expectedCode {
+				t.Fatalf("Test '%s' failed: expected code '%s': actual code '%s'", name, mock.expectedCode, s.Code())
+			}
+			if s.Message() != mock.expectedMessage {
+				t.Fatalf("Test '%s' failed: expected message '%s': actual message '%s'", name, mock.expectedMessage, s.Message())
+			}
+			var violations int
+			for _, d := range s.Details() {
+				if br, ok := d.(*errdetails.BadRequest); ok {
+					violations += len(br.GetFieldViolations())
+				}
+			}
+			if violations != mock.expectedDetails {
+				t.Fatalf("Test '%s' failed: expected %d detail entries: actual %d", name, mock.expectedDetails, violations)
+			}
+
+			// round trip through the wire representation
+			r := FromStatus(status.FromProto(s.Proto()))
+			expected, actual := mockDescs(*mock.msgs.AllMsgs().ToMsgs()), mockDescs(*r)
+			if len(expected) != len(actual) {
+				t.Fatalf("Test '%s' failed: expected %v: actual %v", name, expected, actual)
+			}
+			for mtype, descs := range expected {
+				if len(descs) != len(actual[mtype]) {
+					t.Fatalf("Test '%s' failed: expected %s %v: actual %v", name, mtype, descs, actual[mtype])
+				}
+				for i := range descs {
+					if descs[i] != actual[mtype][i] {
+						t.Fatalf("Test '%s' failed: expected %s %v: actual %v", name, mtype, descs, actual[mtype])
+					}
+				}
+			}
+			for _, item := range r.Errors().Items {
+				if item.Err == nil || item.Err.Error() != item.Desc {
+					t.Fatalf("Test '%s' failed: expected reconstructed error '%s': actual '%v'", name, item.Desc, item.Err)
+				}
+			}
+		})
+	}
+}
+
+func TestStatusWithCode(t *testing.T) {
+	warnsAsFailed := func(a msg.AllMsgs) codes.Code {
+		if a.HasWarn() {
+			return codes.FailedPrecondition
+		}
+		return DefaultCode(a)
+	}
+	s := StatusWithCode((&msg.Msgs{}).AddWarn("w1").AllMsgs(), warnsAsFailed)
+	if s.Code() != codes.FailedPrecondition {
+		t.Fatalf("Test failed: expected code '%s': actual code '%s'", codes.FailedPrecondition, s.Code())
+	}
+	if s.Err() == nil {
+		t.Fatalf("Test failed: expected non nil error for code '%s'", s.Code())
+	}
+	if r := FromStatus(s); len(r.Warns().Items) != 1 {
+		t.Fatalf("Test failed: expected 1 warn after round trip: actual %d", len(r.Warns().Items))
+	}
+}
+
+func TestFromStatusNil(t *testing.T) {
+	if m := FromStatus(nil); m == nil || len(m.Items) != 0 {
+		t.Fatalf("Test failed: expected empty msgs: actual %v", m)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+)
+
+// writeHash writes the type, description and code of the non nil
+// messages to the provided hash. Every field is prefixed with its
+// length so that different messages never write the same bytes.
+func writeHash(h hash.Hash, items []*msg) {
+	var n [8]byte
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		for _, f := range []string{string(item.Mtype), item.Description(), item.Code} {
+			binary.BigEndian.PutUint64(n[:], uint64(len(f)))
+			h.Write(n[:])
+			h.Write([]byte(f))
+		}
+	}
+}
+
+// Hash returns a fingerprint of the type, description and code of the
+// non nil messages. Other fields e.g. time and id do not affect the
+// hash. The hash depends on the order of the messages.
+func (m Msgs) Hash() string {
+	h := sha256.New()
+	writeHash(h, m.Items)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ChangedSince returns true if the hash of the messages is not the
+// provided one
+func (m Msgs) ChangedSince(prevHash string) bool {
+	return m.Hash() != prevHash
+}
+
+// Hash returns a fingerprint of the type, description and code of the
+// non nil messages. Buckets are hashed in a fixed order, see ToMsgs,
+// hence only the order of messages within a bucket affects the hash.
+func (a AllMsgs) Hash() string {
+	h := sha256.New()
+	for _, mtype := range a.types() {
+		writeHash(h, a[mtype].Items)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ChangedSince returns true if the hash of the messages is not the
+// provided one
+func (a AllMsgs) ChangedSince(prevHash string) bool {
+	return a.Hash() != prevHash
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMsgsHash(t *testing.T) {
+	t1, t2 := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC), time.Date(2018, 6, 2, 10, 0, 0, 0, time.UTC)
+	base := (&Msgs{}).AddInfo("i1").AddWarn("w1")
+	tests := map[string]struct {
+		other *Msgs
+		equal bool
+	}{
+		"101": {(&Msgs{}).AddInfo("i1").AddWarn("w1"), true},
+		"102": {(&Msgs{}).AddWarn("w1").AddInfo("i1"), false},
+		"103": {(&Msgs{}).AddInfo("i1").AddWarn("w1").AddSkip("s1"), false},
+		"104": {&Msgs{Items: []*msg{nil, &msg{Mtype: InfoMsg, Desc: "i1"}, nil, &msg{Mtype: WarnMsg, Desc: "w1"}}}, true},
+		"105": {&Msgs{Items: []*msg{&msg{Mtype: InfoMsg, Desc: "i1", Time: &t1, ID: "a"}, &msg{Mtype: WarnMsg, Desc: "w1", Time: &t2}}}, true},
+		"106": {(&Msgs{}).AddInfo("i1").AddWarnCode("Degraded", "w1"), false},
+		"107": {(&Msgs{}).AddInfo("i1w").AddWarn("1"), false},
+		"108": {(&Msgs{}).AddInfo("i1").AddError(errors.New("w1")), false},
+		"109": {NewMsgs(WithTimestamps(), WithIDs()).AddInfo("i1").AddWarn("w1"), true},
+	}
+
+	prev := base.Hash()
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := mock.other.Hash() == prev; actual != mock.equal {
+				t.Fatalf("Test '%s' failed: expected equal hash %t: actual %t", name, mock.equal, actual)
+			}
+			if mock.other.ChangedSince(prev) == mock.equal {
+				t.Fatalf("Test '%s' failed: expected changed %t", name, !mock.equal)
+			}
+		})
+	}
+	if (&Msgs{}).Hash() != (&Msgs{Items: []*msg{nil}}).Hash() {
+		t.Fatalf("Test failed: expected nil messages to not affect the hash")
+	}
+}
+
+func TestAllMsgsHash(t *testing.T) {
+	prev := (&Msgs{}).AddInfo("i1").AddWarn("w1").AddInfo("i2").AllMsgs().Hash()
+	tests := map[string]struct {
+		a     AllMsgs
+		equal bool
+	}{
+		"101": {(&Msgs{}).AddWarn("w1").AddInfo("i1").AddInfo("i2").AllMsgs(), true},
+		"102": {(&Msgs{}).AddWarn("w1").AddInfo("i2").AddInfo("i1").AllMsgs(), false},
+		"103": {(&Msgs{}).AddWarn("w1").AddInfo("i1").AddInfo("i2").AddSkip("s1").AllMsgs(), false},
+		"104": {NewMsgs(WithTimestamps()).AddInfo("i1").AddInfo("i2").AddWarn("w1").AllMsgs(), true},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := mock.a.Hash() == prev; actual != mock.equal {
+				t.Fatalf("Test '%s' failed: expected equal hash %t: actual %t", name, mock.equal, actual)
+			}
+			if mock.a.ChangedSince(prev) == mock.equal {
+				t.Fatalf("Test '%s' failed: expected changed %t", name, !mock.equal)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// CompactedHistoryCode is the code of the messages that summarize the
+// older messages collapsed by CompactHistory
+const CompactedHistoryCode = "CompactedHistory"
+
+// historyNames holds the plural names of the declared message types as
+// used in the summaries of CompactHistory
+var historyNames = map[MsgType]string{
+	ErrMsg:  "errors",
+	WarnMsg: "warnings",
+	InfoMsg: "infos",
+	SkipMsg: "skips",
+}
+
+// history is the summary of the older messages of a type
+type history struct {
+	mtype    MsgType
+	count    int
+	from, to *time.Time
+	seq      uint64 // order of the first summarized message
+
+	descs []string       // distinct descriptions in the order they were seen
+	freq  map[string]int // occurrences per description
+}
+
+// isHistory returns true if the provided message is a summary built by
+// CompactHistory
+func isHistory(given *msg) bool {
+	return given.Code == CompactedHistoryCode && given.Fields != nil
+}
+
+// fieldInt returns the provided field of the provided message as an
+// int. Numbers are float64 once restored from json.
+func fieldInt(given *msg, key string) int {
+	switch v := given.Fields[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+// fieldTime returns the provided field of the provided message as a
+// time or nil if it is not set
+func fieldTime(given *msg, key string) *time.Time {
+	s, _ := given.Fields[key].(string)
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// observe accounts the provided number of occurrences of the provided
+// description seen within the provided times
+func (h *history) observe(desc string, n int, from, to *time.Time) {
+	h.count += n
+	if _, found := h.freq[desc]; !found {
+		h.descs = append(h.descs, desc)
+	}
+	h.freq[desc] += n
+	if from != nil && (h.from == nil || from.Before(*h.from)) {
+		h.from = from
+	}
+	if to != nil && (h.to == nil || to.After(*h.to)) {
+		h.to = to
+	}
+}
+
+// add accounts the provided message. A summary built earlier is
+// accounted by its counts such that summaries are not summarized.
+func (h *history) add(item *msg) {
+	if !isHistory(item) {
+		to := item.LastSeen
+		if to == nil {
+			to = item.Time
+		}
+		h.observe(item.Description(), item.Occurrences(), item.Time, to)
+		return
+	}
+	top, topCount := item.Fields["top"].(string)
+	count := fieldInt(item, "count")
+	from, to := fieldTime(item, "from"), fieldTime(item, "to")
+	if topCount {
+		h.observe(top, fieldInt(item, "topCount"), from, to)
+		count -= fieldInt(item, "topCount")
+	}
+	// the remaining occurrences can not be attributed to a description
+	if count > 0 {
+		h.observe("", count, from, to)
+	}
+}
+
+// top returns the most frequent description and its occurrences. The
+// earliest seen description wins a tie.
+func (h *history) top() (desc string, n int) {
+	for _, d := range h.descs {
+		if len(d) != 0 && h.freq[d] > n {
+			desc, n = d, h.freq[d]
+		}
+	}
+	return
+}
+
+// msg returns the message that summarizes this history e.g. "[warn]
+// 213 older warnings between 10:02 and 11:45 (most frequent: 'target
+// not ready' x87)"
+func (h *history) msg() *msg {
+	name, found := historyNames[h.mtype]
+	if !found {
+		name = string(h.mtype) + " messages"
+	}
+	desc := fmt.Sprintf("[%s] %d older %s", h.mtype, h.count, name)
+	fields := map[string]interface{}{"count": h.count}
+	if h.from != nil && h.to != nil {
+		desc += fmt.Sprintf(" between %s and %s", h.from.Format("15:04"), h.to.Format("15:04"))
+		fields["from"], fields["to"] = h.from.Format(time.RFC3339Nano), h.to.Format(time.RFC3339Nano)
+	}
+	if top, n := h.top(); n != 0 {
+		desc += fmt.Sprintf(" (most frequent: '%s' x%d)", top, n)
+		fields["top"], fields["topCount"] = top, n
+	}
+	item := &msg{Mtype: h.mtype, Desc: desc, Code: CompactedHistoryCode, Fields: fields, Time: h.from, seq: h.seq}
+	if h.from != nil && h.to != nil && !h.to.Equal(*h.from) {
+		item.LastSeen = h.to
+	}
+	if h.mtype == ErrMsg {
+		item.Err = errors.New(desc)
+	}
+	return item
+}
+
+// CompactHistory keeps the newest keepRecent messages as is and
+// collapses the older ones into a summary per message type holding
+// their count, the time range they were added within if they were
+// stamped and their most frequent description. Summaries precede the
+// kept messages in the order their types were first seen. Summaries
+// built earlier are collapsed along with the older messages such that
+// compacting repeatedly results in one summary per type. Messages are
+// not changed if there are at most keepRecent of them.
+func (m *Msgs) CompactHistory(keepRecent int) (u *Msgs) {
+	m.mustNotBeNil("CompactHistory")
+	if keepRecent < 0 {
+		keepRecent = 0
+	}
+	all := m.Filter(func(*msg) bool { return true })
+	if len(all.Items) <= keepRecent {
+		return m
+	}
+	older, recent := all.Items[:len(all.Items)-keepRecent], all.Items[len(all.Items)-keepRecent:]
+	var order []*history
+	histories := map[MsgType]*history{}
+	for _, item := range older {
+		h, found := histories[item.Mtype]
+		if !found {
+			h = &history{mtype: item.Mtype, seq: item.seq, freq: map[string]int{}}
+			histories[item.Mtype] = h
+			order = append(order, h)
+		}
+		h.add(item)
+	}
+	items := make([]*msg, 0, len(order)+len(recent))
+	for _, h := range order {
+		items = append(items, h.msg())
+	}
+	m.Items = append(items, recent...)
+	m.removed()
+	m.touch()
+	return m
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// mockHistory returns timestamped messages where every message is
+// added a minute after the previous one starting at 10:01
+func mockHistory() *Msgs {
+	m := NewMsgs(WithTimestamps(), WithClock(mockClock()))
+	m.AddWarn("target not ready").AddInfo("i1").AddWarn("pool degraded").AddWarn("target not ready").
+		AddError(errors.New("e1")).AddWarn("target not ready").AddInfo("i2").AddWarn("w3")
+	return m
+}
+
+func TestMsgsCompactHistory(t *testing.T) {
+	tests := map[string]struct {
+		m          *Msgs
+		keepRecent int
+		expected   string
+	}{
+		"101": {mockHistory(), 2, strings.Join([]string{
+			"[warn] 4 older warnings between 10:01 and 10:06 (most frequent: 'target not ready' x3)",
+			"[info] 1 older infos between 10:02 and 10:02 (most frequent: 'i1' x1)",
+			"[error] 1 older errors between 10:05 and 10:05 (most frequent: 'e1' x1)",
+			"i2", "w3"}, ",")},
+		"102": {mockHistory(), 8, "target not ready,i1,pool degraded,target not ready,e1,target not ready,i2,w3"},
+		"103": {mockHistory(), 20, "target not ready,i1,pool degraded,target not ready,e1,target not ready,i2,w3"},
+		"104": {(&Msgs{}).AddWarn("w1").AddWarn("w2").AddWarn("w2").AddInfo("i1"), 1,
+			"[warn] 3 older warnings (most frequent: 'w2' x2),i1"},
+		"105": {(&Msgs{}).AddWarn("w1").AddWarn("w2").AddInfo("i1"), 0,
+			"[warn] 2 older warnings (most frequent: 'w1' x1),[info] 1 older infos (most frequent: 'i1' x1)"},
+		"106": {&Msgs{Items: []*msg{nil, {Mtype: "audit", Desc: "a1"}, nil, {Mtype: InfoMsg, Desc: "i1"}}}, 1,
+			"[audit] 1 older audit messages (most frequent: 'a1' x1),i1"},
+		"107": {&Msgs{}, 0, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock.m.CompactHistory(mock.keepRecent)
+			if actual := strings.Join(mock.m.Descriptions(), ","); actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, actual)
+			}
+			again := mock.m.Clone()
+			again.CompactHistory(mock.keepRecent).CompactHistory(mock.keepRecent)
+			if !again.EqualStrict(*mock.m) {
+				t.Fatalf("Test '%s' failed: expected compaction to be idempotent: actual '%s'", name, strings.Join(again.Descriptions(), ","))
+			}
+		})
+	}
+}
+
+func TestMsgsCompactHistoryRepeated(t *testing.T) {
+	m := mockHistory()
+	m.CompactHistory(2)
+	clock := mockClock()
+	for i := 0; i < 8; i++ {
+		clock()
+	}
+	m.now = clock
+	m.AddWarn("pool degraded").AddWarn("pool degraded").AddWarn("pool degraded").AddWarn("pool degraded").AddInfo("i3")
+	for name, roundTrip := range mockRoundTrips() {
+		actual, err := roundTrip(m)
+		if err != nil {
+			t.Fatalf("Test '%s' failed: expected no error: actual '%v'", name, err)
+		}
+		actual.CompactHistory(1)
+		expected := strings.Join([]string{
+			"[warn] 9 older warnings between 10:01 and 10:12 (most frequent: 'pool degraded' x4)",
+			"[info] 2 older infos between 10:02 and 10:07 (most frequent: 'i1' x1)",
+			"[error] 1 older errors between 10:05 and 10:05 (most frequent: 'e1' x1)",
+			"i3"}, ",")
+		if d := strings.Join(actual.Descriptions(), ","); d != expected {
+			t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, expected, d)
+		}
+		if !IsErr(actual.Items[2]) || actual.Items[2].Err == nil || actual.Items[0].Code != CompactedHistoryCode {
+			t.Fatalf("Test '%s' failed: expected summaries of their types: actual '%v'", name, actual.Items)
+		}
+	}
+}
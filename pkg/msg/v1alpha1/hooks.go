@@ -0,0 +1,66 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+)
+
+// AddHook is invoked with the type, description and error of every
+// message added to a list of messages
+type AddHook func(t MsgType, desc string, err error)
+
+// OnAdd registers the provided hook against the list of messages. Hooks
+// are invoked in the order of registration whenever a message is added
+// via AddInfo, AddWarn, AddSkip, AddError or Merge.
+func (m *Msgs) OnAdd(fn AddHook) (u *Msgs) {
+	m.mustNotBeNil("OnAdd")
+	if fn == nil {
+		return m
+	}
+	m.hooks = append(m.hooks, fn)
+	return m
+}
+
+// fire invokes the registered hooks for the provided message and passes
+// it on to the parent of a live scope
+func (m *Msgs) fire(item *msg) {
+	for _, h := range m.hooks {
+		m.call(h, item)
+	}
+	m.propagate(item)
+}
+
+// call invokes the provided hook. A panic in the hook is recovered and
+// recorded as a WarnMsg without firing the hooks again.
+func (m *Msgs) call(h AddHook, item *msg) {
+	defer func() {
+		if r := recover(); r != nil {
+			w := &msg{
+				Mtype:  WarnMsg,
+				Desc:   fmt.Sprintf("recovered from panic in add hook: %v", r),
+				Source: m.source,
+				seq:    nextSeq(),
+			}
+			if m.admit(w) {
+				m.Items = append(m.Items, w)
+				observe(WarnMsg)
+			}
+		}
+	}()
+	h(item.Mtype, item.Description(), item.Err)
+}
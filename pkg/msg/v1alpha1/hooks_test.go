@@ -0,0 +1,83 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMsgsOnAdd(t *testing.T) {
+	tests := map[string]struct {
+		add      func(m *Msgs)
+		expected []string
+	}{
+		"101": {func(m *Msgs) { m.AddInfo("i1") }, []string{"h1:info:i1", "h2:info:i1"}},
+		"102": {func(m *Msgs) { m.AddWarn("w1").AddSkip("s1") },
+			[]string{"h1:warn:w1", "h2:warn:w1", "h1:skip:s1", "h2:skip:s1"}},
+		"103": {func(m *Msgs) { m.AddError(errors.New("e1")) }, []string{"h1:error:e1", "h2:error:e1"}},
+		"104": {func(m *Msgs) { m.AddInfo("") }, nil},
+		"105": {func(m *Msgs) {
+			m.Merge((&Msgs{}).AddInfo("i1").AddWarn("w1"))
+		}, []string{"h1:info:i1", "h2:info:i1", "h1:warn:w1", "h2:warn:w1"}},
+		"106": {func(m *Msgs) {
+			m.Merge(&Msgs{Items: []*msg{nil, &msg{Mtype: SkipMsg, Desc: "s1"}}})
+		}, []string{"h1:skip:s1", "h2:skip:s1"}},
+		"107": {func(m *Msgs) { m.Reset().AddInfo("i1") }, []string{"h1:info:i1", "h2:info:i1"}},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			var fired []string
+			m := &Msgs{}
+			for _, h := range []string{"h1", "h2"} {
+				h := h
+				m.OnAdd(func(mtype MsgType, desc string, err error) {
+					fired = append(fired, fmt.Sprintf("%s:%s:%s", h, mtype, desc))
+				})
+			}
+			mock.add(m)
+			if strings.Join(fired, ",") != strings.Join(mock.expected, ",") {
+				t.Fatalf("Test '%s' failed: expected hooks '%v': actual hooks '%v'", name, mock.expected, fired)
+			}
+		})
+	}
+}
+
+func TestMsgsOnAddPanic(t *testing.T) {
+	var fired int
+	m := (&Msgs{}).
+		OnAdd(func(MsgType, string, error) { panic("bad hook") }).
+		OnAdd(func(MsgType, string, error) { fired++ })
+
+	m.AddError(errors.New("e1"))
+
+	if fired != 1 {
+		t.Fatalf("Test failed: expected hooks after a panicking hook to fire once: actual %d", fired)
+	}
+	if len(m.Items) != 2 {
+		t.Fatalf("Test failed: expected 2 messages: actual %d", len(m.Items))
+	}
+	if !IsErr(m.Items[0]) || m.Items[0].Desc != "e1" {
+		t.Fatalf("Test failed: expected original error to be stored: actual '%v'", m.Items[0])
+	}
+	if !IsWarn(m.Items[1]) || !strings.Contains(m.Items[1].Desc, "bad hook") {
+		t.Fatalf("Test failed: expected panic to be recorded as a warning: actual '%v'", m.Items[1])
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// jsonMarshal is the json serializer used to write http responses
+var jsonMarshal = json.Marshal
+
+// HTTPOptions represents the http status codes used while writing
+// messages as an http response. A status that is not set defaults to
+// the one of DefaultHTTPOptions.
+type HTTPOptions struct {
+	ErrorStatus int // used when at least one ErrMsg is present
+	WarnStatus  int // used when WarnMsg but no ErrMsg is present
+	OKStatus    int // used in all other cases
+}
+
+// DefaultHTTPOptions responds with 500 if any error is present
+// and with 200 otherwise
+var DefaultHTTPOptions = HTTPOptions{
+	ErrorStatus: http.StatusInternalServerError,
+	WarnStatus:  http.StatusOK,
+	OKStatus:    http.StatusOK,
+}
+
+// status returns the http status code for the given messages
+func (o HTTPOptions) status(m Msgs) int {
+	all := m.AllMsgs()
+	switch {
+	case all.HasError():
+		return orStatus(o.ErrorStatus, DefaultHTTPOptions.ErrorStatus)
+	case all.HasWarn():
+		return orStatus(o.WarnStatus, DefaultHTTPOptions.WarnStatus)
+	default:
+		return orStatus(o.OKStatus, DefaultHTTPOptions.OKStatus)
+	}
+}
+
+// orStatus returns the provided status if set or the provided default
+// otherwise
+func orStatus(status, def int) int {
+	if status <= 0 {
+		return def
+	}
+	return status
+}
+
+// httpMsg is the json representation of a message in an http response
+type httpMsg struct {
+	Type   MsgType `json:"type"`
+	Desc   string  `json:"desc"`
+	Err    string  `json:"err,omitempty"`
+	Code   string  `json:"code,omitempty"`
+	Source string  `json:"source,omitempty"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// httpMsgs is the json document written as an http response
+type httpMsgs struct {
+	Messages []httpMsg `json:"messages"`
+	Summary  string    `json:"summary"`
+}
+
+// WriteHTTP writes the provided messages as a json http response
+// using DefaultHTTPOptions
+func WriteHTTP(w http.ResponseWriter, m Msgs) {
+	WriteHTTPWithOptions(w, m, DefaultHTTPOptions)
+}
+
+// WriteHTTPWithOptions writes the provided messages as a json http
+// response of the form:
+//
+//	{"messages":[{"type":"warn","desc":"..."}],"summary":"..."}
+//
+// The status code is picked from the provided options based on the
+// messages. A failure to serialize the messages responds with 500 and
+// a json document holding the serialization error.
+func WriteHTTPWithOptions(w http.ResponseWriter, m Msgs, o HTTPOptions) {
+	doc := httpMsgs{Messages: []httpMsg{}, Summary: m.SummaryString()}
+	for _, msg := range m.Items {
+		if msg == nil {
+			continue
+		}
+		msg = msg.forOutput()
+		h := httpMsg{Type: msg.Mtype, Desc: msg.Description(), Code: msg.Code, Source: msg.Source, Labels: msg.Labels}
+		if msg.Err != nil {
+			h.Err = msg.Err.Error()
+		}
+		doc.Messages = append(doc.Messages, h)
+	}
+	status := o.status(m)
+	b, err := jsonMarshal(doc)
+	if err != nil {
+		status = http.StatusInternalServerError
+		failed := (&Msgs{}).AddError(fmt.Errorf("failed to serialize messages: %s", err))
+		b, _ = json.Marshal(httpMsgs{
+			Messages: []httpMsg{{Type: ErrMsg, Desc: failed.Items[0].Description()}},
+			Summary:  failed.SummaryString(),
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(b)
+}
+
+// snapshotHTTPOptions responds with 200 irrespective of the messages
+// since the messages are served for introspection
+var snapshotHTTPOptions = HTTPOptions{
+	ErrorStatus: http.StatusOK,
+	WarnStatus:  http.StatusOK,
+	OKStatus:    http.StatusOK,
+}
+
+// Handler returns an http handler that serves a snapshot of the guarded
+// messages as returned by WriteHTTP e.g. for a debug endpoint. The
+// messages can be filtered by their types via the 'type' query
+// parameter e.g. '?type=error,warn' and capped via the 'limit' query
+// parameter as per Truncate e.g. '?limit=10'. An invalid limit responds
+// with 400.
+func (s *SafeMsgs) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		m := s.Snapshot()
+		if types := queryTypes(q["type"]); len(types) != 0 {
+			m = m.Filter(func(given *msg) bool { return types[given.Mtype] })
+		}
+		if l := q.Get("limit"); len(l) != 0 {
+			n, err := strconv.Atoi(l)
+			if err != nil || n < 0 {
+				WriteHTTPWithOptions(w, *(&Msgs{}).AddError(fmt.Errorf("invalid limit '%s': must be a non negative integer", l)),
+					HTTPOptions{ErrorStatus: http.StatusBadRequest})
+				return
+			}
+			m = m.Truncate(n)
+		}
+		WriteHTTPWithOptions(w, m, snapshotHTTPOptions)
+	})
+}
+
+// queryTypes returns the message types held by the provided values of
+// the 'type' query parameter where every value may hold comma
+// separated types
+func queryTypes(values []string) (types map[MsgType]bool) {
+	for _, v := range values {
+		for _, t := range strings.Split(v, ",") {
+			if t = strings.TrimSpace(t); len(t) == 0 {
+				continue
+			}
+			if types == nil {
+				types = map[MsgType]bool{}
+			}
+			types[MsgType(t)] = true
+		}
+	}
+	return
+}
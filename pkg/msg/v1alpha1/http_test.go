@@ -0,0 +1,167 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWriteHTTPWithOptions(t *testing.T) {
+	tests := map[string]struct {
+		msgs           *Msgs
+		options        HTTPOptions
+		expectedStatus int
+		expectedBody   string
+	}{
+		"101": {(&Msgs{}).AddInfo("i1").AddError(errors.New("e1")), DefaultHTTPOptions, http.StatusInternalServerError,
+			`{"messages":[{"type":"info","desc":"i1"},{"type":"error","desc":"e1","err":"e1"}],"summary":"errors: 1, warns: 0, infos: 1, skips: 0"}`},
+		"102": {(&Msgs{}).AddWarn("w1"), DefaultHTTPOptions, http.StatusOK,
+			`{"messages":[{"type":"warn","desc":"w1"}],"summary":"errors: 0, warns: 1, infos: 0, skips: 0"}`},
+		"103": {(&Msgs{}).AddWarn("w1"), HTTPOptions{ErrorStatus: 500, WarnStatus: http.StatusMultiStatus, OKStatus: 200}, http.StatusMultiStatus,
+			`{"messages":[{"type":"warn","desc":"w1"}],"summary":"errors: 0, warns: 1, infos: 0, skips: 0"}`},
+		"104": {(&Msgs{}).AddInfo("i1").AddSkip("s1"), DefaultHTTPOptions, http.StatusOK,
+			`{"messages":[{"type":"info","desc":"i1"},{"type":"skip","desc":"s1"}],"summary":"errors: 0, warns: 0, infos: 1, skips: 1"}`},
+		"105": {&Msgs{}, DefaultHTTPOptions, http.StatusOK,
+			`{"messages":[],"summary":"errors: 0, warns: 0, infos: 0, skips: 0"}`},
+		"106": {&Msgs{Items: []*msg{nil}}, DefaultHTTPOptions, http.StatusOK,
+			`{"messages":[],"summary":"errors: 0, warns: 0, infos: 0, skips: 0"}`},
+		"107": {(&Msgs{}).AddInfo("i1"), HTTPOptions{WarnStatus: http.StatusMultiStatus}, http.StatusOK,
+			`{"messages":[{"type":"info","desc":"i1"}],"summary":"errors: 0, warns: 0, infos: 1, skips: 0"}`},
+		"108": {(&Msgs{}).AddError(errors.New("e1")), HTTPOptions{WarnStatus: http.StatusMultiStatus}, http.StatusInternalServerError,
+			`{"messages":[{"type":"error","desc":"e1","err":"e1"}],"summary":"errors: 1, warns: 0, infos: 0, skips: 0"}`},
+		"109": {(&Msgs{}).AddWarn("w1"), HTTPOptions{}, http.StatusOK,
+			`{"messages":[{"type":"warn","desc":"w1"}],"summary":"errors: 0, warns: 1, infos: 0, skips: 0"}`},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			WriteHTTPWithOptions(w, *mock.msgs, mock.options)
+			if w.Code != mock.expectedStatus {
+				t.Fatalf("Test '%s' failed: expected status %d: actual status %d", name, mock.expectedStatus, w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Fatalf("Test '%s' failed: expected content type 'application/json': actual '%s'", name, ct)
+			}
+			if w.Body.String() != mock.expectedBody {
+				t.Fatalf("Test '%s' failed: expected body '%s': actual body '%s'", name, mock.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestWriteHTTPSerializationFailure(t *testing.T) {
+	jsonMarshal = func(interface{}) ([]byte, error) {
+		return nil, errors.New("boom")
+	}
+	defer func() { jsonMarshal = json.Marshal }()
+
+	w := httptest.NewRecorder()
+	WriteHTTP(w, *(&Msgs{}).AddInfo("i1"))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("Test failed: expected status 500: actual status %d", w.Code)
+	}
+	var doc httpMsgs
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Test failed: expected valid json body: actual error '%s': body '%s'", err, w.Body.String())
+	}
+	if len(doc.Messages) != 1 || doc.Messages[0].Type != ErrMsg || doc.Messages[0].Desc != "failed to serialize messages: boom" {
+		t.Fatalf("Test failed: expected serialization error message: actual %+v", doc.Messages)
+	}
+}
+
+func TestSafeMsgsHandler(t *testing.T) {
+	s := NewSafeMsgs().AddInfo("i1").AddError(errors.New("e1")).AddWarn("w1").AddInfo("i2")
+
+	tests := map[string]struct {
+		query          string
+		expectedStatus int
+		expectedBody   string
+	}{
+		"101": {"", http.StatusOK,
+			`{"messages":[{"type":"info","desc":"i1"},{"type":"error","desc":"e1","err":"e1"},{"type":"warn","desc":"w1"},{"type":"info","desc":"i2"}],"summary":"errors: 1, warns: 1, infos: 2, skips: 0"}`},
+		"102": {"?type=error", http.StatusOK,
+			`{"messages":[{"type":"error","desc":"e1","err":"e1"}],"summary":"errors: 1, warns: 0, infos: 0, skips: 0"}`},
+		"103": {"?type=info,warn", http.StatusOK,
+			`{"messages":[{"type":"info","desc":"i1"},{"type":"warn","desc":"w1"},{"type":"info","desc":"i2"}],"summary":"errors: 0, warns: 1, infos: 2, skips: 0"}`},
+		"104": {"?type=info&type=error", http.StatusOK,
+			`{"messages":[{"type":"info","desc":"i1"},{"type":"error","desc":"e1","err":"e1"},{"type":"info","desc":"i2"}],"summary":"errors: 1, warns: 0, infos: 2, skips: 0"}`},
+		"105": {"?limit=1", http.StatusOK,
+			`{"messages":[{"type":"error","desc":"e1","err":"e1"},{"type":"info","desc":"…and 3 more messages (1 warns, 2 infos)"}],"summary":"errors: 1, warns: 0, infos: 1, skips: 0"}`},
+		"106": {"?type=info&limit=5", http.StatusOK,
+			`{"messages":[{"type":"info","desc":"i1"},{"type":"info","desc":"i2"}],"summary":"errors: 0, warns: 0, infos: 2, skips: 0"}`},
+		"107": {"?type=skip", http.StatusOK,
+			`{"messages":[],"summary":"errors: 0, warns: 0, infos: 0, skips: 0"}`},
+		"108": {"?limit=-1", http.StatusBadRequest,
+			`{"messages":[{"type":"error","desc":"invalid limit '-1': must be a non negative integer","err":"invalid limit '-1': must be a non negative integer"}],"summary":"errors: 1, warns: 0, infos: 0, skips: 0"}`},
+		"109": {"?limit=x", http.StatusBadRequest,
+			`{"messages":[{"type":"error","desc":"invalid limit 'x': must be a non negative integer","err":"invalid limit 'x': must be a non negative integer"}],"summary":"errors: 1, warns: 0, infos: 0, skips: 0"}`},
+	}
+
+	h := s.Handler()
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/msgs"+mock.query, nil))
+			if w.Code != mock.expectedStatus {
+				t.Fatalf("Test '%s' failed: expected status %d: actual status %d", name, mock.expectedStatus, w.Code)
+			}
+			if w.Body.String() != mock.expectedBody {
+				t.Fatalf("Test '%s' failed: expected body '%s': actual body '%s'", name, mock.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestSafeMsgsHandlerConcurrentAdds(t *testing.T) {
+	s := NewSafeMsgs()
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 500; i++ {
+			s.AddInfo("i1").AddError(errors.New("e1"))
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		resp, err := http.Get(srv.URL + "?type=error&limit=10")
+		if err != nil {
+			t.Fatalf("Test failed: expected no error: actual '%v'", err)
+		}
+		var doc httpMsgs
+		err = json.NewDecoder(resp.Body).Decode(&doc)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("Test failed: expected a valid document: actual '%v'", err)
+		}
+		for _, m := range doc.Messages {
+			if m.Type == WarnMsg {
+				t.Fatalf("Test failed: expected filtered messages: actual '%v'", doc.Messages)
+			}
+		}
+	}
+	<-done
+	if all := s.Snapshot(); len(all.Items) != 1000 {
+		t.Fatalf("Test failed: expected 1000 messages: actual %d", len(all.Items))
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sync"
+)
+
+// IDGenerator abstracts generating the id of a message e.g. a UUID
+type IDGenerator func() string
+
+// SequenceIDs returns a generator of monotonically increasing ids
+// e.g. 'msg-000042'. Ids are unique per generator only.
+func SequenceIDs() IDGenerator {
+	var (
+		mu   sync.Mutex
+		last uint64
+	)
+	return func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		last++
+		return fmt.Sprintf("msg-%06d", last)
+	}
+}
+
+// WithIDs stamps an id from a new SequenceIDs generator on every added
+// message
+func WithIDs() Option {
+	return WithIDGenerator(SequenceIDs())
+}
+
+// WithIDGenerator stamps an id from the provided generator on every
+// added message
+func WithIDGenerator(gen IDGenerator) Option {
+	return func(m *Msgs) {
+		m.ids = gen
+	}
+}
+
+// IDIs returns a predicate that is true for messages with the provided
+// id
+func IDIs(id string) msgPredicate {
+	return func(given *msg) bool {
+		return given != nil && given.ID == id
+	}
+}
+
+// ByID returns the first message with the provided id. Ids are retained
+// on merge, hence messages merged from lists built with their own
+// SequenceIDs may share an id; use a generator shared by the lists or
+// one that generates UUIDs to avoid such collisions.
+func (m Msgs) ByID(id string) (*msg, bool) {
+	if len(id) == 0 {
+		return nil, false
+	}
+	for _, item := range m.Items {
+		if IDIs(id)(item) {
+			return item, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSequenceIDs(t *testing.T) {
+	m := NewMsgs(WithIDs()).AddInfo("i1").AddWarn("w1").AddError(errors.New("e1"))
+	for i, expected := range []string{"msg-000001", "msg-000002", "msg-000003"} {
+		if m.Items[i].ID != expected {
+			t.Fatalf("Test failed: expected id '%s': actual '%s'", expected, m.Items[i].ID)
+		}
+	}
+	if (&Msgs{}).AddInfo("i1").Items[0].ID != "" {
+		t.Fatalf("Test failed: expected no id without a generator")
+	}
+}
+
+func TestMsgsByID(t *testing.T) {
+	gen := SequenceIDs()
+	a := NewMsgs(WithIDGenerator(gen)).AddInfo("a1").AddWarn("a2")
+	b := NewMsgs(WithIDGenerator(gen)).AddInfo("b1")
+	c := NewMsgs(WithIDs()).AddInfo("c1")
+	a.Merge(b).Merge(c)
+	tests := map[string]struct {
+		id       string
+		found    bool
+		expected string
+	}{
+		"101": {"msg-000001", true, "a1"},
+		"102": {"msg-000003", true, "b1"},
+		"103": {"msg-000004", false, ""},
+		"104": {"", false, ""},
+		"105": {"unknown", false, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			item, found := a.ByID(mock.id)
+			if found != mock.found {
+				t.Fatalf("Test '%s' failed: expected found %t: actual %t", name, mock.found, found)
+			}
+			if found && item.Desc != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, item.Desc)
+			}
+		})
+	}
+	// lists with their own sequences collide on merge and the first
+	// message wins
+	if f := a.Filter(IDIs("msg-000001")); descs(&f) != "a1,c1" {
+		t.Fatalf("Test failed: expected colliding ids to be retained: actual '%s'", descs(&f))
+	}
+}
+
+func TestMsgsIDOutput(t *testing.T) {
+	m := NewMsgs(WithIDGenerator(func() string { return "fixed" })).AddInfo("i1")
+	b, err := json.Marshal(m)
+	if err != nil || string(b) != `{"items":[{"type":"info","desc":"i1","id":"fixed"}]}` {
+		t.Fatalf("Test failed: expected id in json: actual '%s' '%v'", string(b), err)
+	}
+	if !strings.Contains(m.String(), "id: fixed") {
+		t.Fatalf("Test failed: expected id in yaml: actual '%s'", m)
+	}
+	if f := m.Filter(IsInfo); f.Items[0].ID != "fixed" {
+		t.Fatalf("Test failed: expected id to be retained by filter")
+	}
+}
@@ -0,0 +1,53 @@
+//go:build go1.23
+// +build go1.23
+
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"iter"
+)
+
+// All returns an iterator over read only copies of the non nil
+// messages
+func (m Msgs) All() iter.Seq[MsgView] {
+	return m.Filtered(func(*msg) bool { return true })
+}
+
+// OfType returns an iterator over read only copies of the messages of
+// the provided type
+func (m Msgs) OfType(t MsgType) iter.Seq[MsgView] {
+	return m.Filtered(func(given *msg) bool { return given.Mtype == t })
+}
+
+// Filtered returns an iterator over read only copies of the messages
+// matching the provided predicate. The iterator ranges over the
+// messages that were present when it was created.
+func (m Msgs) Filtered(p msgPredicate) iter.Seq[MsgView] {
+	items := m.Items
+	return func(yield func(MsgView) bool) {
+		for _, item := range items {
+			if item == nil || !p(item) {
+				continue
+			}
+			if !yield(item.view()) {
+				return
+			}
+		}
+	}
+}
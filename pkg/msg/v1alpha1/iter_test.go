@@ -0,0 +1,85 @@
+//go:build go1.23
+// +build go1.23
+
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"iter"
+	"strings"
+	"testing"
+)
+
+func TestMsgsIterators(t *testing.T) {
+	m := (&Msgs{}).AddInfo("i1").AddError(errors.New("e1")).AddWarn("w1").AddError(errors.New("e2"))
+	m.Items = append(m.Items, nil, &msg{Mtype: InfoMsg, Desc: "i2"})
+
+	tests := map[string]struct {
+		seq      iter.Seq[MsgView]
+		limit    int
+		expected string
+	}{
+		"101": {m.All(), 0, "i1,e1,w1,e2,i2"},
+		"102": {m.OfType(ErrMsg), 0, "e1,e2"},
+		"103": {m.Filtered(IsNotErr), 0, "i1,w1,i2"},
+		"104": {m.All(), 2, "i1,e1"},
+		"105": {m.OfType(ErrMsg), 1, "e1"},
+		"106": {m.OfType("unknown"), 0, ""},
+		"107": {(&Msgs{}).All(), 0, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			var actual []string
+			for v := range mock.seq {
+				actual = append(actual, v.Desc)
+				if mock.limit != 0 && len(actual) == mock.limit {
+					break
+				}
+			}
+			if strings.Join(actual, ",") != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, strings.Join(actual, ","))
+			}
+		})
+	}
+}
+
+func TestMsgsIteratorSnapshot(t *testing.T) {
+	m := (&Msgs{}).AddInfo("i1")
+	seq := m.All()
+	m.AddInfo("i2")
+
+	var count int
+	for v := range m.All() {
+		count++
+		if v.Type != InfoMsg {
+			t.Fatalf("Test failed: expected info: actual '%s'", v.Type)
+		}
+	}
+	if count != 2 {
+		t.Fatalf("Test failed: expected messages added before iterating: actual %d", count)
+	}
+	count = 0
+	for range seq {
+		count++
+	}
+	if count != 1 {
+		t.Fatalf("Test failed: expected iterator to range over messages when created: actual %d", count)
+	}
+}
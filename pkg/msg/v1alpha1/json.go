@@ -0,0 +1,157 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+)
+
+// jsonMsg is used to marshal a message without recursing into its
+// MarshalJSON
+type jsonMsg msg
+
+// jsonWireMsg is the json representation of a message. The type is
+// held as a plain string so that messages of types unknown to this
+// package e.g. from a newer producer can still be unmarshaled. The
+// error is marshaled as its message since an error can not be
+// unmarshaled. It is held raw so that the legacy form i.e. an empty
+// object can still be unmarshaled.
+type jsonWireMsg struct {
+	Mtype string `json:"type"`
+	*jsonMsg
+	Err     json.RawMessage `json:"err,omitempty"`
+	Percent *float64        `json:"percent,omitempty"`
+	Stack   []string        `json:"stack,omitempty"`
+}
+
+// MarshalJSON is an implementation of json.Marshaler interface. It
+// renders the description of a lazy message before marshaling. The
+// percent is rendered only for a ProgressMsg. The stack trace is
+// rendered only if enabled via IncludeStackTracesInJSON.
+func (m *msg) MarshalJSON() ([]byte, error) {
+	return m.marshalJSON(stackTracesInJSON())
+}
+
+// marshalJSON marshals the message along with its stack trace if asked
+// for
+func (m *msg) marshalJSON(stack bool) ([]byte, error) {
+	m = m.forOutput()
+	j := jsonMsg(*m)
+	j.Desc = m.Description()
+	w := jsonWireMsg{jsonMsg: &j, Mtype: string(j.Mtype)}
+	if j.Err != nil {
+		e, err := json.Marshal(j.Err.Error())
+		if err != nil {
+			return nil, err
+		}
+		w.Err = e
+	}
+	if m.Mtype == ProgressMsg {
+		w.Percent = &j.Percent
+	}
+	if stack {
+		w.Stack = m.Stack
+	}
+	return json.Marshal(w)
+}
+
+// errText returns the message of the provided marshaled error. It
+// falls back to the provided description if the error is not a string
+// e.g. an empty object. It returns false if there is no error.
+func errText(raw json.RawMessage, desc string) (string, bool) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", false
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil || len(text) == 0 {
+		return desc, true
+	}
+	return text, true
+}
+
+// UnmarshalJSON is an implementation of json.Unmarshaler interface. An
+// error is restored such that it wraps the error registered against
+// its code if any. An error in the legacy form i.e. an object carries
+// no message and is restored from the description instead.
+func (m *msg) UnmarshalJSON(b []byte) error {
+	w := jsonWireMsg{jsonMsg: (*jsonMsg)(m)}
+	if err := json.Unmarshal(b, &w); err != nil {
+		return err
+	}
+	m.Mtype = MsgType(w.Mtype)
+	if text, ok := errText(w.Err, m.Desc); ok {
+		m.Err = restoreError(text, m.ErrCode)
+	}
+	if w.Percent != nil {
+		m.Percent = *w.Percent
+	}
+	m.Stack = w.Stack
+	return nil
+}
+
+// jsonAllMsgs is the json representation of AllMsgs
+type jsonAllMsgs struct {
+	Counts map[MsgType]int  `json:"counts"` // count of messages per type
+	Msgs   map[MsgType]Msgs `json:"msgs"`   // non empty buckets
+}
+
+// MarshalJSON is an implementation of json.Marshaler interface. Only
+// non empty buckets are marshaled, along with the count of messages
+// of every bucket e.g.
+//
+//	{"counts":{"warn":1},"msgs":{"warn":{"items":[...]}}}
+func (a AllMsgs) MarshalJSON() ([]byte, error) {
+	j := jsonAllMsgs{Counts: map[MsgType]int{}, Msgs: map[MsgType]Msgs{}}
+	for mtype, m := range a {
+		if len(m.Items) == 0 {
+			continue
+		}
+		j.Counts[mtype] = len(m.Items)
+		j.Msgs[mtype] = m
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON is an implementation of json.Unmarshaler interface.
+// Counts are derived from the buckets and hence ignored. Buckets of
+// types unknown to this package are retained. A document in the legacy
+// form i.e. buckets keyed by type at the top level is accepted as well.
+func (a *AllMsgs) UnmarshalJSON(b []byte) error {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+	_, hasMsgs := doc["msgs"]
+	_, hasCounts := doc["counts"]
+	buckets := map[string]Msgs{}
+	if hasMsgs || hasCounts {
+		var j struct {
+			Msgs map[string]Msgs `json:"msgs"`
+		}
+		if err := json.Unmarshal(b, &j); err != nil {
+			return err
+		}
+		buckets = j.Msgs
+	} else if err := json.Unmarshal(b, &buckets); err != nil {
+		return err
+	}
+	*a = AllMsgs{}
+	for mtype, m := range buckets {
+		(*a)[MsgType(mtype)] = m
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ghodss/yaml"
+)
+
+func TestAllMsgsMarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		a        AllMsgs
+		expected string
+	}{
+		"101": {(&Msgs{}).AddWarn("w1").AddInfo("i1").AddInfo("i2").AllMsgs(),
+			`{"counts":{"info":2,"warn":1},"msgs":{"info":{"items":[{"type":"info","desc":"i1"},{"type":"info","desc":"i2"}]},"warn":{"items":[{"type":"warn","desc":"w1"}]}}}`},
+		"102": {(&Msgs{}).AllMsgs(), `{"counts":{},"msgs":{}}`},
+		"103": {AllMsgs{"custom": Msgs{Items: []*msg{&msg{Mtype: "custom", Desc: "c1"}}}, SkipMsg: Msgs{}},
+			`{"counts":{"custom":1},"msgs":{"custom":{"items":[{"type":"custom","desc":"c1"}]}}}`},
+		"104": {nil, `{"counts":{},"msgs":{}}`},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, err := json.Marshal(mock.a)
+			if err != nil {
+				t.Fatalf("Test '%s' failed: expected no error: actual '%s'", name, err)
+			}
+			if string(b) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, string(b))
+			}
+		})
+	}
+}
+
+func TestAllMsgsUnmarshalJSON(t *testing.T) {
+	a := (&Msgs{}).AddWarnCode("PoolDegraded", "w1").AddSkip("s1").AddSkip("s2").AllMsgs()
+	a[ProgressMsg] = (&Msgs{}).AddProgress("copy", 20).Progresses()
+
+	b, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	var actual AllMsgs
+	if err := json.Unmarshal(b, &actual); err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	if len(actual) != 3 {
+		t.Fatalf("Test failed: expected 3 buckets: actual %d: '%s'", len(actual), string(b))
+	}
+	skips := actual[SkipMsg]
+	if descs(&skips) != "s1,s2" || actual[WarnMsg].Items[0].Code != "PoolDegraded" ||
+		actual[ProgressMsg].Items[0].Percent != 20 {
+		t.Fatalf("Test failed: expected messages to survive round trip: actual '%s'", string(b))
+	}
+	if err := json.Unmarshal([]byte(`{"msgs":[]}`), &actual); err == nil {
+		t.Fatalf("Test failed: expected error for invalid json")
+	}
+}
+
+func TestAllMsgsUnmarshalJSONLegacy(t *testing.T) {
+	// as marshaled by json.Marshal of RunCommandResult prior to the
+	// counted form of AllMsgs
+	doc := `{"debug":{"error":{"items":[{"type":"error","desc":"e1","err":{}}]},"info":{"items":[{"type":"info","desc":"i1"}]},"warn":{"items":[{"type":"warn","desc":"w1"}]}}}`
+	var actual struct {
+		Extras AllMsgs `json:"debug,omitempty"`
+	}
+	if err := json.Unmarshal([]byte(doc), &actual); err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	if len(actual.Extras) != 3 {
+		t.Fatalf("Test failed: expected 3 buckets: actual %d", len(actual.Extras))
+	}
+	errs, infos, warns := actual.Extras[ErrMsg], actual.Extras[InfoMsg], actual.Extras[WarnMsg]
+	if descs(&errs) != "e1" || descs(&infos) != "i1" || descs(&warns) != "w1" {
+		t.Fatalf("Test failed: expected legacy buckets to be retained: actual '%#v'", actual.Extras)
+	}
+	if err := errs.Items[0].Err; err == nil || err.Error() != "e1" {
+		t.Fatalf("Test failed: expected error to be restored from its desc: actual '%v'", err)
+	}
+}
+
+func TestMsgsUnmarshalLegacyErr(t *testing.T) {
+	tests := map[string]struct {
+		doc      string
+		expected string
+	}{
+		"101": {`{"items":[{"type":"error","desc":"e1","err":{}}]}`, "e1"},
+		"102": {`{"items":[{"type":"error","desc":"e1","err":{"reason":"eof"}}]}`, "e1"},
+		"103": {`{"items":[{"type":"error","desc":"e1","err":"eof"}]}`, "eof"},
+		"104": {`{"items":[{"type":"error","desc":"e1","err":null}]}`, ""},
+		"105": {`{"items":[{"type":"error","desc":"e1"}]}`, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			unmarshals := map[string]func([]byte, interface{}) error{
+				"json": json.Unmarshal,
+				"yaml": func(b []byte, v interface{}) error { return yaml.Unmarshal(b, v) },
+			}
+			for format, unmarshal := range unmarshals {
+				var m Msgs
+				if err := unmarshal([]byte(mock.doc), &m); err != nil {
+					t.Fatalf("Test '%s' failed: expected no %s error: actual '%s'", name, format, err)
+				}
+				if actual := errString(m.Items[0].Err); actual != mock.expected {
+					t.Fatalf("Test '%s' failed: expected %s error '%s': actual '%s'", name, format, mock.expected, actual)
+				}
+			}
+		})
+	}
+}
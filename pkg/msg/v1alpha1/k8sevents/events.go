@@ -0,0 +1,106 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package k8sevents emits messages as Kubernetes events against the
+// object that the messages are about
+package k8sevents
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// MaxMessageLen is the maximum length in bytes of an event message
+const MaxMessageLen = 1024
+
+// truncatedSuffix is appended to event messages that were truncated
+const truncatedSuffix = "..."
+
+// Options controls how messages are emitted as events
+type Options struct {
+	IgnoreSkips   bool // do not emit SkipMsg messages
+	MaxMessageLen int  // defaults to MaxMessageLen if not set
+}
+
+// DefaultOptions emits every message type
+var DefaultOptions = Options{MaxMessageLen: MaxMessageLen}
+
+// EventType returns the event type corresponding to the given
+// message type
+func EventType(mtype msg.MsgType) string {
+	switch mtype {
+	case msg.ErrMsg, msg.WarnMsg, msg.DeprecationMsg:
+		return corev1.EventTypeWarning
+	default:
+		return corev1.EventTypeNormal
+	}
+}
+
+// Reason returns the event reason corresponding to the given
+// message type e.g. 'Error' for ErrMsg
+func Reason(mtype msg.MsgType) string {
+	if len(mtype) == 0 {
+		return "Unknown"
+	}
+	return strings.ToUpper(string(mtype[:1])) + string(mtype[1:])
+}
+
+// truncate shortens the given message to at most max bytes without
+// splitting a multi-byte character
+func truncate(message string, max int) string {
+	if len(message) <= max {
+		return message
+	}
+	if max <= len(truncatedSuffix) {
+		return truncatedSuffix[:max]
+	}
+	cut := max - len(truncatedSuffix)
+	for cut > 0 && !utf8.RuneStart(message[cut]) {
+		cut--
+	}
+	return message[:cut] + truncatedSuffix
+}
+
+// EmitEvents records an event against the provided object for every
+// non nil message using DefaultOptions
+func EmitEvents(rec record.EventRecorder, obj runtime.Object, m msg.Msgs) {
+	EmitEventsWithOptions(rec, obj, m, DefaultOptions)
+}
+
+// EmitEventsWithOptions records an event against the provided object for
+// every non nil message. ErrMsg, WarnMsg and DeprecationMsg messages
+// are recorded as warning events while rest are recorded as normal
+// events.
+func EmitEventsWithOptions(rec record.EventRecorder, obj runtime.Object, m msg.Msgs, o Options) {
+	max := o.MaxMessageLen
+	if max <= 0 {
+		max = MaxMessageLen
+	}
+	for _, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		if o.IgnoreSkips && item.Mtype == msg.SkipMsg {
+			continue
+		}
+		rec.Event(obj, EventType(item.Mtype), Reason(item.Mtype), truncate(item.Description(), max))
+	}
+}
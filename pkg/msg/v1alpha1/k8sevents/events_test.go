@@ -0,0 +1,82 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sevents
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestEmitEventsWithOptions(t *testing.T) {
+	tests := map[string]struct {
+		msgs     *msg.Msgs
+		options  Options
+		expected []string
+	}{
+		"101": {(&msg.Msgs{}).AddError(errors.New("e1")), DefaultOptions, []string{"Warning Error e1"}},
+		"102": {(&msg.Msgs{}).AddWarn("w1"), DefaultOptions, []string{"Warning Warn w1"}},
+		"103": {(&msg.Msgs{}).AddInfo("i1"), DefaultOptions, []string{"Normal Info i1"}},
+		"104": {(&msg.Msgs{}).AddSkip("s1"), DefaultOptions, []string{"Normal Skip s1"}},
+		"105": {(&msg.Msgs{}).AddSkip("s1"), Options{IgnoreSkips: true}, nil},
+		"106": {(&msg.Msgs{}).AddInfo("i1").AddSkip("s1").AddWarn("w1"), Options{IgnoreSkips: true},
+			[]string{"Normal Info i1", "Warning Warn w1"}},
+		"107": {(&msg.Msgs{}).AddInfo("abcdefghij"), Options{MaxMessageLen: 8}, []string{"Normal Info abcde..."}},
+		"108": {(&msg.Msgs{}).AddInfo("héllo wörld"), Options{MaxMessageLen: 5}, []string{"Normal Info h..."}},
+		"109": {&msg.Msgs{}, DefaultOptions, nil},
+		"110": {(&msg.Msgs{}).AddDeprecation("d1"), DefaultOptions, []string{"Warning Deprecation d1"}},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := record.NewFakeRecorder(10)
+			EmitEventsWithOptions(rec, &corev1.Pod{}, *mock.msgs, mock.options)
+			close(rec.Events)
+
+			var actual []string
+			for e := range rec.Events {
+				actual = append(actual, e)
+			}
+			if len(actual) != len(mock.expected) {
+				t.Fatalf("Test '%s' failed: expected events %v: actual events %v", name, mock.expected, actual)
+			}
+			for i, e := range actual {
+				if e != mock.expected[i] {
+					t.Fatalf("Test '%s' failed: expected event '%s': actual event '%s'", name, mock.expected[i], e)
+				}
+			}
+		})
+	}
+}
+
+func TestEmitEventsTruncatesToDefaultLimit(t *testing.T) {
+	rec := record.NewFakeRecorder(1)
+	EmitEvents(rec, &corev1.Pod{}, *(&msg.Msgs{}).AddWarn(strings.Repeat("x", 2*MaxMessageLen)))
+
+	e := <-rec.Events
+	message := strings.TrimPrefix(e, "Warning Warn ")
+	if len(message) != MaxMessageLen {
+		t.Fatalf("Test failed: expected message length %d: actual length %d", MaxMessageLen, len(message))
+	}
+	if !strings.HasSuffix(message, truncatedSuffix) {
+		t.Fatalf("Test failed: expected message ending with '%s': actual '%s'", truncatedSuffix, message[len(message)-10:])
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package klogbridge logs messages via klog's structured logging calls
+// with verbosity derived from the message type. It lives in its own
+// package so that users of msg/v1alpha1 that do not log via klog do not
+// pull in the klog dependency.
+package klogbridge
+
+import (
+	"errors"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	"k8s.io/klog/v2"
+)
+
+// Options represents the klog verbosity of each message type
+type Options struct {
+	WarnLevel  klog.Level // verbosity of WarnMsg messages
+	InfoLevel  klog.Level // verbosity of InfoMsg messages
+	SkipLevel  klog.Level // verbosity of SkipMsg messages
+	OtherLevel klog.Level // verbosity of messages of any other MsgType
+}
+
+// DefaultOptions logs warnings at V(0), infos at V(2) and skips at V(4)
+var DefaultOptions = Options{
+	WarnLevel:  0,
+	InfoLevel:  2,
+	SkipLevel:  4,
+	OtherLevel: 2,
+}
+
+// level returns the verbosity for the given message type
+func (o Options) level(mtype msg.MsgType) klog.Level {
+	switch mtype {
+	case msg.WarnMsg:
+		return o.WarnLevel
+	case msg.InfoMsg:
+		return o.InfoLevel
+	case msg.SkipMsg:
+		return o.SkipLevel
+	default:
+		return o.OtherLevel
+	}
+}
+
+// Log logs non nil messages via klog using DefaultOptions
+func Log(m msg.Msgs) {
+	LogWithOptions(m, DefaultOptions)
+}
+
+// LogWithOptions logs non nil messages via klog. ErrMsg messages are
+// logged via klog.ErrorS while rest are logged via klog.InfoS at the
+// verbosity set in the provided options. The code, source and labels of
+// a message are logged as key value pairs if set.
+func LogWithOptions(m msg.Msgs, o Options) {
+	for _, item := range m.View() {
+		switch item.Type {
+		case msg.ErrMsg:
+			err := item.Err
+			if err == nil {
+				err = errors.New(item.Desc)
+			}
+			klog.ErrorS(err, item.Desc, keysAndValues(item)...)
+		case msg.WarnMsg:
+			klog.V(o.level(item.Type)).InfoS(item.Desc, keysAndValues(item, "severity", "warn")...)
+		default:
+			klog.V(o.level(item.Type)).InfoS(item.Desc, keysAndValues(item, "type", string(item.Type))...)
+		}
+	}
+}
+
+// keysAndValues returns the provided key value pairs followed by the
+// code, source and labels of the provided message if set
+func keysAndValues(item msg.MsgView, kv ...interface{}) []interface{} {
+	if item.Code != "" {
+		kv = append(kv, "code", item.Code)
+	}
+	if item.Source != "" {
+		kv = append(kv, "source", item.Source)
+	}
+	if len(item.Labels) != 0 {
+		kv = append(kv, "labels", item.Labels)
+	}
+	return kv
+}
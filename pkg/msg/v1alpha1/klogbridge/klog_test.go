@@ -0,0 +1,97 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package klogbridge
+
+import (
+	"errors"
+	"flag"
+	"strconv"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	"k8s.io/klog/v2"
+)
+
+// mockKlog routes klog output at given verbosity to the returned lines
+func mockKlog(t *testing.T, verbosity int) *[]string {
+	fs := flag.NewFlagSet("klog", flag.ContinueOnError)
+	klog.InitFlags(fs)
+	if err := fs.Set("v", strconv.Itoa(verbosity)); err != nil {
+		t.Fatalf("failed to set klog verbosity: %s", err)
+	}
+	lines := &[]string{}
+	klog.SetLogger(funcr.New(func(prefix, args string) {
+		*lines = append(*lines, args)
+	}, funcr.Options{Verbosity: 10}))
+	t.Cleanup(klog.ClearLogger)
+	return lines
+}
+
+func TestLogWithOptions(t *testing.T) {
+	tests := map[string]struct {
+		verbosity int
+		options   Options
+		msgs      *msg.Msgs
+		expected  []string
+	}{
+		"101": {0, DefaultOptions, (&msg.Msgs{}).AddWarn("w1").AddInfo("i1").AddSkip("s1"),
+			[]string{`"level"=0 "msg"="w1" "severity"="warn"`}},
+		"102": {2, DefaultOptions, (&msg.Msgs{}).AddWarn("w1").AddInfo("i1").AddSkip("s1"),
+			[]string{
+				`"level"=0 "msg"="w1" "severity"="warn"`,
+				`"level"=2 "msg"="i1" "type"="info"`,
+			}},
+		"103": {4, DefaultOptions, (&msg.Msgs{}).AddWarn("w1").AddInfo("i1").AddSkip("s1"),
+			[]string{
+				`"level"=0 "msg"="w1" "severity"="warn"`,
+				`"level"=2 "msg"="i1" "type"="info"`,
+				`"level"=4 "msg"="s1" "type"="skip"`,
+			}},
+		"104": {0, DefaultOptions, (&msg.Msgs{}).AddError(errors.New("e1")),
+			[]string{`"msg"="e1" "error"="e1"`}},
+		"105": {1, Options{WarnLevel: 1, InfoLevel: 0, SkipLevel: 3}, (&msg.Msgs{}).AddWarn("w1").AddInfo("i1").AddSkip("s1"),
+			[]string{
+				`"level"=1 "msg"="w1" "severity"="warn"`,
+				`"level"=0 "msg"="i1" "type"="info"`,
+			}},
+		"106": {10, DefaultOptions, &msg.Msgs{}, nil},
+		"107": {2, DefaultOptions, (&msg.Msgs{}).WithSource("pool").AddWarnCode("PoolDegraded", "w1").
+			WithLabels(map[string]string{"replica": "2"}).AddInfo("i1"),
+			[]string{
+				`"level"=0 "msg"="w1" "severity"="warn" "code"="PoolDegraded" "source"="pool"`,
+				`"level"=2 "msg"="i1" "type"="info" "source"="pool" "labels"={"replica"="2"}`,
+			}},
+		"108": {0, DefaultOptions, (&msg.Msgs{}).AddErrorCode("PoolDown", errors.New("e1")),
+			[]string{`"msg"="e1" "error"="e1" "code"="PoolDown"`}},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			lines := mockKlog(t, mock.verbosity)
+			LogWithOptions(*mock.msgs, mock.options)
+			if len(*lines) != len(mock.expected) {
+				t.Fatalf("Test '%s' failed: expected lines %d: actual lines %d: %v", name, len(mock.expected), len(*lines), *lines)
+			}
+			for i, line := range *lines {
+				if line != mock.expected[i] {
+					t.Fatalf("Test '%s' failed: expected line '%s': actual line '%s'", name, mock.expected[i], line)
+				}
+			}
+		})
+	}
+}
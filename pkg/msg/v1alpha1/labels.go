@@ -0,0 +1,76 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// HasLabel returns a predicate that is true for messages having the
+// provided label key
+func HasLabel(k string) msgPredicate {
+	return func(given *msg) bool {
+		if given == nil {
+			return false
+		}
+		_, found := given.Labels[k]
+		return found
+	}
+}
+
+// LabelEquals returns a predicate that is true for messages having the
+// provided label key set to the provided value
+func LabelEquals(k, v string) msgPredicate {
+	return func(given *msg) bool {
+		if given == nil {
+			return false
+		}
+		actual, found := given.Labels[k]
+		return found && actual == v
+	}
+}
+
+// mergeLabels returns a new map with the provided labels where the
+// overrides take precedence over the defaults
+func mergeLabels(defaults, overrides map[string]string) map[string]string {
+	if len(defaults) == 0 && len(overrides) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(defaults)+len(overrides))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
+
+// WithLabels sets the labels that are applied to every message
+// subsequently added. Labels set explicitly on a message take
+// precedence over these.
+func (m *Msgs) WithLabels(labels map[string]string) (u *Msgs) {
+	m.mustNotBeNil("WithLabels")
+	m.labels = mergeLabels(nil, labels)
+	return m
+}
+
+// AddInfoLabeled appends a new InfoMsg to messages and initializes
+// it with the passed description and labels
+func (m *Msgs) AddInfoLabeled(i string, labels map[string]string) (u *Msgs) {
+	m.mustNotBeNil("AddInfoLabeled")
+	if len(i) == 0 {
+		return m
+	}
+	return m.add(m.newMsg(msg{Mtype: InfoMsg, Desc: i, Labels: mergeLabels(nil, labels)}))
+}
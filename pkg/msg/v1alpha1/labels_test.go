@@ -0,0 +1,64 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMsgsLabels(t *testing.T) {
+	m := (&Msgs{}).
+		AddInfoLabeled("i1", map[string]string{"replica": "1"}).
+		WithLabels(map[string]string{"phase": "provision", "replica": "0"}).
+		AddWarn("w1").
+		AddInfoLabeled("i2", map[string]string{"replica": "2"}).
+		WithLabels(nil).
+		AddSkip("s1")
+	merged := (&Msgs{}).Merge(m)
+
+	tests := map[string]struct {
+		predicate msgPredicate
+		expected  string
+	}{
+		"101": {HasLabel("replica"), "i1,w1,i2"},
+		"102": {HasLabel("phase"), "w1,i2"},
+		"103": {LabelEquals("replica", "2"), "i2"},
+		"104": {LabelEquals("replica", "0"), "w1"},
+		"105": {LabelEquals("phase", "provision"), "w1,i2"},
+		"106": {HasLabel("unknown"), ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := merged.Filter(mock.predicate)
+			if descs(&f) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(&f))
+			}
+		})
+	}
+}
+
+func TestMsgsLabelsYaml(t *testing.T) {
+	labels := map[string]string{"replica": "2"}
+	m := (&Msgs{}).AddInfoLabeled("i1", labels)
+	labels["replica"] = "3"
+	s := m.String()
+	if !strings.Contains(s, "labels:\n    replica: \"2\"") {
+		t.Fatalf("Test failed: expected labels in yaml: actual '%s'", s)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sync"
+)
+
+// lazyDesc renders the description of a message at most once
+type lazyDesc struct {
+	once sync.Once
+	fn   func() string
+	desc string // rendered description
+	max  int    // maximum length of the rendered description if positive
+}
+
+// resolve returns the rendered description
+func (l *lazyDesc) resolve() string {
+	l.once.Do(func() {
+		l.desc, _ = truncateDesc(render(l.fn), l.max)
+	})
+	return l.desc
+}
+
+// render invokes the provided function while converting a panic into
+// a description of the failure
+func render(fn func() string) (desc string) {
+	defer func() {
+		if r := recover(); r != nil {
+			desc = fmt.Sprintf("<failed to render: %v>", r)
+		}
+	}()
+	return fn()
+}
+
+// addLazy appends a new message of the provided type whose description
+// is rendered by the provided function when first needed
+func (m *Msgs) addLazy(mtype MsgType, fn func() string) (u *Msgs) {
+	if fn == nil {
+		return m
+	}
+	return m.add(m.newMsg(msg{Mtype: mtype, lazy: &lazyDesc{fn: fn}}))
+}
+
+// AddInfoLazy appends a new InfoMsg to messages whose description is
+// rendered by the passed function only when first needed
+func (m *Msgs) AddInfoLazy(fn func() string) (u *Msgs) {
+	m.mustNotBeNil("AddInfoLazy")
+	return m.addLazy(InfoMsg, fn)
+}
+
+// AddWarnLazy appends a new WarnMsg to messages whose description is
+// rendered by the passed function only when first needed
+func (m *Msgs) AddWarnLazy(fn func() string) (u *Msgs) {
+	m.mustNotBeNil("AddWarnLazy")
+	return m.addLazy(WarnMsg, fn)
+}
+
+// AddSkipLazy appends a new SkipMsg to messages whose description is
+// rendered by the passed function only when first needed
+func (m *Msgs) AddSkipLazy(fn func() string) (u *Msgs) {
+	m.mustNotBeNil("AddSkipLazy")
+	return m.addLazy(SkipMsg, fn)
+}
+
+// Descriptions returns the descriptions of non nil messages
+func (m Msgs) Descriptions() (descs []string) {
+	for _, msg := range m.Items {
+		if msg == nil {
+			continue
+		}
+		descs = append(descs, msg.Description())
+	}
+	return
+}
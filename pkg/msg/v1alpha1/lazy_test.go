@@ -0,0 +1,88 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMsgsAddLazy(t *testing.T) {
+	tests := map[string]struct {
+		add           func(m *Msgs, fn func() string) *Msgs
+		use           func(m *Msgs)
+		expectedCalls int
+	}{
+		"101": {(*Msgs).AddInfoLazy, func(m *Msgs) { m.LogNonInfos(func(string, ...interface{}) {}) }, 0},
+		"102": {(*Msgs).AddInfoLazy, func(m *Msgs) { m.Errors(); m.Warns(); m.AllMsgs() }, 0},
+		"103": {(*Msgs).AddWarnLazy, func(m *Msgs) {
+			m.Log(func(string, ...interface{}) {})
+			m.Log(func(string, ...interface{}) {})
+		}, 1},
+		"104": {(*Msgs).AddSkipLazy, func(m *Msgs) { _ = m.String(); m.Descriptions() }, 1},
+		"105": {(*Msgs).AddWarnLazy, func(m *Msgs) { json.Marshal(m) }, 1},
+		"106": {(*Msgs).AddInfoLazy, func(m *Msgs) {}, 0},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int
+			m := mock.add(&Msgs{}, func() string {
+				calls++
+				return "rendered"
+			})
+			mock.use(m)
+			if calls != mock.expectedCalls {
+				t.Fatalf("Test '%s' failed: expected calls %d: actual calls %d", name, mock.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestMsgsAddLazyDescription(t *testing.T) {
+	tests := map[string]struct {
+		fn       func() string
+		expected []string
+	}{
+		"101": {func() string { return "rendered" }, []string{"rendered"}},
+		"102": {func() string { panic("boom") }, []string{"<failed to render: boom>"}},
+		"103": {nil, nil},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := (&Msgs{}).AddWarnLazy(mock.fn)
+			actual := m.Descriptions()
+			if strings.Join(actual, ",") != strings.Join(mock.expected, ",") {
+				t.Fatalf("Test '%s' failed: expected '%v': actual '%v'", name, mock.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMsgsAddLazyJSON(t *testing.T) {
+	m := (&Msgs{}).AddInfoLazy(func() string { return "i1" })
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	expected := `{"items":[{"type":"info","desc":"i1"}]}`
+	if string(b) != expected {
+		t.Fatalf("Test failed: expected '%s': actual '%s'", expected, string(b))
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+)
+
+// LimitPolicy represents the way messages are dropped once a list of
+// messages has reached its limit
+type LimitPolicy int
+
+const (
+	// DropOldest drops the oldest message to make room for a new one
+	DropOldest LimitPolicy = iota
+	// DropNewest drops the new messages
+	DropNewest
+	// CompactDuplicates drops a new message if a message with same type
+	// and description is already present; the oldest message is dropped
+	// otherwise. A ProgressMsg instead replaces the older one.
+	CompactDuplicates
+)
+
+// msgLimit holds the limit set against a list of messages
+type msgLimit struct {
+	max     int         // maximum number of messages; 0 means no limit
+	policy  LimitPolicy // policy to drop messages
+	dropped int         // count of dropped messages
+	marker  *msg        // warning that reports the dropped messages
+}
+
+// reset clears the count of dropped messages
+func (l *msgLimit) reset() {
+	l.dropped = 0
+	l.marker = nil
+}
+
+// markerDesc returns the description of the warning that reports the
+// dropped messages
+func (l msgLimit) markerDesc() string {
+	switch l.policy {
+	case DropNewest:
+		return fmt.Sprintf("dropped %d newer messages", l.dropped)
+	case CompactDuplicates:
+		return fmt.Sprintf("dropped %d duplicate or older messages", l.dropped)
+	default:
+		return fmt.Sprintf("dropped %d older messages", l.dropped)
+	}
+}
+
+// WithLimit caps the list of messages to the provided number of
+// messages. Once the limit is reached, messages are dropped as per the
+// provided policy and a single WarnMsg reporting the count of dropped
+// messages is maintained in addition to the n messages. A limit of zero
+// or less removes the cap.
+func (m *Msgs) WithLimit(n int, policy LimitPolicy) (u *Msgs) {
+	m.mustNotBeNil("WithLimit")
+	if n <= 0 {
+		m.limit.max = 0
+		return m
+	}
+	defer m.touch()
+	m.limit.max = n
+	m.limit.policy = policy
+	for m.count() > n {
+		if policy == DropNewest {
+			m.evict(len(m.Items) - 1)
+		} else {
+			m.evict(m.oldest())
+		}
+	}
+	return m
+}
+
+// Dropped returns the count of messages dropped due to the limit
+func (m Msgs) Dropped() int {
+	return m.limit.dropped
+}
+
+// count returns the number of messages excluding the drop marker
+func (m *Msgs) count() int {
+	if m.limit.marker != nil {
+		return len(m.Items) - 1
+	}
+	return len(m.Items)
+}
+
+// oldest returns the index of the oldest message that is not the drop
+// marker
+func (m *Msgs) oldest() int {
+	if m.limit.marker != nil && len(m.Items) > 1 && m.Items[0] == m.limit.marker {
+		return 1
+	}
+	return 0
+}
+
+// evict removes the message at the provided index and records it as
+// dropped
+func (m *Msgs) evict(i int) {
+	if m.Items[i] == m.limit.marker {
+		i--
+	}
+	m.Items = append(m.Items[:i], m.Items[i+1:]...)
+	m.removed()
+	m.drop()
+}
+
+// drop records a dropped message against the drop marker
+func (m *Msgs) drop() {
+	m.limit.dropped++
+	if m.limit.marker != nil {
+		m.limit.marker.Desc = m.limit.markerDesc()
+		return
+	}
+	m.limit.marker = &msg{Mtype: WarnMsg, Desc: m.limit.markerDesc()}
+	if m.limit.policy == DropNewest {
+		m.Items = append(m.Items, m.limit.marker)
+		return
+	}
+	m.Items = append([]*msg{m.limit.marker}, m.Items...)
+	m.removed()
+}
+
+// indexOf returns the index of a message other than the drop marker
+// having the same type and description as the provided one or -1 if
+// there is no such message
+func (m *Msgs) indexOf(item *msg) int {
+	k := keyOf(item)
+	for i, given := range m.Items {
+		if given == nil || given == m.limit.marker {
+			continue
+		}
+		if keyOf(given) == k {
+			return i
+		}
+	}
+	return -1
+}
+
+// admit makes room for the provided message as per the limit. It
+// returns false if the message should be dropped instead.
+func (m *Msgs) admit(item *msg) (ok bool) {
+	if m.limit.max <= 0 || m.count() < m.limit.max {
+		return true
+	}
+	switch m.limit.policy {
+	case DropNewest:
+		m.drop()
+		return false
+	case CompactDuplicates:
+		i := m.indexOf(item)
+		if i >= 0 && IsProgress(item) {
+			// only the latest progress is kept
+			m.evict(i)
+			return true
+		}
+		if i >= 0 {
+			m.drop()
+			return false
+		}
+	}
+	m.evict(m.oldest())
+	return true
+}
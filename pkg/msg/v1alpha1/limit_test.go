@@ -0,0 +1,111 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// descs returns the descriptions of the provided messages
+func descs(m *Msgs) string {
+	var d []string
+	for _, item := range m.Items {
+		d = append(d, item.Desc)
+	}
+	return strings.Join(d, ",")
+}
+
+func TestMsgsWithLimit(t *testing.T) {
+	tests := map[string]struct {
+		policy   LimitPolicy
+		descs    []string
+		expected string
+		dropped  int
+	}{
+		"101": {DropOldest, []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"},
+			"dropped 7 older messages,7,8,9", 7},
+		"102": {DropNewest, []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"},
+			"0,1,2,dropped 7 newer messages", 7},
+		"103": {CompactDuplicates, []string{"0", "1", "0", "1", "0", "1", "2", "0", "3", "3"},
+			"dropped 7 duplicate or older messages,0,2,3", 7},
+		"104": {CompactDuplicates, []string{"0", "0", "0", "0", "0", "0", "0", "0", "0", "0"},
+			"dropped 7 duplicate or older messages,0,0,0", 7},
+		"105": {DropOldest, []string{"0", "1", "2"}, "0,1,2", 0},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := (&Msgs{}).WithLimit(3, mock.policy)
+			for _, d := range mock.descs {
+				m.AddWarn(d)
+			}
+			if descs(m) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(m))
+			}
+			if m.Dropped() != mock.dropped {
+				t.Fatalf("Test '%s' failed: expected dropped %d: actual %d", name, mock.dropped, m.Dropped())
+			}
+		})
+	}
+}
+
+func TestMsgsWithLimitMerge(t *testing.T) {
+	s := &Msgs{}
+	for i := 0; i < 10; i++ {
+		s.AddInfo(fmt.Sprintf("%d", i))
+	}
+	m := (&Msgs{}).WithLimit(3, DropOldest).AddError(fmt.Errorf("e1"))
+	m.Merge(s)
+	if descs(m) != "dropped 8 older messages,7,8,9" {
+		t.Fatalf("Test failed: expected merge to respect limit: actual '%s'", descs(m))
+	}
+
+	m.Reset()
+	if m.Dropped() != 0 || len(m.Items) != 0 {
+		t.Fatalf("Test failed: expected reset to clear dropped count: actual %d", m.Dropped())
+	}
+	m.Merge(s)
+	if descs(m) != "dropped 7 older messages,7,8,9" {
+		t.Fatalf("Test failed: expected limit to be retained after reset: actual '%s'", descs(m))
+	}
+}
+
+func TestMsgsWithLimitExisting(t *testing.T) {
+	m := (&Msgs{}).AddInfo("0").AddInfo("1").AddInfo("2").AddInfo("3").WithLimit(2, DropNewest)
+	if descs(m) != "0,1,dropped 2 newer messages" {
+		t.Fatalf("Test failed: expected existing messages to be capped: actual '%s'", descs(m))
+	}
+	m.WithLimit(0, DropNewest).AddInfo("4")
+	if descs(m) != "0,1,dropped 2 newer messages,4" {
+		t.Fatalf("Test failed: expected limit to be removed: actual '%s'", descs(m))
+	}
+}
+
+func TestMsgsWithLimitJSON(t *testing.T) {
+	m := (&Msgs{}).WithLimit(1, DropOldest).AddWarn("w1").AddWarn("w2").AddWarn("w3")
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	expected := `{"items":[{"type":"warn","desc":"dropped 2 older messages"},{"type":"warn","desc":"w3"}]}`
+	if string(b) != expected {
+		t.Fatalf("Test failed: expected '%s': actual '%s'", expected, string(b))
+	}
+}
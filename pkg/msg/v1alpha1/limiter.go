@@ -0,0 +1,139 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sync"
+	"time"
+)
+
+// msgKey identifies messages that are duplicates of each other
+type msgKey struct {
+	mtype MsgType
+	desc  string
+	code  string
+}
+
+// keyOf returns the key of the provided message
+func keyOf(given *msg) msgKey {
+	return msgKey{mtype: given.Mtype, desc: given.Description(), code: given.Code}
+}
+
+// logSuppressed logs a summary of the count of suppressed messages
+func logSuppressed(l func(string, ...interface{}), count int) {
+	if count == 0 {
+		return
+	}
+	l("suppressed %d duplicate messages", count)
+}
+
+// LogOnce logs non nil messages such that each unique pair of type
+// and description is logged only once. Messages below the log threshold
+// are not logged, see SetLogThreshold.
+func (m Msgs) LogOnce(l func(string, ...interface{})) {
+	if l = orDefaultLogger(l); l == nil {
+		return
+	}
+	seen := map[msgKey]bool{}
+	var suppressed int
+	for _, msg := range m.Items {
+		if msg == nil || !m.atThreshold(msg) {
+			continue
+		}
+		k := keyOf(msg)
+		if seen[k] {
+			suppressed++
+			continue
+		}
+		seen[k] = true
+		l("%s", msg.String())
+	}
+	logSuppressed(l, suppressed)
+}
+
+// LogLimiter logs messages while suppressing the ones whose type and
+// description was already logged within a time window. It is safe for
+// concurrent use.
+type LogLimiter struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu   sync.Mutex
+	seen map[msgKey]time.Time // time when a message was last logged
+}
+
+// NewLogLimiter returns a new log limiter that suppresses duplicate
+// messages within the provided window
+func NewLogLimiter(window time.Duration) *LogLimiter {
+	return &LogLimiter{
+		window: window,
+		now:    time.Now,
+		seen:   map[msgKey]time.Time{},
+	}
+}
+
+// WithClock sets the function used by the limiter to get the current
+// time
+func (ll *LogLimiter) WithClock(now func() time.Time) (u *LogLimiter) {
+	if now == nil {
+		return ll
+	}
+	ll.mu.Lock()
+	defer ll.mu.Unlock()
+	ll.now = now
+	return ll
+}
+
+// allowed returns the messages that may be logged now along with the
+// count of suppressed ones
+func (ll *LogLimiter) allowed(m Msgs) (f Msgs, suppressed int) {
+	ll.mu.Lock()
+	defer ll.mu.Unlock()
+	now := ll.now()
+	// forget the messages whose window has expired
+	for k, last := range ll.seen {
+		if now.Sub(last) >= ll.window {
+			delete(ll.seen, k)
+		}
+	}
+	for _, msg := range m.Items {
+		if msg == nil || !m.atThreshold(msg) {
+			continue
+		}
+		k := keyOf(msg)
+		if _, found := ll.seen[k]; found {
+			suppressed++
+			continue
+		}
+		ll.seen[k] = now
+		f.Items = append(f.Items, msg)
+	}
+	return
+}
+
+// Log logs non nil messages that were not logged within the limiter's
+// window and ends with a summary of the suppressed ones. Messages below
+// the log threshold of the provided messages are neither logged nor
+// remembered, see SetLogThreshold.
+func (ll *LogLimiter) Log(m Msgs, l func(string, ...interface{})) {
+	if l = orDefaultLogger(l); l == nil {
+		return
+	}
+	f, suppressed := ll.allowed(m)
+	f.Log(l)
+	logSuppressed(l, suppressed)
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockLogger returns a logger that captures every logged line
+func mockLogger(lines *[]string) func(string, ...interface{}) {
+	var mu sync.Mutex
+	return func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		*lines = append(*lines, fmt.Sprintf(format, args...))
+	}
+}
+
+func TestMsgsLogOnce(t *testing.T) {
+	tests := map[string]struct {
+		msgs          *Msgs
+		expectedLines int
+		expectedLast  string
+	}{
+		"101": {(&Msgs{}).AddWarn("w1").AddWarn("w1").AddWarn("w1"), 2, "suppressed 2 duplicate messages"},
+		"102": {(&Msgs{}).AddWarn("w1").AddInfo("w1").AddSkip("w1"), 3, ""},
+		"103": {(&Msgs{}).AddWarn("w1").AddWarn("w2").AddWarn("w1"), 3, "suppressed 1 duplicate messages"},
+		"104": {&Msgs{}, 0, ""},
+		"105": {(&Msgs{}).SetLogThreshold(WarnMsg).AddInfo("i1").AddWarn("w1").AddInfo("i1"), 1, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			var lines []string
+			mock.msgs.LogOnce(mockLogger(&lines))
+			if len(lines) != mock.expectedLines {
+				t.Fatalf("Test '%s' failed: expected lines %d: actual lines %d: %v", name, mock.expectedLines, len(lines), lines)
+			}
+			if mock.expectedLast != "" && lines[len(lines)-1] != mock.expectedLast {
+				t.Fatalf("Test '%s' failed: expected last line '%s': actual '%s'", name, mock.expectedLast, lines[len(lines)-1])
+			}
+		})
+	}
+}
+
+func TestLogLimiterWindow(t *testing.T) {
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	ll := NewLogLimiter(time.Minute).WithClock(func() time.Time { return now })
+	m := (&Msgs{}).AddWarn("w1").AddWarn("w1")
+
+	tests := []struct {
+		elapsed       time.Duration
+		expectedLines []string
+	}{
+		// first occurrence is logged, in call duplicate is suppressed
+		{0, []string{m.Items[0].String(), "suppressed 1 duplicate messages"}},
+		// within the window everything is suppressed
+		{30 * time.Second, []string{"suppressed 2 duplicate messages"}},
+		// window has expired
+		{31 * time.Second, []string{m.Items[0].String(), "suppressed 1 duplicate messages"}},
+	}
+
+	for i, mock := range tests {
+		now = now.Add(mock.elapsed)
+		var lines []string
+		ll.Log(*m, mockLogger(&lines))
+		if fmt.Sprint(lines) != fmt.Sprint(mock.expectedLines) {
+			t.Fatalf("Test '%d' failed: expected lines '%v': actual lines '%v'", i, mock.expectedLines, lines)
+		}
+	}
+}
+
+func TestLogLimiterThreshold(t *testing.T) {
+	ll := NewLogLimiter(time.Minute)
+	m := (&Msgs{}).SetLogThreshold(WarnMsg).AddInfo("i1").AddWarn("w1")
+	var lines []string
+	ll.Log(*m, mockLogger(&lines))
+	if fmt.Sprint(lines) != fmt.Sprint([]string{m.Items[1].String()}) {
+		t.Fatalf("Test failed: expected only messages at the threshold: actual lines '%v'", lines)
+	}
+	lines = nil
+	infos := (&Msgs{}).AddInfo("i1")
+	ll.Log(*infos, mockLogger(&lines))
+	if fmt.Sprint(lines) != fmt.Sprint([]string{infos.Items[0].String()}) {
+		t.Fatalf("Test failed: expected messages below the threshold to not be remembered: actual lines '%v'", lines)
+	}
+}
+
+func TestLogLimiterConcurrent(t *testing.T) {
+	ll := NewLogLimiter(time.Hour)
+	m := (&Msgs{}).AddWarn("w1").AddError(fmt.Errorf("e1"))
+
+	var lines []string
+	l := mockLogger(&lines)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ll.Log(*m, l)
+		}()
+	}
+	wg.Wait()
+
+	var logged, suppressed int
+	for _, line := range lines {
+		var n int
+		if _, err := fmt.Sscanf(line, "suppressed %d duplicate messages", &n); err == nil {
+			suppressed += n
+			continue
+		}
+		logged++
+	}
+	if logged != 2 {
+		t.Fatalf("Test failed: expected 2 logged messages: actual %d", logged)
+	}
+	if suppressed != 38 {
+		t.Fatalf("Test failed: expected 38 suppressed messages: actual %d", suppressed)
+	}
+}
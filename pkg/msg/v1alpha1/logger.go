@@ -0,0 +1,151 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"log"
+	"sync"
+)
+
+var (
+	defaultLoggerMu sync.RWMutex
+	// defaultLogger is used by the Log methods when they are invoked
+	// with a nil logger
+	defaultLogger = log.Printf
+)
+
+// SetDefaultLogger sets the logger used by the Log methods when they
+// are invoked with a nil logger. It defaults to log.Printf. Setting it
+// to nil makes such invocations a no-op.
+func SetDefaultLogger(l func(string, ...interface{})) {
+	defaultLoggerMu.Lock()
+	defer defaultLoggerMu.Unlock()
+	defaultLogger = l
+}
+
+// orDefaultLogger returns the provided logger if it is not nil and the
+// default logger otherwise
+func orDefaultLogger(l func(string, ...interface{})) func(string, ...interface{}) {
+	if l != nil {
+		return l
+	}
+	defaultLoggerMu.RLock()
+	defer defaultLoggerMu.RUnlock()
+	return defaultLogger
+}
+
+// LeveledLogger is a logger having a method per level of importance
+// e.g. glog or a wrapper of a structured logger
+type LeveledLogger interface {
+	Infof(format string, args ...interface{})
+	Warningf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// leveledLog returns the method of the provided logger that logs
+// messages of the provided type. Messages are routed by the severity
+// of their type such that custom types are logged at the level of the
+// declared type they are as severe as.
+func leveledLog(l LeveledLogger, t MsgType) func(string, ...interface{}) {
+	switch s := Severity(t); {
+	case s >= Severity(ErrMsg):
+		return l.Errorf
+	case s >= Severity(WarnMsg):
+		return l.Warningf
+	default:
+		return l.Infof
+	}
+}
+
+// LogLeveled logs non nil messages that are at or above the log
+// threshold in the order they were added. Each message is logged as a
+// single line via Errorf if it is an ErrMsg, via Warningf if it is a
+// WarnMsg and via Infof otherwise. Messages are logged via the default
+// logger if the provided logger is nil, see SetDefaultLogger.
+func (m Msgs) LogLeveled(l LeveledLogger) {
+	if l == nil {
+		m.logIf(func(*msg) bool { return true }, nil)
+		return
+	}
+	for _, item := range m.Items {
+		if item == nil || !m.atThreshold(item) {
+			continue
+		}
+		leveledLog(l, item.Mtype)("%s", item.compact())
+	}
+}
+
+// LogNewOption configures LogNew and LogNewErrors
+type LogNewOption func(o *logNewOptions)
+
+// logNewOptions holds the options of LogNew and LogNewErrors
+type logNewOptions struct {
+	resolved bool // logs the messages that are no longer present
+}
+
+// LogResolved additionally logs the messages of the previous iteration
+// that are no longer present as a single line prefixed with 'resolved:'
+// e.g. 'resolved: warn: pool is degraded'
+func LogResolved() LogNewOption {
+	return func(o *logNewOptions) {
+		o.resolved = true
+	}
+}
+
+// LogNew logs only the messages that were added since the provided
+// previous messages e.g. ones of the previous iteration of a reconcile
+// loop, as Log would. Messages are matched as in Subtract i.e. by their
+// type and code if they have a code and by their type and description
+// otherwise. The added messages are returned; pass the receiver as the
+// previous messages of the next iteration such that messages that
+// persist across iterations are logged only once.
+func (m Msgs) LogNew(previous Msgs, l func(string, ...interface{}), opts ...LogNewOption) (added Msgs) {
+	return m.logNewIf(func(*msg) bool { return true }, previous, l, opts)
+}
+
+// LogNewErrors is same as LogNew but considers messages of type ErrMsg
+// only
+func (m Msgs) LogNewErrors(previous Msgs, l func(string, ...interface{}), opts ...LogNewOption) (added Msgs) {
+	return m.logNewIf(IsErr, previous, l, opts)
+}
+
+// logNewIf logs the messages matching the predicate that were added
+// since the provided previous messages and returns them
+func (m Msgs) logNewIf(p msgPredicate, previous Msgs, l func(string, ...interface{}), opts []LogNewOption) (added Msgs) {
+	var o logNewOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	added = m.Subtract(previous).Filter(p)
+	if l = orDefaultLogger(l); l == nil {
+		return
+	}
+	for _, item := range added.Items {
+		if m.atThreshold(item) {
+			l("%s", item.String())
+		}
+	}
+	if !o.resolved {
+		return
+	}
+	for _, item := range previous.Subtract(m).Filter(p).Items {
+		if m.atThreshold(item) {
+			l("resolved: %s", item.compact())
+		}
+	}
+	return
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMsgsLogNilLogger(t *testing.T) {
+	m := Msgs{}
+	m.AddInfo("i1").AddWarn("w1").AddError(errors.New("e1"))
+	tests := map[string]struct {
+		log      func()
+		expected int
+	}{
+		"101": {func() { m.Log(nil) }, 3},
+		"102": {func() { m.LogNonInfos(nil) }, 2},
+		"103": {func() { m.LogNonErrors(nil) }, 2},
+		"104": {func() { m.LogErrors(nil) }, 1},
+		"105": {func() { m.LogAtLeast(WarnMsg, nil) }, 2},
+		"106": {func() { m.LogOnce(nil) }, 3},
+		"107": {func() { NewLogLimiter(time.Minute).Log(m, nil) }, 3},
+		"108": {func() { m.LogLeveled(nil) }, 3},
+	}
+	defer SetDefaultLogger(defaultLogger)
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			var lines []string
+			SetDefaultLogger(mockLogger(&lines))
+			mock.log()
+			if len(lines) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected %d lines via the default logger: actual %d", name, mock.expected, len(lines))
+			}
+			SetDefaultLogger(nil)
+			mock.log()
+			var own []string
+			m.Log(mockLogger(&own))
+			if len(lines) != mock.expected || len(own) != 3 {
+				t.Fatalf("Test '%s' failed: expected no lines without a default logger: actual %d %d", name, len(lines), len(own))
+			}
+		})
+	}
+}
+
+func TestSetDefaultLoggerConcurrent(t *testing.T) {
+	defer SetDefaultLogger(defaultLogger)
+	m := Msgs{}
+	m.AddWarn("w1")
+	var (
+		wg    sync.WaitGroup
+		lines []string
+	)
+	l := mockLogger(&lines)
+	SetDefaultLogger(nil)
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetDefaultLogger(l)
+		}()
+		go func() {
+			defer wg.Done()
+			m.Log(nil)
+		}()
+	}
+	wg.Wait()
+}
+
+// mockLeveledLogger records the lines logged at every level as
+// 'level: line' in the order they were logged
+type mockLeveledLogger struct {
+	lines []string
+}
+
+func (l *mockLeveledLogger) log(level, format string, args ...interface{}) {
+	l.lines = append(l.lines, level+": "+fmt.Sprintf(format, args...))
+}
+
+func (l *mockLeveledLogger) Infof(format string, args ...interface{}) {
+	l.log("I", format, args...)
+}
+
+func (l *mockLeveledLogger) Warningf(format string, args ...interface{}) {
+	l.log("W", format, args...)
+}
+
+func (l *mockLeveledLogger) Errorf(format string, args ...interface{}) {
+	l.log("E", format, args...)
+}
+
+func TestMsgsLogLeveled(t *testing.T) {
+	SetSeverity("test.leveled.audit", 45)
+	SetSeverity("test.leveled.fatal", 60)
+	SetSeverity("test.leveled.trace", 5)
+
+	tests := map[string]struct {
+		m         func() *Msgs
+		threshold MsgType
+		expected  string
+	}{
+		"101": {func() *Msgs {
+			return (&Msgs{}).AddInfo("i1").AddError(errors.New("e1")).AddWarn("w1").AddSkip("s1").AddDeprecation("d1").AddInfo("i2")
+		}, "", "I: info: i1,E: error: e1,W: warn: w1,I: skip: s1,I: deprecation: d1,I: info: i2"},
+		"102": {func() *Msgs {
+			m := (&Msgs{}).AddInfo("100% done")
+			for _, mtype := range []MsgType{"test.leveled.audit", "test.leveled.fatal", "test.leveled.trace", "test.leveled.unknown"} {
+				m.Items = append(m.Items, &msg{Mtype: mtype, Desc: string(mtype[13]) + "1"})
+			}
+			return m
+		}, "", "I: info: 100% done,W: test.leveled.audit: a1,E: test.leveled.fatal: f1,I: test.leveled.trace: t1,I: test.leveled.unknown: u1"},
+		"103": {func() *Msgs {
+			return (&Msgs{}).AddInfo("i1").AddError(errors.New("e1")).AddWarn("w1")
+		}, WarnMsg, "E: error: e1,W: warn: w1"},
+		"104": {func() *Msgs {
+			m := &Msgs{}
+			m.Items = append(m.Items, nil)
+			return m.AddWarn("w1")
+		}, "", "W: warn: w1"},
+		"105": {func() *Msgs { return &Msgs{} }, "", ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			l := &mockLeveledLogger{}
+			mock.m().SetLogThreshold(mock.threshold).LogLeveled(l)
+			if actual := strings.Join(l.lines, ","); actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, actual)
+			}
+		})
+	}
+}
+
+// mockIterations returns the messages of three iterations of a
+// reconcile loop having overlapping content
+func mockIterations() []*Msgs {
+	return []*Msgs{
+		(&Msgs{}).AddWarn("pool degraded").AddInfo("volume created").
+			AddErrorCode("QuotaExceeded", errors.New("quota exceeded by 1Gi")),
+		(&Msgs{}).AddWarn("pool degraded").AddInfo("snapshot taken").
+			AddErrorCode("QuotaExceeded", errors.New("quota exceeded by 2Gi")),
+		(&Msgs{}).AddInfo("snapshot taken").AddWarn("replica lagging"),
+	}
+}
+
+func TestMsgsLogNew(t *testing.T) {
+	tests := map[string]struct {
+		log      func(m, previous Msgs, l func(string, ...interface{})) Msgs
+		expected []string
+		added    []string
+	}{
+		"101": {
+			func(m, previous Msgs, l func(string, ...interface{})) Msgs { return m.LogNew(previous, l) },
+			[]string{"pool degraded", "volume created", "quota exceeded by 1Gi", "snapshot taken", "replica lagging"},
+			[]string{"pool degraded,volume created,quota exceeded by 1Gi", "snapshot taken", "replica lagging"},
+		},
+		"102": {
+			func(m, previous Msgs, l func(string, ...interface{})) Msgs {
+				return m.LogNew(previous, l, LogResolved())
+			},
+			[]string{"pool degraded", "volume created", "quota exceeded by 1Gi", "snapshot taken",
+				"resolved: info: volume created", "replica lagging", "resolved: warn: pool degraded",
+				"resolved: error: quota exceeded by 2Gi"},
+			[]string{"pool degraded,volume created,quota exceeded by 1Gi", "snapshot taken", "replica lagging"},
+		},
+		"103": {
+			func(m, previous Msgs, l func(string, ...interface{})) Msgs {
+				return m.LogNewErrors(previous, l, LogResolved())
+			},
+			[]string{"quota exceeded by 1Gi", "resolved: error: quota exceeded by 2Gi"},
+			[]string{"quota exceeded by 1Gi", "", ""},
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			var lines []string
+			var previous Msgs
+			for i, m := range mockIterations() {
+				added := mock.log(*m, previous, mockLogger(&lines))
+				if actual := strings.Join(added.Descriptions(), ","); actual != mock.added[i] {
+					t.Fatalf("Test '%s' failed: expected added '%s' in iteration %d: actual '%s'", name, mock.added[i], i, actual)
+				}
+				previous = *m
+			}
+			if len(lines) != len(mock.expected) {
+				t.Fatalf("Test '%s' failed: expected %d lines: actual %d '%v'", name, len(mock.expected), len(lines), lines)
+			}
+			for i, expected := range mock.expected {
+				if !strings.Contains(lines[i], expected) {
+					t.Fatalf("Test '%s' failed: expected line %d to contain '%s': actual '%v'", name, i, expected, lines)
+				}
+			}
+		})
+	}
+}
+
+func TestMsgsLogNewThreshold(t *testing.T) {
+	var lines []string
+	m := (&Msgs{}).AddInfo("i1").AddWarn("w1")
+	m.SetLogThreshold(WarnMsg)
+	previous := (&Msgs{}).AddInfo("i0").AddWarn("w0")
+	added := m.LogNew(*previous, mockLogger(&lines), LogResolved())
+	if len(added.Items) != 2 || len(lines) != 2 || !strings.Contains(lines[0], "w1") || lines[1] != "resolved: warn: w0" {
+		t.Fatalf("Test failed: expected messages below threshold to not be logged: actual '%v'", lines)
+	}
+	defer SetDefaultLogger(defaultLogger)
+	SetDefaultLogger(nil)
+	if added := m.LogNew(Msgs{}, nil); len(added.Items) != 2 {
+		t.Fatalf("Test failed: expected added messages without a logger: actual '%s'", added)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+
+	"github.com/go-logr/logr"
+)
+
+// LogROptions represents the verbosity levels used while logging
+// messages via a logr.Logger
+type LogROptions struct {
+	WarnLevel  int // verbosity of WarnMsg messages
+	InfoLevel  int // verbosity of InfoMsg messages
+	SkipLevel  int // verbosity of SkipMsg messages
+	OtherLevel int // verbosity of messages of any other MsgType
+}
+
+// DefaultLogROptions logs warnings at V(0) and everything else
+// that is not an error at V(1)
+var DefaultLogROptions = LogROptions{
+	WarnLevel:  0,
+	InfoLevel:  1,
+	SkipLevel:  1,
+	OtherLevel: 1,
+}
+
+// level returns the verbosity for the given message type
+func (o LogROptions) level(mtype MsgType) int {
+	switch mtype {
+	case WarnMsg:
+		return o.WarnLevel
+	case InfoMsg:
+		return o.InfoLevel
+	case SkipMsg:
+		return o.SkipLevel
+	default:
+		return o.OtherLevel
+	}
+}
+
+// LogR logs non nil messages via the provided logr.Logger
+// using DefaultLogROptions
+func (m Msgs) LogR(l logr.Logger) {
+	m.LogRWithOptions(l, DefaultLogROptions)
+}
+
+// LogRWithOptions logs non nil messages via the provided logr.Logger.
+// ErrMsg messages are logged via the logger's error slot while rest
+// are logged as info at the verbosity set in the provided options.
+func (m Msgs) LogRWithOptions(l logr.Logger, o LogROptions) {
+	for _, msg := range m.Items {
+		if msg == nil {
+			continue
+		}
+		if msg.Mtype == ErrMsg {
+			err := msg.Err
+			if err == nil {
+				err = errors.New(msg.Description())
+			}
+			l.Error(err, msg.Description())
+			continue
+		}
+		l.V(o.level(msg.Mtype)).Info(msg.Description(), "type", msg.Mtype)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+)
+
+// mockLogR returns a logr.Logger that captures every log line
+func mockLogR(verbosity int, lines *[]string) logr.Logger {
+	return funcr.New(func(prefix, args string) {
+		*lines = append(*lines, args)
+	}, funcr.Options{Verbosity: verbosity})
+}
+
+func TestMsgsLogR(t *testing.T) {
+	tests := map[string]struct {
+		verbosity int
+		options   LogROptions
+		msgs      *Msgs
+		expected  []string
+	}{
+		"101": {0, DefaultLogROptions, (&Msgs{}).AddWarn("w1").AddInfo("i1").AddSkip("s1"),
+			[]string{`"level"=0 "msg"="w1" "type"="warn"`}},
+		"102": {1, DefaultLogROptions, (&Msgs{}).AddWarn("w1").AddInfo("i1").AddSkip("s1"),
+			[]string{
+				`"level"=0 "msg"="w1" "type"="warn"`,
+				`"level"=1 "msg"="i1" "type"="info"`,
+				`"level"=1 "msg"="s1" "type"="skip"`,
+			}},
+		"103": {0, DefaultLogROptions, (&Msgs{}).AddError(errors.New("e1")),
+			[]string{`"msg"="e1" "error"="e1"`}},
+		"104": {0, DefaultLogROptions, &Msgs{Items: []*msg{nil, &msg{Mtype: ErrMsg, Desc: "e2"}}},
+			[]string{`"msg"="e2" "error"="e2"`}},
+		"105": {2, LogROptions{WarnLevel: 2, InfoLevel: 3, SkipLevel: 0}, (&Msgs{}).AddWarn("w1").AddInfo("i1").AddSkip("s1"),
+			[]string{
+				`"level"=2 "msg"="w1" "type"="warn"`,
+				`"level"=0 "msg"="s1" "type"="skip"`,
+			}},
+		"106": {0, DefaultLogROptions, &Msgs{}, nil},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			var lines []string
+			mock.msgs.LogRWithOptions(mockLogR(mock.verbosity, &lines), mock.options)
+			if len(lines) != len(mock.expected) {
+				t.Fatalf("Test '%s' failed: expected lines %d: actual lines %d: %v", name, len(mock.expected), len(lines), lines)
+			}
+			for i, line := range lines {
+				if line != mock.expected[i] {
+					t.Fatalf("Test '%s' failed: expected line '%s': actual line '%s'", name, mock.expected[i], line)
+				}
+			}
+		})
+	}
+}
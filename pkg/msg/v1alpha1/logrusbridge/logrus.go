@@ -0,0 +1,58 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logrusbridge logs messages via a logrus.FieldLogger. It lives
+// in its own package so that users of msg/v1alpha1 that do not log via
+// logrus do not pull in the logrus dependency.
+package logrusbridge
+
+import (
+	"errors"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+// TypeKey is the logrus field that holds the message type
+const TypeKey = "msg_type"
+
+// Log logs non nil messages via the provided logrus.FieldLogger.
+//
+// ErrMsg messages are logged at error level with the message's error
+// attached, WarnMsg at warn level, SkipMsg at debug level and all others
+// at info level.
+func Log(m msg.Msgs, l logrus.FieldLogger) {
+	for _, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		e := l.WithField(TypeKey, string(item.Mtype))
+		switch item.Mtype {
+		case msg.ErrMsg:
+			err := item.Err
+			if err == nil {
+				err = errors.New(item.Description())
+			}
+			e.WithError(err).Error(item.Description())
+		case msg.WarnMsg:
+			e.Warn(item.Description())
+		case msg.SkipMsg:
+			e.Debug(item.Description())
+		default:
+			e.Info(item.Description())
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logrusbridge
+
+import (
+	"errors"
+	"testing"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestLog(t *testing.T) {
+	err1 := errors.New("e1")
+
+	tests := map[string]struct {
+		msgs          *msg.Msgs
+		expectedLevel logrus.Level
+		expectedDesc  string
+		expectedType  string
+		expectedErr   error
+	}{
+		"101": {(&msg.Msgs{}).AddError(err1), logrus.ErrorLevel, "e1", "error", err1},
+		"102": {(&msg.Msgs{}).AddWarn("w1"), logrus.WarnLevel, "w1", "warn", nil},
+		"103": {(&msg.Msgs{}).AddInfo("i1"), logrus.InfoLevel, "i1", "info", nil},
+		"104": {(&msg.Msgs{}).AddSkip("s1"), logrus.DebugLevel, "s1", "skip", nil},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			l, hook := test.NewNullLogger()
+			l.SetLevel(logrus.DebugLevel)
+
+			Log(*mock.msgs, l)
+
+			if len(hook.AllEntries()) != 1 {
+				t.Fatalf("Test '%s' failed: expected 1 entry: actual %d", name, len(hook.AllEntries()))
+			}
+			e := hook.LastEntry()
+			if e.Level != mock.expectedLevel {
+				t.Fatalf("Test '%s' failed: expected level '%s': actual level '%s'", name, mock.expectedLevel, e.Level)
+			}
+			if e.Message != mock.expectedDesc {
+				t.Fatalf("Test '%s' failed: expected message '%s': actual message '%s'", name, mock.expectedDesc, e.Message)
+			}
+			if e.Data[TypeKey] != mock.expectedType {
+				t.Fatalf("Test '%s' failed: expected type '%s': actual type '%v'", name, mock.expectedType, e.Data[TypeKey])
+			}
+			if mock.expectedErr != nil && e.Data[logrus.ErrorKey] != mock.expectedErr {
+				t.Fatalf("Test '%s' failed: expected error '%v': actual error '%v'", name, mock.expectedErr, e.Data[logrus.ErrorKey])
+			}
+			if mock.expectedErr == nil && e.Data[logrus.ErrorKey] != nil {
+				t.Fatalf("Test '%s' failed: expected no error: actual error '%v'", name, e.Data[logrus.ErrorKey])
+			}
+		})
+	}
+}
+
+func TestLogOrderAndNilItems(t *testing.T) {
+	l, hook := test.NewNullLogger()
+	l.SetLevel(logrus.DebugLevel)
+
+	m := &msg.Msgs{}
+	m.AddInfo("i1").AddError(errors.New("e1")).AddWarn("w1")
+	m.Items = append(m.Items, nil)
+	m.AddSkip("s1")
+
+	Log(*m, l)
+
+	expected := []string{"i1", "e1", "w1", "s1"}
+	entries := hook.AllEntries()
+	if len(entries) != len(expected) {
+		t.Fatalf("Test failed: expected %d entries: actual %d", len(expected), len(entries))
+	}
+	for i, e := range entries {
+		if e.Message != expected[i] {
+			t.Fatalf("Test failed: expected message '%s' at %d: actual message '%s'", expected[i], i, e.Message)
+		}
+	}
+}
+
+func TestLogErrorWithoutErr(t *testing.T) {
+	l, hook := test.NewNullLogger()
+	m := (&msg.Msgs{}).AddError(errors.New("x"))
+	m.Items[0].Err = nil
+
+	Log(*m, l)
+
+	err, ok := hook.LastEntry().Data[logrus.ErrorKey].(error)
+	if !ok || err.Error() != "x" {
+		t.Fatalf("Test failed: expected fallback error 'x': actual '%v'", hook.LastEntry().Data[logrus.ErrorKey])
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Contains returns true if a message of the provided type has exactly
+// the provided description
+func (m Msgs) Contains(t MsgType, desc string) bool {
+	for _, item := range m.Items {
+		if item != nil && item.Mtype == t && item.Description() == desc {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsDesc returns true if a message of any type has exactly the
+// provided description
+func (m Msgs) ContainsDesc(desc string) bool {
+	for _, item := range m.Items {
+		if item != nil && item.Description() == desc {
+			return true
+		}
+	}
+	return false
+}
+
+// ToMap returns the type of the messages keyed by their description
+// for repeated lookups. If messages of different types share a
+// description, the type of the last one wins.
+func (m Msgs) ToMap() map[string]MsgType {
+	types := make(map[string]MsgType, len(m.Items))
+	for _, item := range m.Items {
+		if item != nil {
+			types[item.Description()] = item.Mtype
+		}
+	}
+	return types
+}
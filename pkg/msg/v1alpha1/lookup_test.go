@@ -0,0 +1,68 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMsgsContains(t *testing.T) {
+	m := (&Msgs{}).AddWarn("pool degraded").AddInfo("pool degraded").AddError(errors.New("e1"))
+	m.Items = append(m.Items, nil)
+	tests := map[string]struct {
+		mtype            MsgType
+		desc             string
+		expected, inDesc bool
+	}{
+		"101": {WarnMsg, "pool degraded", true, true},
+		"102": {InfoMsg, "pool degraded", true, true},
+		"103": {SkipMsg, "pool degraded", false, true},
+		"104": {ErrMsg, "e1", true, true},
+		"105": {WarnMsg, "pool", false, false},
+		"106": {WarnMsg, "", false, false},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if m.Contains(mock.mtype, mock.desc) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected contains '%t': actual '%t'", name, mock.expected, !mock.expected)
+			}
+			if m.ContainsDesc(mock.desc) != mock.inDesc {
+				t.Fatalf("Test '%s' failed: expected contains desc '%t': actual '%t'", name, mock.inDesc, !mock.inDesc)
+			}
+		})
+	}
+}
+
+func TestMsgsToMap(t *testing.T) {
+	m := (&Msgs{}).AddWarn("pool degraded").AddInfo("i1").AddInfo("pool degraded").AddSkip("s1")
+	m.Items = append(m.Items, nil)
+	actual := m.ToMap()
+	expected := map[string]MsgType{"pool degraded": InfoMsg, "i1": InfoMsg, "s1": SkipMsg}
+	if len(actual) != len(expected) {
+		t.Fatalf("Test failed: expected '%v': actual '%v'", expected, actual)
+	}
+	for desc, mtype := range expected {
+		if actual[desc] != mtype {
+			t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", desc, mtype, actual[desc])
+		}
+	}
+	if len((Msgs{}).ToMap()) != 0 {
+		t.Fatalf("Test failed: expected an empty map")
+	}
+}
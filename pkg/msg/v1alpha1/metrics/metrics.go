@@ -0,0 +1,59 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics exposes prometheus counters of the messages recorded
+// via msg/v1alpha1. It lives in its own package so that msg/v1alpha1
+// does not depend on prometheus.
+package metrics
+
+import (
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MessagesTotal is the name of the counter of recorded messages
+	MessagesTotal = "maya_messages_total"
+	// TypeLabel is the label holding the message type
+	TypeLabel = "type"
+)
+
+// EnableMetrics registers a counter of recorded messages per message
+// type with the provided registerer and starts counting every message
+// that gets added. The provided labels are set on every counter.
+func EnableMetrics(reg prometheus.Registerer, labels prometheus.Labels) error {
+	counter := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        MessagesTotal,
+			Help:        "Total number of messages recorded per message type",
+			ConstLabels: labels,
+		},
+		[]string{TypeLabel},
+	)
+	err := reg.Register(counter)
+	if err != nil {
+		return err
+	}
+	msg.SetObserver(func(mtype msg.MsgType) {
+		counter.WithLabelValues(string(mtype)).Inc()
+	})
+	return nil
+}
+
+// DisableMetrics stops counting the messages that get added
+func DisableMetrics() {
+	msg.SetObserver(nil)
+}
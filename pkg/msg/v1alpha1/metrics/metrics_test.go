@@ -0,0 +1,107 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// mockCounts gathers the registry and returns the counter values
+// of recorded messages per message type
+func mockCounts(t *testing.T, reg *prometheus.Registry) map[string]float64 {
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %s", err)
+	}
+	counts := map[string]float64{}
+	for _, mf := range mfs {
+		if mf.GetName() != MessagesTotal {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			var mtype string
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "component" && l.GetValue() != "test" {
+					t.Fatalf("expected const label component=test: actual %s", l.GetValue())
+				}
+				if l.GetName() == TypeLabel {
+					mtype = l.GetValue()
+				}
+			}
+			counts[mtype] = m.GetCounter().GetValue()
+		}
+	}
+	return counts
+}
+
+func TestEnableMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if err := EnableMetrics(reg, prometheus.Labels{"component": "test"}); err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	defer DisableMetrics()
+
+	pre := (&msg.Msgs{}).AddWarn("w1").AddInfo("i1")
+	m := &msg.Msgs{}
+	m.AddError(errors.New("e1")).AddWarn("w2").AddInfo("i2").AddSkip("s1")
+	m.AddInfo("").AddError(nil)
+	m.Merge(pre)
+
+	expected := map[string]float64{"error": 1, "warn": 2, "info": 2, "skip": 1}
+	actual := mockCounts(t, reg)
+	for mtype, count := range expected {
+		if actual[mtype] != count {
+			t.Fatalf("Test failed: expected %s count %v: actual %v", mtype, count, actual[mtype])
+		}
+	}
+
+	m.ObserveAll()
+	expected = map[string]float64{"error": 2, "warn": 4, "info": 4, "skip": 2}
+	actual = mockCounts(t, reg)
+	for mtype, count := range expected {
+		if actual[mtype] != count {
+			t.Fatalf("Test failed: expected %s count %v after ObserveAll: actual %v", mtype, count, actual[mtype])
+		}
+	}
+}
+
+func TestEnableMetricsAlreadyRegistered(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if err := EnableMetrics(reg, nil); err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	defer DisableMetrics()
+	if err := EnableMetrics(reg, nil); err == nil {
+		t.Fatalf("Test failed: expected error on duplicate registration: actual nil")
+	}
+}
+
+func TestDisableMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if err := EnableMetrics(reg, nil); err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	DisableMetrics()
+	(&msg.Msgs{}).AddWarn("w1")
+	if c := mockCounts(t, reg)["warn"]; c != 0 {
+		t.Fatalf("Test failed: expected no count after disable: actual %v", c)
+	}
+}
@@ -18,19 +18,72 @@ package v1alpha1
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/ghodss/yaml"
 )
 
 // YamlString returns the provided object as a yaml formatted string
+// prefixed with a newline. A failure to format is returned as the
+// string itself; use YamlStringE to get the error instead.
 func YamlString(ctx string, o interface{}) string {
+	return YamlStringOpts(ctx, o, DefaultYamlOpts)
+}
+
+// YamlOpts represents the options used while formatting an object as
+// a yaml formatted string
+type YamlOpts struct {
+	Indent            string // prefixed to every non empty line
+	LeadingNewline    bool   // prefixes the document with a newline
+	DocumentSeparator bool   // prefixes the document with '---'
+}
+
+// DefaultYamlOpts prefixes the document with a newline as done by
+// YamlString
+var DefaultYamlOpts = YamlOpts{LeadingNewline: true}
+
+// YamlStringOpts returns the provided object as a yaml formatted string
+// as per the provided options. A failure to format is returned as the
+// string itself.
+func YamlStringOpts(ctx string, o interface{}, opts YamlOpts) string {
 	if o == nil {
 		return ""
 	}
+	y, err := YamlStringE(ctx, o)
+	if err != nil {
+		return err.Error()
+	}
+	if opts.DocumentSeparator {
+		y = "---\n" + y
+	}
+	if len(opts.Indent) != 0 {
+		lines := strings.SplitAfter(y, "\n")
+		for i, l := range lines {
+			if len(strings.TrimSpace(l)) != 0 {
+				lines[i] = opts.Indent + l
+			}
+		}
+		y = strings.Join(lines, "")
+	}
+	if opts.LeadingNewline {
+		y = "\n" + y
+	}
+	return y
+}
+
+// YamlStringE returns the provided object as a yaml formatted string.
+// Unlike YamlString, the document is not prefixed with a newline.
+func YamlStringE(ctx string, o interface{}) (string, error) {
+	if o == nil {
+		return "", nil
+	}
 	b, err := yaml.Marshal(o)
 	if err != nil {
-		return fmt.Sprintf("%s: failed to format '%s' as yaml string", err, ctx)
+		return "", fmt.Errorf("%s: failed to format '%s' as yaml string", err, ctx)
 	}
-	return fmt.Sprintf("\n%s", string(b))
+	return string(b), nil
 }
 
 // MsgType represents a message type
@@ -45,22 +98,54 @@ const (
 	WarnMsg MsgType = "warn"
 	// SkipMsg represents a message about a skipped operation
 	SkipMsg MsgType = "skip"
+	// ProgressMsg represents the progress of a long running operation
+	ProgressMsg MsgType = "progress"
+	// DeprecationMsg represents a message about a deprecated usage
+	DeprecationMsg MsgType = "deprecation"
 )
 
 type msg struct {
-	Mtype MsgType `json:"type"`          // type of this message
-	Desc  string  `json:"desc"`          // long description of this message
-	Err   error   `json:"err,omitempty"` // if this message is an error
+	Mtype    MsgType       `json:"type"`               // type of this message
+	Desc     string        `json:"desc"`               // long description of this message
+	Err      error         `json:"err,omitempty"`      // if this message is an error
+	ErrCode  string        `json:"errCode,omitempty"`  // registered code of the error if any
+	ID       string        `json:"id,omitempty"`       // identifies this message if ids are enabled
+	Reason   SkipReason    `json:"reason,omitempty"`   // reason of a skip if any
+	Priority int           `json:"priority,omitempty"` // importance of this message within its type
+	Category ErrorCategory `json:"category,omitempty"` // kind of an error if classified
+	Code     string        `json:"code,omitempty"`     // machine readable reason
+	Source   string        `json:"source,omitempty"`   // component that reported this message
+
+	Labels map[string]string      `json:"labels,omitempty"` // arbitrary tags of this message
+	Fields map[string]interface{} `json:"fields,omitempty"` // named parameters of this message e.g. of a template
+	Object *ObjectRef             `json:"object,omitempty"` // object this message is about if any
+
+	Time   *time.Time `json:"time,omitempty"`   // when this message was added if timestamps are enabled
+	Caller string     `json:"caller,omitempty"` // file:line that added this message if callers are enabled
+
+	Count    int        `json:"count,omitempty"`    // occurrences if this message was merged via MergeCounted
+	LastSeen *time.Time `json:"lastSeen,omitempty"` // when this message was last merged via MergeCounted
+
+	Percent float64  `json:"-"` // completion if this message is a progress
+	Stack   []string `json:"-"` // stack trace of an error if stack traces are enabled
+
+	FullDesc string `json:"-"` // full description if the description was truncated and retained
+
+	lazy *lazyDesc // renders the description when first needed
+	seq  uint64    // order in which this message was added
 }
 
-// String is an implementation of Stringer interface
-func (m *msg) String() string {
-	return YamlString("msg", m)
+// Description returns the description of this message
+func (m *msg) Description() string {
+	if m.lazy != nil {
+		return m.lazy.resolve()
+	}
+	return m.Desc
 }
 
-// GoString is an implementation of GoStringer interface
-func (m *msg) GoString() string {
-	return YamlString("msg", m)
+// String is an implementation of Stringer interface
+func (m *msg) String() string {
+	return YamlString("msg", (*detailedMsg)(m))
 }
 
 // msgPredicate abstracts evaluation of a message condition
@@ -108,111 +193,336 @@ func IsNotErr(given *msg) (ok bool) {
 	return !IsErr(given)
 }
 
-// Msgs represent a list of msg instance
+// Msgs represent a list of msg instance. Methods that add messages or
+// otherwise change the list panic with a message naming the method if
+// invoked on a nil instance. Run is an exception as it is meant to be
+// used in deferred calls.
+//
+// Items is exported for serialization only and should be treated as
+// read only; use View or All to inspect the messages.
 type Msgs struct {
 	Items []*msg `json:"items,omitempty"`
+
+	gen    uint64            // changes whenever the messages change
+	cache  *stringCache      // caches the rendered messages
+	hooks  []AddHook         // invoked whenever a message is added
+	limit  msgLimit          // optional cap on the number of messages
+	source string            // stamped on messages added without a source
+	labels map[string]string // default labels of added messages
+	strict bool              // records warnings as errors
+
+	timestamps bool // stamps the time on added messages
+	caller     bool // stamps the caller on added messages
+
+	ids IDGenerator      // generates the ids of added messages
+	now func() time.Time // clock used to stamp messages
+
+	stackDepth int // frames of stack traces captured on errors if positive
+
+	maxDescLen *int // overrides the default maximum length of descriptions if set
+	fullDescs  bool // retains the full descriptions of truncated messages
+
+	pruneUntimed bool // prunes the messages without a timestamp
+
+	epoch uint64 // changes whenever messages are removed, see Checkpoint
+	scope *scope // links a scoped list to its parent, see Scope
+
+	seen    *seenSet  // keys of messages for the Add*Once methods
+	sampler *sampler  // samples the added messages, see WithSampling
+	arena   *msgArena // allocates the added messages, see WithValueStorage
+
+	threshold MsgType // least severe message type that gets logged
 }
 
-// String is an implementation of Stringer interface
+// String is an implementation of Stringer interface. The rendered
+// string is cached till the messages are changed.
 func (m Msgs) String() string {
-	return YamlString("msgs", m)
+	return m.cache.get(m.gen, len(m.Items), func() string {
+		return YamlString("msgs", m.detailed())
+	})
+}
+
+// YAML returns the messages as a yaml formatted string
+func (m Msgs) YAML() (string, error) {
+	return YamlStringE("msgs", m.detailed())
+}
+
+// SummaryString returns a single line summary of the count of messages
+// per message type e.g. 'errors: 1, warns: 2, infos: 0, skips: 0'.
+// Counts of message types other than the declared ones follow in
+// sorted order, followed by the counts of sampled out messages if any,
+// see WithSampling.
+func (m Msgs) SummaryString() string {
+	counts := m.counts()
+	summary := fmt.Sprintf("errors: %d, warns: %d, infos: %d, skips: %d",
+		counts[ErrMsg], counts[WarnMsg], counts[InfoMsg], counts[SkipMsg])
+	for _, mtype := range otherTypes(counts) {
+		summary += fmt.Sprintf(", %s: %d", mtype, counts[mtype])
+	}
+	return summary + m.sampledOutSummary()
 }
 
-// GoString is an implementation of GoStringer interface
-func (m Msgs) GoString() string {
-	return YamlString("msgs", m)
+// counts returns the count of non nil messages per message type
+func (m Msgs) counts() map[MsgType]int {
+	counts := map[MsgType]int{}
+	for _, msg := range m.Items {
+		if msg == nil {
+			continue
+		}
+		counts[msg.Mtype]++
+	}
+	return counts
+}
+
+// otherTypes returns the sorted message types other than the declared
+// ones that are present in the provided counts
+func otherTypes(counts map[MsgType]int) (others []MsgType) {
+	for mtype := range counts {
+		switch mtype {
+		case ErrMsg, WarnMsg, InfoMsg, SkipMsg:
+			continue
+		}
+		others = append(others, mtype)
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i] < others[j] })
+	return
 }
 
-// Filter filters messages by predicate returning only matching ones
+// Filter filters messages by predicate returning only matching ones.
+// Matches are counted upfront so that the result is allocated once.
+// Matching messages are shared with the receiver; see FilterCopy.
 func (m Msgs) Filter(p msgPredicate) (f Msgs) {
+	var count int
 	for _, msg := range m.Items {
-		if msg == nil {
-			continue
+		if msg != nil && p(msg) {
+			count++
 		}
-		if p(msg) {
+	}
+	if count == 0 {
+		return
+	}
+	f.Items = make([]*msg, 0, count)
+	for _, msg := range m.Items {
+		if msg != nil && p(msg) {
 			f.Items = append(f.Items, msg)
 		}
 	}
 	return
 }
 
-// Log logs non nil messages
-func (m Msgs) Log(l func(string, ...interface{})) {
+// FilterInPlace retains only the messages matching the predicate while
+// preserving their order. It reuses the receiver's storage and hence
+// must be used only if the receiver owns its messages.
+func (m *Msgs) FilterInPlace(p msgPredicate) (u *Msgs) {
+	m.mustNotBeNil("FilterInPlace")
+	kept := m.Items[:0]
 	for _, msg := range m.Items {
-		if msg == nil {
-			continue
+		if msg != nil && p(msg) {
+			kept = append(kept, msg)
 		}
-		l(msg.String())
 	}
+	if len(kept) != len(m.Items) {
+		m.removed()
+	}
+	// release the references beyond the retained messages
+	for i := len(kept); i < len(m.Items); i++ {
+		m.Items[i] = nil
+	}
+	m.Items = kept
+	m.touch()
+	return m
+}
+
+// Log logs non nil messages at or above the log threshold. The Log
+// methods log via the default logger if the provided logger is nil,
+// see SetDefaultLogger.
+func (m Msgs) Log(l func(string, ...interface{})) {
+	m.logIf(func(*msg) bool { return true }, l)
 }
 
 // LogNonInfos logs all messages but ones of type InfoMsg
 func (m Msgs) LogNonInfos(l func(string, ...interface{})) {
-	m.Filter(IsNotInfo).Log(l)
+	m.logIf(IsNotInfo, l)
 }
 
 // LogNonErrors logs all messages but ones of type ErrMsg
 func (m Msgs) LogNonErrors(l func(string, ...interface{})) {
-	m.Filter(IsNotErr).Log(l)
+	m.logIf(IsNotErr, l)
 }
 
 // LogErrors logs all messages of type ErrMsg
 func (m Msgs) LogErrors(l func(string, ...interface{})) {
-	m.Filter(IsErr).Log(l)
+	m.logIf(IsErr, l)
+}
+
+// logIf logs non nil messages that match the predicate and are at or
+// above the log threshold. The default logger is used if the provided
+// one is nil, see SetDefaultLogger.
+func (m Msgs) logIf(p msgPredicate, l func(string, ...interface{})) {
+	if l = orDefaultLogger(l); l == nil {
+		return
+	}
+	for _, msg := range m.Items {
+		if msg == nil || !p(msg) || !m.atThreshold(msg) {
+			continue
+		}
+		l("%s", msg.String())
+	}
+}
+
+// add appends the provided message to the list of messages
+func (m *Msgs) add(item *msg) (u *Msgs) {
+	if !scrub(item) {
+		return m
+	}
+	return m.addScrubbed(item)
+}
+
+// addScrubbed appends the provided message that was already scrubbed,
+// see SetScrubber
+func (m *Msgs) addScrubbed(item *msg) (u *Msgs) {
+	defer m.touch()
+	item.seq = nextSeq()
+	m.limitDesc(item)
+	if m.strict {
+		escalate(item)
+	}
+	if !m.sampler.keep(item) {
+		return m
+	}
+	if len(item.Source) == 0 {
+		item.Source = m.source
+	}
+	if m.timestamps && item.Time == nil {
+		now := m.clock()
+		item.Time = &now
+	}
+	if m.caller && len(item.Caller) == 0 {
+		item.Caller = callerOutside()
+	}
+	if item.Err != nil && len(item.ErrCode) == 0 {
+		item.ErrCode = registeredCode(item.Err)
+	}
+	if item.Mtype == ErrMsg && len(item.Category) == 0 {
+		item.Category = classify(item.Err)
+	}
+	if m.stackDepth > 0 && item.Mtype == ErrMsg && item.Stack == nil {
+		item.Stack = stackOutside(m.stackDepth)
+	}
+	if m.ids != nil && len(item.ID) == 0 {
+		item.ID = m.ids()
+	}
+	if len(m.labels) != 0 {
+		item.Labels = mergeLabels(m.labels, item.Labels)
+	}
+	if !m.admit(item) {
+		return m
+	}
+	m.Items = append(m.Items, item)
+	observe(item.Mtype)
+	m.fire(item)
+	return m
 }
 
 // AddInfo appends a new InfoMsg to messages and initializes
 // it with the passed description
 func (m *Msgs) AddInfo(i string) (u *Msgs) {
+	m.mustNotBeNil("AddInfo")
 	if len(i) == 0 {
 		return m
 	}
-	m.Items = append(m.Items, &msg{Mtype: InfoMsg, Desc: i})
-	return m
+	return m.add(m.newMsg(msg{Mtype: InfoMsg, Desc: i}))
 }
 
 // AddWarn appends a new WarnMsg to messages and initializes
 // it with the passed description
 func (m *Msgs) AddWarn(w string) (u *Msgs) {
+	m.mustNotBeNil("AddWarn")
 	if len(w) == 0 {
 		return m
 	}
-	m.Items = append(m.Items, &msg{Mtype: WarnMsg, Desc: w})
-	return m
+	return m.add(m.newMsg(msg{Mtype: WarnMsg, Desc: w}))
 }
 
 // AddSkip appends a new SkipMsg to messages and initializes
 // it with the passed description
 func (m *Msgs) AddSkip(s string) (u *Msgs) {
+	m.mustNotBeNil("AddSkip")
 	if len(s) == 0 {
 		return m
 	}
-	m.Items = append(m.Items, &msg{Mtype: SkipMsg, Desc: s})
-	return m
+	return m.add(m.newMsg(msg{Mtype: SkipMsg, Desc: s}))
 }
 
 // AddError appends a new ErrMsg to messages and initializes
 // it with the passed description
 func (m *Msgs) AddError(e error) (u *Msgs) {
+	m.mustNotBeNil("AddError")
 	if e == nil {
 		return m
 	}
-	m.Items = append(m.Items, &msg{Mtype: ErrMsg, Desc: e.Error(), Err: e})
-	return m
+	return m.add(m.newMsg(msg{Mtype: ErrMsg, Desc: e.Error(), Err: e}))
+}
+
+// AddErrorWithContext appends a new ErrMsg to messages whose
+// description is prefixed with the passed context e.g. 'delete
+// snapshot: not found'. The passed error remains reachable from the
+// stored error via errors.Is and errors.As.
+func (m *Msgs) AddErrorWithContext(ctx string, e error) (u *Msgs) {
+	m.mustNotBeNil("AddErrorWithContext")
+	if e == nil {
+		return m
+	}
+	if len(ctx) == 0 {
+		return m.AddError(e)
+	}
+	return m.AddError(fmt.Errorf("%s: %w", ctx, e))
 }
 
-// Merge merges receiver messages with passed ones
+// Merge merges receiver messages with passed ones. Registered add hooks
+// are fired for each non nil message that gets merged. Merged messages
+// are shared with the passed ones; see MergeCopy. Room for the passed
+// messages is reserved at once, see Grow.
 func (m *Msgs) Merge(s *Msgs) (u *Msgs) {
+	m.mustNotBeNil("Merge")
 	if s == nil {
 		return m
 	}
-	m.Items = append(m.Items, s.Items...)
+	defer m.touch()
+	items := s.Items
+	if m.limit.max > 0 && len(items) > m.limit.max {
+		// a limited list never holds more than its limit
+		m.Grow(m.limit.max)
+	} else {
+		m.Grow(len(items))
+	}
+	if m.limit.max > 0 {
+		for _, item := range items {
+			if item == nil || !m.admit(item) {
+				continue
+			}
+			m.Items = append(m.Items, item)
+			m.fire(item)
+		}
+		return m
+	}
+	m.Items = append(m.Items, items...)
+	for _, item := range items {
+		if item != nil {
+			m.fire(item)
+		}
+	}
 	return m
 }
 
-// Reset clears the list of messages
+// Reset clears the list of messages as well as the count of messages
+// dropped due to a limit. Registered add hooks and limit are retained.
 func (m *Msgs) Reset() (u *Msgs) {
+	m.mustNotBeNil("Reset")
 	m.Items = nil
+	m.removed()
+	m.limit.reset()
+	m.touch()
 	return m
 }
 
@@ -256,12 +566,7 @@ type AllMsgs map[MsgType]Msgs
 
 // String is an implementation of Stringer interface
 func (a AllMsgs) String() string {
-	return YamlString("allmsgs", a)
-}
-
-// GoString is an implementation of GoStringer interface
-func (a AllMsgs) GoString() string {
-	return YamlString("allmsgs", a)
+	return a.yamlString()
 }
 
 // Error returns the first error that was recorded
@@ -312,54 +617,121 @@ func (a AllMsgs) HasInfo() (isinfo bool) {
 	return true
 }
 
-// IsEmpty returns true no messages having a declared MsgType are present
+// IsEmpty returns true if no bucket has any message
 func (a AllMsgs) IsEmpty() (isempty bool) {
-	warns := a[WarnMsg]
-	infos := a[InfoMsg]
-	errs := a[ErrMsg]
-	skips := a[SkipMsg]
-
-	if len(warns.Items) == 0 && len(errs.Items) == 0 && len(infos.Items) == 0 && len(skips.Items) == 0 {
-		return true
+	for _, m := range a {
+		if len(m.Items) != 0 {
+			return
+		}
 	}
-	return
+	return true
 }
 
-// ToMsgs parses AllMsgs to appropriate Msgs
+// toMsgsOrder is the order in which the buckets of declared message
+// types are parsed to Msgs
+var toMsgsOrder = []MsgType{ErrMsg, WarnMsg, DeprecationMsg, InfoMsg, SkipMsg}
+
+// ToMsgs parses AllMsgs to appropriate Msgs. Errors are followed by
+// warns, deprecations, infos and skips. Buckets of any other message
+// type follow in sorted order.
 func (a AllMsgs) ToMsgs() (m *Msgs) {
 	m = &Msgs{}
 	if len(a) == 0 {
 		return
 	}
-	// grab the errors
-	errors := a[ErrMsg].Items
-	if len(errors) != 0 {
-		m.Items = append(m.Items, errors...)
-	}
-	// grab the warns
-	warns := a[WarnMsg].Items
-	if len(warns) != 0 {
-		m.Items = append(m.Items, warns...)
+	var n int
+	for _, bucket := range a {
+		n += len(bucket.Items)
 	}
-	// grab the infos
-	infos := a[InfoMsg].Items
-	if len(infos) != 0 {
-		m.Items = append(m.Items, infos...)
+	if n == 0 {
+		return
 	}
-	// grab the skips
-	skips := a[SkipMsg].Items
-	if len(skips) != 0 {
-		m.Items = append(m.Items, skips...)
+	m.Items = make([]*msg, 0, n)
+	for _, mtype := range a.types() {
+		m.Items = append(m.Items, a[mtype].Items...)
 	}
 	return
 }
 
-// AllMsgs returns messages by MsgType key
+// types returns the message types of the buckets in the order errors,
+// warns, deprecations, infos and skips followed by other message types
+// in sorted order
+func (a AllMsgs) types() (types []MsgType) {
+	known := map[MsgType]bool{}
+	for _, mtype := range toMsgsOrder {
+		known[mtype] = true
+		if _, found := a[mtype]; found {
+			types = append(types, mtype)
+		}
+	}
+	var others []MsgType
+	for mtype := range a {
+		if !known[mtype] {
+			others = append(others, mtype)
+		}
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i] < others[j] })
+	return append(types, others...)
+}
+
+// AllMsgs returns messages by MsgType key. Buckets of the declared
+// message types other than ProgressMsg are always present while buckets
+// of any other message type are present only if they have messages.
+// Messages are counted per type in a first pass over the list so that
+// every bucket is allocated once while filling them in a second one.
 func (m Msgs) AllMsgs() (all AllMsgs) {
-	return map[MsgType]Msgs{
-		InfoMsg: m.Infos(),
-		ErrMsg:  m.Errors(),
-		WarnMsg: m.Warns(),
-		SkipMsg: m.Skips(),
+	var (
+		declared = [...]MsgType{InfoMsg, ErrMsg, WarnMsg, SkipMsg, DeprecationMsg}
+		counts   [len(declared)]int
+		others   map[MsgType]int
+	)
+	index := func(mtype MsgType) int {
+		for i, d := range declared {
+			if d == mtype {
+				return i
+			}
+		}
+		return -1
+	}
+	for _, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		if i := index(item.Mtype); i >= 0 {
+			counts[i]++
+			continue
+		}
+		if others == nil {
+			others = map[MsgType]int{}
+		}
+		others[item.Mtype]++
+	}
+	all = make(AllMsgs, len(declared)+len(others))
+	var buckets [len(declared)][]*msg
+	for i := range declared {
+		if counts[i] != 0 {
+			buckets[i] = make([]*msg, 0, counts[i])
+		}
 	}
+	rest := make(map[MsgType][]*msg, len(others))
+	for mtype, n := range others {
+		rest[mtype] = make([]*msg, 0, n)
+	}
+	for _, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		if i := index(item.Mtype); i >= 0 {
+			buckets[i] = append(buckets[i], item)
+			continue
+		}
+		rest[item.Mtype] = append(rest[item.Mtype], item)
+	}
+	for i, mtype := range declared {
+		all[mtype] = Msgs{Items: buckets[i]}
+	}
+	for mtype, items := range rest {
+		all[mtype] = Msgs{Items: items}
+	}
+	return
 }
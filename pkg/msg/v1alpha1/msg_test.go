@@ -20,7 +20,9 @@ import (
 	"errors"
 	"fmt"
 	"github.com/golang/glog"
+	"strings"
 	"testing"
+	"time"
 )
 
 func mockMsgFromType(mtype MsgType) *msg {
@@ -230,6 +232,33 @@ func TestMsgsLogNonInfos(t *testing.T) {
 	}
 }
 
+func TestMsgsLogPercentVerbs(t *testing.T) {
+	m := Msgs{}
+	m.AddWarn("capacity at 95% used").AddInfo("pool %s has %d disks").AddError(errors.New("resize failed at 100%d"))
+	tests := map[string]struct {
+		log      func(Msgs, func(string, ...interface{}))
+		expected string
+	}{
+		"101": {func(m Msgs, l func(string, ...interface{})) { m.Log(l) }, "capacity at 95% used"},
+		"102": {func(m Msgs, l func(string, ...interface{})) { m.LogNonInfos(l) }, "capacity at 95% used"},
+		"103": {func(m Msgs, l func(string, ...interface{})) { m.LogNonErrors(l) }, "pool %s has %d disks"},
+		"104": {func(m Msgs, l func(string, ...interface{})) { m.LogErrors(l) }, "resize failed at 100%d"},
+		"105": {func(m Msgs, l func(string, ...interface{})) { m.LogOnce(l) }, "capacity at 95% used"},
+		"106": {func(m Msgs, l func(string, ...interface{})) { NewLogLimiter(time.Minute).Log(m, l) }, "pool %s has %d disks"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			var lines []string
+			mock.log(m, mockLogger(&lines))
+			all := strings.Join(lines, "\n")
+			if !strings.Contains(all, mock.expected) || strings.Contains(all, "%!") {
+				t.Fatalf("Test '%s' failed: expected '%s' without mangled verbs: actual '%s'", name, mock.expected, all)
+			}
+		})
+	}
+}
+
 func TestMsgsAddInfo(t *testing.T) {
 	tests := map[string]struct {
 		messages []string
@@ -334,6 +363,43 @@ func TestMsgsAddError(t *testing.T) {
 	}
 }
 
+func TestMsgsAddErrorWithContext(t *testing.T) {
+	notFound := errors.New("not found")
+	tests := map[string]struct {
+		ctx          string
+		err          error
+		expectedDesc string
+		isEmpty      bool
+	}{
+		"101": {"delete snapshot", notFound, "delete snapshot: not found", false},
+		"102": {"", notFound, "not found", false},
+		"103": {"delete snapshot", nil, "", true},
+		"104": {"", nil, "", true},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			ml := (&Msgs{}).AddErrorWithContext(mock.ctx, mock.err)
+			if mock.isEmpty {
+				if len(ml.Items) != 0 {
+					t.Fatalf("Test '%s' failed: expected no messages: actual '%d'", name, len(ml.Items))
+				}
+				return
+			}
+			e := ml.Errors().Items[0]
+			if e.Desc != mock.expectedDesc {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expectedDesc, e.Desc)
+			}
+			if e.Err.Error() != mock.expectedDesc {
+				t.Fatalf("Test '%s' failed: expected error '%s': actual '%s'", name, mock.expectedDesc, e.Err)
+			}
+			if !errors.Is(e.Err, mock.err) {
+				t.Fatalf("Test '%s' failed: expected error to wrap '%s': actual '%s'", name, mock.err, e.Err)
+			}
+		})
+	}
+}
+
 func TestMsgsMerge(t *testing.T) {
 	err1 := fmt.Errorf("error1")
 	err2 := fmt.Errorf("error2")
@@ -432,3 +498,105 @@ func TestAllMsgsToMsgs(t *testing.T) {
 		})
 	}
 }
+
+func TestMsgsSummaryString(t *testing.T) {
+	tests := map[string]struct {
+		msgs     Msgs
+		expected string
+	}{
+		"101": {*(&Msgs{}).AddInfo("i").AddInfo("i").AddWarn("w").AddError(errors.New("e")), "errors: 1, warns: 1, infos: 2, skips: 0"},
+		"102": {Msgs{}, "errors: 0, warns: 0, infos: 0, skips: 0"},
+		"103": {mockMsgsFromType([]MsgType{"zeta", SkipMsg, "alpha", "zeta"}), "errors: 0, warns: 0, infos: 0, skips: 1, alpha: 1, zeta: 2"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := mock.msgs.SummaryString(); actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, actual)
+			}
+		})
+	}
+}
+
+func TestYamlStringE(t *testing.T) {
+	tests := map[string]struct {
+		o           interface{}
+		expected    string
+		expectedStr string
+		isErr       bool
+	}{
+		"101": {struct{ Name string }{"pool"}, "Name: pool\n", "\nName: pool\n", false},
+		"102": {nil, "", "", false},
+		"103": {struct{ C chan int }{make(chan int)}, "", "", true},
+		"104": {map[string]int{"b": 2, "a": 1}, "a: 1\nb: 2\n", "\na: 1\nb: 2\n", false},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := YamlStringE("mock", mock.o)
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test '%s' failed: expected error '%t': actual '%v'", name, mock.isErr, err)
+			}
+			if actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%q': actual '%q'", name, mock.expected, actual)
+			}
+			str := YamlString("mock", mock.o)
+			if mock.isErr {
+				if str != err.Error() {
+					t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, err, str)
+				}
+				return
+			}
+			if str != mock.expectedStr {
+				t.Fatalf("Test '%s' failed: expected '%q': actual '%q'", name, mock.expectedStr, str)
+			}
+		})
+	}
+}
+
+func TestYamlStringOpts(t *testing.T) {
+	nested := map[string]interface{}{"pool": map[string]interface{}{"name": "p1", "disks": []string{"d1", "d2"}}}
+	tests := map[string]struct {
+		o        interface{}
+		opts     YamlOpts
+		expected string
+	}{
+		"101": {nested, YamlOpts{}, "pool:\n  disks:\n  - d1\n  - d2\n  name: p1\n"},
+		"102": {nested, YamlOpts{Indent: "  "}, "  pool:\n    disks:\n    - d1\n    - d2\n    name: p1\n"},
+		"103": {nested, YamlOpts{Indent: "    ", LeadingNewline: true}, "\n    pool:\n      disks:\n      - d1\n      - d2\n      name: p1\n"},
+		"104": {nested, YamlOpts{DocumentSeparator: true}, "---\npool:\n  disks:\n  - d1\n  - d2\n  name: p1\n"},
+		"105": {struct{ Desc string }{"l1\n\nl2"}, YamlOpts{Indent: "  ", DocumentSeparator: true}, "  ---\n  Desc: |-\n    l1\n\n    l2\n"},
+		"106": {struct{ Name string }{"pool"}, DefaultYamlOpts, YamlString("mock", struct{ Name string }{"pool"})},
+		"107": {nil, YamlOpts{Indent: "  ", LeadingNewline: true}, ""},
+		"108": {struct{ C chan int }{make(chan int)}, YamlOpts{Indent: "  "}, "error marshaling into JSON: json: unsupported type: chan int: failed to format 'mock' as yaml string"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := YamlStringOpts("mock", mock.o, mock.opts)
+			if actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%q': actual '%q'", name, mock.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMsgsYAML(t *testing.T) {
+	m := (&Msgs{}).AddWarn("w1")
+	y, err := m.YAML()
+	if err != nil || y != "items:\n- desc: w1\n  type: warn\n" {
+		t.Fatalf("Test failed: expected raw yaml document: actual '%q' '%v'", y, err)
+	}
+	if m.String() != "\n"+y {
+		t.Fatalf("Test failed: expected string to be prefixed with newline: actual '%q'", m.String())
+	}
+
+	a := m.AllMsgs()
+	y, err = a.YAML()
+	if err != nil || y != "warn:\n  items:\n  - desc: w1\n    type: warn\n" {
+		t.Fatalf("Test failed: expected raw yaml document: actual '%q' '%v'", y, err)
+	}
+	if a.String() != "\n"+y {
+		t.Fatalf("Test failed: expected string to be prefixed with newline: actual '%q'", a.String())
+	}
+}
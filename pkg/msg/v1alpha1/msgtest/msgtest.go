@@ -0,0 +1,122 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package msgtest provides assertions and fixtures to test code that
+// produces messages
+package msgtest
+
+import (
+	"strings"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+)
+
+// T abstracts the parts of testing.T used by the assertions
+type T interface {
+	Helper()
+	Fatalf(format string, args ...interface{})
+}
+
+// count returns the number of messages of the provided type whose
+// description contains the provided substring
+func count(m msg.Msgs, mtype msg.MsgType, substr string) (n int) {
+	for _, item := range m.Items {
+		if item != nil && item.Mtype == mtype && strings.Contains(item.Description(), substr) {
+			n++
+		}
+	}
+	return
+}
+
+// AssertContains fails the test if there is no message of the provided
+// type whose description contains the provided substring
+func AssertContains(t T, m msg.Msgs, mtype msg.MsgType, substr string) {
+	t.Helper()
+	if count(m, mtype, substr) == 0 {
+		t.Fatalf("expected a '%s' message containing '%s': actual messages '%s'", mtype, substr, m.String())
+	}
+}
+
+// AssertHasError fails the test if there is no error message whose
+// description contains the provided substring
+func AssertHasError(t T, m msg.Msgs, substr string) {
+	t.Helper()
+	if count(m, msg.ErrMsg, substr) == 0 {
+		t.Fatalf("expected an error containing '%s': actual messages '%s'", substr, m.String())
+	}
+}
+
+// AssertNoErrors fails the test if there is at least one error message
+func AssertNoErrors(t T, m msg.Msgs) {
+	t.Helper()
+	if n := count(m, msg.ErrMsg, ""); n != 0 {
+		t.Fatalf("expected no errors: actual %d errors in messages '%s'", n, m.String())
+	}
+}
+
+// AssertWarnCount fails the test if the number of warnings is not the
+// provided count
+func AssertWarnCount(t T, m msg.Msgs, n int) {
+	t.Helper()
+	if actual := count(m, msg.WarnMsg, ""); actual != n {
+		t.Fatalf("expected %d warnings: actual %d warnings in messages '%s'", n, actual, m.String())
+	}
+}
+
+// Builder builds messages to be used as test fixtures
+type Builder struct {
+	m *msg.Msgs
+}
+
+// Build returns a new instance of Builder
+func Build() *Builder {
+	return &Builder{m: &msg.Msgs{}}
+}
+
+// Info adds an info message
+func (b *Builder) Info(desc string) *Builder {
+	b.m.AddInfo(desc)
+	return b
+}
+
+// Warn adds a warning message
+func (b *Builder) Warn(desc string) *Builder {
+	b.m.AddWarn(desc)
+	return b
+}
+
+// Skip adds a skip message
+func (b *Builder) Skip(desc string) *Builder {
+	b.m.AddSkip(desc)
+	return b
+}
+
+// Error adds an error message
+func (b *Builder) Error(err error) *Builder {
+	b.m.AddError(err)
+	return b
+}
+
+// Deprecation adds a deprecation message
+func (b *Builder) Deprecation(desc string) *Builder {
+	b.m.AddDeprecation(desc)
+	return b
+}
+
+// Msgs returns the built messages
+func (b *Builder) Msgs() msg.Msgs {
+	return *b.m
+}
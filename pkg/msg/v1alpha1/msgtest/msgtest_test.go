@@ -0,0 +1,92 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package msgtest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+)
+
+// fakeT captures the failures reported by an assertion
+type fakeT struct {
+	helper   bool
+	failures []string
+}
+
+func (f *fakeT) Helper() { f.helper = true }
+
+func (f *fakeT) Fatalf(format string, args ...interface{}) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions(t *testing.T) {
+	m := Build().Info("pool created").Warn("replica degraded").Warn("replica lagging").
+		Error(errors.New("volume not found")).Msgs()
+	tests := map[string]struct {
+		assert  func(t T)
+		failure string
+	}{
+		"101": {func(t T) { AssertHasError(t, m, "not found") }, ""},
+		"102": {func(t T) { AssertHasError(t, m, "timeout") }, "expected an error containing 'timeout'"},
+		"103": {func(t T) { AssertHasError(t, m, "pool") }, "expected an error containing 'pool'"},
+		"104": {func(t T) { AssertNoErrors(t, Build().Info("i1").Msgs()) }, ""},
+		"105": {func(t T) { AssertNoErrors(t, m) }, "expected no errors: actual 1 errors"},
+		"106": {func(t T) { AssertWarnCount(t, m, 2) }, ""},
+		"107": {func(t T) { AssertWarnCount(t, m, 1) }, "expected 1 warnings: actual 2 warnings"},
+		"108": {func(t T) { AssertContains(t, m, msg.InfoMsg, "created") }, ""},
+		"109": {func(t T) { AssertContains(t, m, msg.SkipMsg, "created") }, "expected a 'skip' message containing 'created'"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := &fakeT{}
+			mock.assert(f)
+			if !f.helper {
+				t.Fatalf("Test '%s' failed: expected assertion to be marked as helper", name)
+			}
+			if len(mock.failure) == 0 {
+				if len(f.failures) != 0 {
+					t.Fatalf("Test '%s' failed: expected no failure: actual '%v'", name, f.failures)
+				}
+				return
+			}
+			if len(f.failures) != 1 || !strings.Contains(f.failures[0], mock.failure) {
+				t.Fatalf("Test '%s' failed: expected failure '%s': actual '%v'", name, mock.failure, f.failures)
+			}
+			if !strings.Contains(f.failures[0], "desc: replica degraded") {
+				t.Fatalf("Test '%s' failed: expected failure to include messages: actual '%s'", name, f.failures[0])
+			}
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	m := Build().Info("i1").Warn("w1").Skip("s1").Error(errors.New("e1")).Deprecation("d1").Msgs()
+	expected := []msg.MsgType{msg.InfoMsg, msg.WarnMsg, msg.SkipMsg, msg.ErrMsg, msg.DeprecationMsg}
+	if len(m.Items) != len(expected) {
+		t.Fatalf("Test failed: expected %d messages: actual '%s'", len(expected), m)
+	}
+	for i, item := range m.Items {
+		if item.Mtype != expected[i] {
+			t.Fatalf("Test failed: expected message %d of type '%s': actual '%s'", i, expected[i], item.Mtype)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	msgTypesMu sync.RWMutex
+	// msgTypes holds the valid message types
+	msgTypes = map[MsgType]bool{
+		InfoMsg:        true,
+		ErrMsg:         true,
+		WarnMsg:        true,
+		SkipMsg:        true,
+		ProgressMsg:    true,
+		DeprecationMsg: true,
+	}
+)
+
+// RegisterMsgType registers the provided custom message type as a valid
+// one. The message type is registered in lower case.
+func RegisterMsgType(t MsgType) {
+	msgTypesMu.Lock()
+	defer msgTypesMu.Unlock()
+	msgTypes[MsgType(strings.ToLower(strings.TrimSpace(string(t))))] = true
+}
+
+// IsValid returns true if the message type is either a declared or
+// a registered one
+func (t MsgType) IsValid() bool {
+	msgTypesMu.RLock()
+	defer msgTypesMu.RUnlock()
+	return msgTypes[t]
+}
+
+// validMsgTypes returns the valid message types in sorted order
+func validMsgTypes() (types []string) {
+	msgTypesMu.RLock()
+	defer msgTypesMu.RUnlock()
+	for t := range msgTypes {
+		types = append(types, string(t))
+	}
+	sort.Strings(types)
+	return
+}
+
+// invalidMsgTypeError returns the error for an invalid message type
+func invalidMsgTypeError(s string) error {
+	return fmt.Errorf("invalid message type '%s': must be one of %s", s, strings.Join(validMsgTypes(), ", "))
+}
+
+// ParseMsgType returns the message type represented by the provided
+// string after trimming surrounding whitespace and lower casing it e.g.
+// ' Error' is parsed as ErrMsg. It returns an error if the result is
+// not a valid message type.
+func ParseMsgType(s string) (MsgType, error) {
+	t := MsgType(strings.ToLower(strings.TrimSpace(s)))
+	if !t.IsValid() {
+		return "", invalidMsgTypeError(s)
+	}
+	return t, nil
+}
+
+// MarshalText is an implementation of encoding.TextMarshaler interface
+func (t MsgType) MarshalText() ([]byte, error) {
+	return []byte(t), nil
+}
+
+// UnmarshalText is an implementation of encoding.TextUnmarshaler
+// interface. A valid message type is normalized as per ParseMsgType
+// while any other text is retained as is e.g. a custom type that is not
+// registered or a type of a newer producer. Use ParseMsgType to reject
+// such types.
+func (t *MsgType) UnmarshalText(b []byte) error {
+	parsed, err := ParseMsgType(string(b))
+	if err != nil {
+		parsed = MsgType(b)
+	}
+	*t = parsed
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ghodss/yaml"
+)
+
+func TestParseMsgType(t *testing.T) {
+	tests := map[string]struct {
+		given    string
+		expected MsgType
+		err      string
+	}{
+		"101": {"error", ErrMsg, ""},
+		"102": {"Error", ErrMsg, ""},
+		"103": {"WARN", WarnMsg, ""},
+		"104": {"skip ", SkipMsg, ""},
+		"105": {"\tInfo\n", InfoMsg, ""},
+		"106": {"Deprecation", DeprecationMsg, ""},
+		"107": {"fatal", "", "invalid message type 'fatal': must be one of deprecation, error, info, progress, skip, warn"},
+		"108": {"", "", "invalid message type '': must be one of deprecation, error, info, progress, skip, warn"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := ParseMsgType(mock.given)
+			if len(mock.err) != 0 {
+				if err == nil || err.Error() != mock.err {
+					t.Fatalf("Test '%s' failed: expected error '%s': actual '%v'", name, mock.err, err)
+				}
+				return
+			}
+			if err != nil || actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s' '%v'", name, mock.expected, actual, err)
+			}
+			if !actual.IsValid() {
+				t.Fatalf("Test '%s' failed: expected '%s' to be valid", name, actual)
+			}
+		})
+	}
+}
+
+func TestRegisterMsgType(t *testing.T) {
+	if MsgType("audit").IsValid() {
+		t.Fatalf("Test failed: expected 'audit' to be invalid before registration")
+	}
+	RegisterMsgType(" Audit")
+	defer func() {
+		msgTypesMu.Lock()
+		delete(msgTypes, "audit")
+		msgTypesMu.Unlock()
+	}()
+	actual, err := ParseMsgType("AUDIT")
+	if err != nil || actual != "audit" {
+		t.Fatalf("Test failed: expected 'audit': actual '%s' '%v'", actual, err)
+	}
+}
+
+func TestMsgTypeText(t *testing.T) {
+	type config struct {
+		Threshold MsgType `json:"threshold"`
+	}
+	tests := map[string]struct {
+		given    string
+		expected MsgType
+	}{
+		"101": {"threshold: Warn", WarnMsg},
+		"102": {"threshold: ' error '", ErrMsg},
+		"103": {"threshold: fatal", "fatal"},
+		"104": {"threshold: Future", "Future"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			var c config
+			err := yaml.Unmarshal([]byte(mock.given), &c)
+			if err != nil || c.Threshold != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s' '%v'", name, mock.expected, c.Threshold, err)
+			}
+			b, err := json.Marshal(c)
+			if err != nil || string(b) != `{"threshold":"`+string(mock.expected)+`"}` {
+				t.Fatalf("Test '%s' failed: expected marshaled threshold: actual '%s' '%v'", name, string(b), err)
+			}
+			if _, err := ParseMsgType(string(c.Threshold)); (err == nil) != c.Threshold.IsValid() {
+				t.Fatalf("Test '%s' failed: expected ParseMsgType to reject only invalid types: actual '%v'", name, err)
+			}
+		})
+	}
+}
+
+func TestMsgsUnmarshalJSONUnknownType(t *testing.T) {
+	var m Msgs
+	if err := json.Unmarshal([]byte(`{"items":[{"type":"future","desc":"f1"}]}`), &m); err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	if len(m.Items) != 1 || m.Items[0].Mtype != "future" {
+		t.Fatalf("Test failed: expected unknown type to be retained: actual '%s'", m)
+	}
+	var a AllMsgs
+	if err := json.Unmarshal([]byte(`{"msgs":{"future":{"items":[{"type":"future","desc":"f1"}]}}}`), &a); err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	if len(a["future"].Items) != 1 {
+		t.Fatalf("Test failed: expected unknown bucket to be retained: actual '%s'", a)
+	}
+}
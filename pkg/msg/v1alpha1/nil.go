@@ -0,0 +1,30 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+)
+
+// mustNotBeNil panics with a message naming the provided method if the
+// receiver is nil. This is preferred to a nil pointer dereference deep
+// within the method which does not hint at the nil instance.
+func (m *Msgs) mustNotBeNil(method string) {
+	if m == nil {
+		panic(fmt.Sprintf("msg: %s invoked on a nil *Msgs: initialize via NewMsgs or &Msgs{}", method))
+	}
+}
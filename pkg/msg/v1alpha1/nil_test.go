@@ -0,0 +1,60 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNilMsgs(t *testing.T) {
+	tests := map[string]struct {
+		method string
+		call   func(m *Msgs)
+	}{
+		"101": {"AddInfo", func(m *Msgs) { m.AddInfo("i1") }},
+		"102": {"AddWarn", func(m *Msgs) { m.AddWarn("w1") }},
+		"103": {"AddSkip", func(m *Msgs) { m.AddSkip("s1") }},
+		"104": {"AddError", func(m *Msgs) { m.AddError(errors.New("e1")) }},
+		"105": {"Merge", func(m *Msgs) { m.Merge(&Msgs{}) }},
+		"106": {"Reset", func(m *Msgs) { m.Reset() }},
+		"107": {"AddInfo", func(m *Msgs) { m.AddInfo("") }},
+		"108": {"AddError", func(m *Msgs) { m.AddError(nil) }},
+		"109": {"Merge", func(m *Msgs) { m.Merge(nil) }},
+		"110": {"AddErrorWithContext", func(m *Msgs) { m.AddErrorWithContext("ctx", errors.New("e1")) }},
+		"111": {"AddWarnIf", func(m *Msgs) { m.AddWarnIf(false, "w1") }},
+		"112": {"MergeCounted", func(m *Msgs) { m.MergeCounted(&Msgs{}) }},
+		"113": {"AddProgress", func(m *Msgs) { m.AddProgress("p1", 10) }},
+		"114": {"WithLimit", func(m *Msgs) { m.WithLimit(1, DropOldest) }},
+		"115": {"OnAdd", func(m *Msgs) { m.OnAdd(nil) }},
+		"116": {"FilterInPlace", func(m *Msgs) { m.FilterInPlace(IsErr) }},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			expected := fmt.Sprintf("msg: %s invoked on a nil *Msgs: initialize via NewMsgs or &Msgs{}", mock.method)
+			defer func() {
+				if r := recover(); r != expected {
+					t.Fatalf("Test '%s' failed: expected panic '%s': actual '%v'", name, expected, r)
+				}
+			}()
+			var m *Msgs
+			mock.call(m)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ObjectRef refers to the object e.g. a PersistentVolumeClaim that a
+// message is about. The namespace is empty for cluster scoped objects.
+type ObjectRef struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// IsEmpty returns true if this reference does not refer to an object
+func (o ObjectRef) IsEmpty() bool {
+	return o == ObjectRef{}
+}
+
+// String returns this reference in its compact form i.e.
+// 'kind/namespace/name' or 'kind/name' if the namespace is empty e.g.
+// 'PersistentVolumeClaim/default/my-pvc'. An empty reference is an
+// empty string.
+func (o ObjectRef) String() string {
+	if o.IsEmpty() {
+		return ""
+	}
+	if len(o.Namespace) == 0 {
+		return o.Kind + "/" + o.Name
+	}
+	return o.Kind + "/" + o.Namespace + "/" + o.Name
+}
+
+// ParseObjectRef returns the reference represented by the provided
+// compact form as returned by String
+func ParseObjectRef(s string) (o ObjectRef, err error) {
+	if len(s) == 0 {
+		return
+	}
+	parts := strings.Split(s, "/")
+	switch len(parts) {
+	case 2:
+		o.Kind, o.Name = parts[0], parts[1]
+	case 3:
+		o.Kind, o.Namespace, o.Name = parts[0], parts[1], parts[2]
+	default:
+		err = fmt.Errorf("invalid object reference '%s': must be kind/name or kind/namespace/name", s)
+	}
+	return
+}
+
+// MarshalText is an implementation of encoding.TextMarshaler interface.
+// It renders the compact form of this reference.
+func (o ObjectRef) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText is an implementation of encoding.TextUnmarshaler
+// interface
+func (o *ObjectRef) UnmarshalText(b []byte) (err error) {
+	*o, err = ParseObjectRef(string(b))
+	return
+}
+
+// objectPtr returns a pointer to the provided reference or nil if it
+// is empty
+func objectPtr(o ObjectRef) *ObjectRef {
+	if o.IsEmpty() {
+		return nil
+	}
+	return &o
+}
+
+// objectOf returns the reference of the provided message or an empty
+// reference if it is not about an object
+func objectOf(given *msg) ObjectRef {
+	if given == nil || given.Object == nil {
+		return ObjectRef{}
+	}
+	return *given.Object
+}
+
+// AboutObject returns a predicate that is true for messages about the
+// provided object. An empty reference matches the messages that are
+// not about an object.
+func AboutObject(o ObjectRef) msgPredicate {
+	return func(given *msg) bool {
+		return objectOf(given) == o
+	}
+}
+
+// AddInfoFor appends a new InfoMsg about the provided object
+func (m *Msgs) AddInfoFor(o ObjectRef, i string) (u *Msgs) {
+	m.mustNotBeNil("AddInfoFor")
+	if len(i) == 0 {
+		return m
+	}
+	return m.add(m.newMsg(msg{Mtype: InfoMsg, Desc: i, Object: objectPtr(o)}))
+}
+
+// AddWarnFor appends a new WarnMsg about the provided object
+func (m *Msgs) AddWarnFor(o ObjectRef, w string) (u *Msgs) {
+	m.mustNotBeNil("AddWarnFor")
+	if len(w) == 0 {
+		return m
+	}
+	return m.add(m.newMsg(msg{Mtype: WarnMsg, Desc: w, Object: objectPtr(o)}))
+}
+
+// AddSkipFor appends a new SkipMsg about the provided object
+func (m *Msgs) AddSkipFor(o ObjectRef, s string) (u *Msgs) {
+	m.mustNotBeNil("AddSkipFor")
+	if len(s) == 0 {
+		return m
+	}
+	return m.add(m.newMsg(msg{Mtype: SkipMsg, Desc: s, Object: objectPtr(o)}))
+}
+
+// AddErrorFor appends a new ErrMsg about the provided object
+func (m *Msgs) AddErrorFor(o ObjectRef, e error) (u *Msgs) {
+	m.mustNotBeNil("AddErrorFor")
+	if e == nil {
+		return m
+	}
+	return m.add(m.newMsg(msg{Mtype: ErrMsg, Desc: e.Error(), Err: e, Object: objectPtr(o)}))
+}
+
+// ByObject returns the messages grouped by the object they are about.
+// Messages that are not about an object are grouped against an empty
+// reference.
+func (m Msgs) ByObject() (b map[ObjectRef]Msgs) {
+	b = map[ObjectRef]Msgs{}
+	for _, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		o := objectOf(item)
+		g := b[o]
+		g.Items = append(g.Items, item)
+		b[o] = g
+	}
+	return
+}
+
+// parseObjectPtr returns a pointer to the reference represented by the
+// provided compact form or nil if it is empty or invalid
+func parseObjectPtr(s string) *ObjectRef {
+	o, err := ParseObjectRef(s)
+	if err != nil {
+		return nil
+	}
+	return objectPtr(o)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"errors"
+	"go/parser"
+	"strings"
+	"testing"
+)
+
+func mockObjectMsgs() *Msgs {
+	pvc := ObjectRef{Kind: "PersistentVolumeClaim", Namespace: "default", Name: "my-pvc"}
+	pool := ObjectRef{Kind: "CStorPool", Name: "cp1"}
+	return (&Msgs{}).
+		AddInfoFor(pvc, "i1").
+		AddWarnFor(pool, "w1").
+		AddInfo("i2").
+		AddErrorFor(pvc, errors.New("e1")).
+		AddSkipFor(ObjectRef{}, "s1")
+}
+
+func TestObjectRefString(t *testing.T) {
+	tests := map[string]struct {
+		ref         ObjectRef
+		expected    string
+		expectedErr bool
+	}{
+		"101": {ObjectRef{Kind: "PersistentVolumeClaim", Namespace: "default", Name: "my-pvc"}, "PersistentVolumeClaim/default/my-pvc", false},
+		"102": {ObjectRef{Kind: "CStorPool", Name: "cp1"}, "CStorPool/cp1", false},
+		"103": {ObjectRef{}, "", false},
+		"104": {ObjectRef{}, "CStorPool", true},
+		"105": {ObjectRef{}, "a/b/c/d", true},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			o, err := ParseObjectRef(mock.expected)
+			if (err != nil) != mock.expectedErr {
+				t.Fatalf("Test '%s' failed: expected error '%t': actual '%v'", name, mock.expectedErr, err)
+			}
+			if mock.expectedErr {
+				return
+			}
+			if mock.ref.String() != mock.expected || o != mock.ref {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s' '%s'", name, mock.expected, mock.ref.String(), o)
+			}
+		})
+	}
+}
+
+func TestMsgsByObject(t *testing.T) {
+	m := mockObjectMsgs()
+	merged := (&Msgs{}).AddWarn("w0").Merge(m)
+
+	tests := map[string]struct {
+		ref      ObjectRef
+		expected string
+	}{
+		"101": {ObjectRef{Kind: "PersistentVolumeClaim", Namespace: "default", Name: "my-pvc"}, "i1,e1"},
+		"102": {ObjectRef{Kind: "CStorPool", Name: "cp1"}, "w1"},
+		"103": {ObjectRef{}, "w0,i2,s1"},
+		"104": {ObjectRef{Kind: "CStorPool", Name: "cp2"}, ""},
+	}
+
+	groups := merged.ByObject()
+	if len(groups) != 3 {
+		t.Fatalf("Test failed: expected 3 groups: actual %d", len(groups))
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := groups[mock.ref]
+			if actual := strings.Join(g.Descriptions(), ","); actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected group '%s': actual '%s'", name, mock.expected, actual)
+			}
+			f := merged.Filter(AboutObject(mock.ref))
+			if actual := strings.Join(f.Descriptions(), ","); actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected filtered '%s': actual '%s'", name, mock.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMsgsObjectSerialization(t *testing.T) {
+	m := mockObjectMsgs()
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%v'", err)
+	}
+	if !strings.Contains(string(b), `"object":"PersistentVolumeClaim/default/my-pvc"`) ||
+		!strings.Contains(string(b), `"object":"CStorPool/cp1"`) || strings.Count(string(b), `"object"`) != 3 {
+		t.Fatalf("Test failed: expected compact objects: actual '%s'", string(b))
+	}
+	if !strings.Contains(m.String(), "object: PersistentVolumeClaim/default/my-pvc") {
+		t.Fatalf("Test failed: expected compact object in yaml: actual '%s'", m.String())
+	}
+	for name, roundTrip := range mockRoundTrips() {
+		actual, err := roundTrip(m)
+		if err != nil || !m.EqualStrict(*actual) {
+			t.Fatalf("Test '%s' failed: expected objects to round trip: actual '%v' '%v'", name, actual, err)
+		}
+		if actual.Items[4].Object != nil {
+			t.Fatalf("Test '%s' failed: expected no object for an empty reference: actual '%v'", name, actual.Items[4].Object)
+		}
+	}
+	if _, err := parser.ParseExpr(m.GoString()); err != nil || !strings.Contains(m.GoString(), `.AddWarnFor(v1alpha1.ObjectRef{`) {
+		t.Fatalf("Test failed: expected objects in go syntax: actual '%s' '%v'", m.GoString(), err)
+	}
+	var invalid Msgs
+	if err := json.Unmarshal([]byte(`{"items":[{"type":"info","desc":"i1","object":"pvc"}]}`), &invalid); err == nil {
+		t.Fatalf("Test failed: expected an error for an invalid object")
+	}
+}
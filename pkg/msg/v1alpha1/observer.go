@@ -0,0 +1,57 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sync/atomic"
+)
+
+// Observer abstracts a callback that gets invoked with the type of
+// every message that gets added
+type Observer func(MsgType)
+
+// observer holds the registered Observer
+var observer atomic.Value
+
+// SetObserver registers the observer that gets invoked for every message
+// added via AddInfo, AddWarn, AddSkip or AddError. Messages imported via
+// Merge are not observed since they were observed when first added.
+// Passing nil removes the registered observer.
+func SetObserver(o Observer) {
+	observer.Store(o)
+}
+
+// observe invokes the registered observer if any
+func observe(mtype MsgType) {
+	o, _ := observer.Load().(Observer)
+	if o == nil {
+		return
+	}
+	o(mtype)
+}
+
+// ObserveAll invokes the registered observer for every non nil message.
+// This is useful to record messages that were built before an observer
+// was registered.
+func (m Msgs) ObserveAll() {
+	for _, msg := range m.Items {
+		if msg == nil {
+			continue
+		}
+		observe(msg.Mtype)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetObserver(t *testing.T) {
+	var observed []MsgType
+	SetObserver(func(mtype MsgType) {
+		observed = append(observed, mtype)
+	})
+	defer SetObserver(nil)
+
+	m := &Msgs{}
+	m.AddInfo("i").AddWarn("w").AddSkip("s").AddError(errors.New("e"))
+	m.AddInfo("").AddError(nil)
+	m.Merge((&Msgs{}).AddInfo("merged"))
+
+	expected := []MsgType{InfoMsg, WarnMsg, SkipMsg, ErrMsg, InfoMsg}
+	if len(observed) != len(expected) {
+		t.Fatalf("Test failed: expected observed %v: actual observed %v", expected, observed)
+	}
+	for i, mtype := range observed {
+		if mtype != expected[i] {
+			t.Fatalf("Test failed: expected observed %v: actual observed %v", expected, observed)
+		}
+	}
+
+	observed = nil
+	m.ObserveAll()
+	if len(observed) != len(m.Items) {
+		t.Fatalf("Test failed: expected %d observed via ObserveAll: actual %d", len(m.Items), len(observed))
+	}
+
+	SetObserver(nil)
+	observed = nil
+	m.AddWarn("unobserved")
+	if len(observed) != 0 {
+		t.Fatalf("Test failed: expected nothing observed after removal: actual %v", observed)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// seenSet holds the keys of the messages of a given generation
+type seenSet struct {
+	gen  uint64
+	keys map[msgKey]bool
+}
+
+// seenKeys returns the keys of the current messages. The keys are
+// derived afresh if the messages were changed by anything other than
+// the Add*Once methods e.g. Merge or Reset.
+func (m *Msgs) seenKeys() map[msgKey]bool {
+	if m.seen != nil && m.seen.gen == m.gen {
+		return m.seen.keys
+	}
+	m.seen = &seenSet{gen: m.gen, keys: make(map[msgKey]bool, len(m.Items))}
+	for _, item := range m.Items {
+		if item != nil {
+			m.seen.keys[keyOf(item)] = true
+		}
+	}
+	return m.seen.keys
+}
+
+// addOnce appends the provided message unless a message of same type,
+// description and code was already added
+func (m *Msgs) addOnce(item *msg) (u *Msgs) {
+	// the scrubbed message is compared such that the original
+	// description is not retained as a key
+	if !scrub(item) {
+		return m
+	}
+	keys := m.seenKeys()
+	k := keyOf(item)
+	if keys[k] {
+		return m
+	}
+	m.addScrubbed(item)
+	// the key as added may differ e.g. in strict mode
+	keys[k], keys[keyOf(item)] = true, true
+	m.seen.gen = m.gen
+	return m
+}
+
+// AddInfoOnce appends a new InfoMsg unless an identical one exists
+func (m *Msgs) AddInfoOnce(i string) (u *Msgs) {
+	m.mustNotBeNil("AddInfoOnce")
+	if len(i) == 0 {
+		return m
+	}
+	return m.addOnce(m.newMsg(msg{Mtype: InfoMsg, Desc: i}))
+}
+
+// AddWarnOnce appends a new WarnMsg unless an identical one exists
+func (m *Msgs) AddWarnOnce(w string) (u *Msgs) {
+	m.mustNotBeNil("AddWarnOnce")
+	if len(w) == 0 {
+		return m
+	}
+	return m.addOnce(m.newMsg(msg{Mtype: WarnMsg, Desc: w}))
+}
+
+// AddSkipOnce appends a new SkipMsg unless an identical one exists
+func (m *Msgs) AddSkipOnce(s string) (u *Msgs) {
+	m.mustNotBeNil("AddSkipOnce")
+	if len(s) == 0 {
+		return m
+	}
+	return m.addOnce(m.newMsg(msg{Mtype: SkipMsg, Desc: s}))
+}
+
+// AddErrorOnce appends a new ErrMsg unless one with an identical
+// description exists
+func (m *Msgs) AddErrorOnce(e error) (u *Msgs) {
+	m.mustNotBeNil("AddErrorOnce")
+	if e == nil {
+		return m
+	}
+	return m.addOnce(m.newMsg(msg{Mtype: ErrMsg, Desc: e.Error(), Err: e}))
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMsgsAddOnce(t *testing.T) {
+	tests := map[string]struct {
+		add      func(m *Msgs, i int) *Msgs
+		expected string
+	}{
+		"101": {func(m *Msgs, i int) *Msgs { return m.AddWarnOnce("replica not ready") }, "replica not ready"},
+		"102": {func(m *Msgs, i int) *Msgs { return m.AddInfoOnce("i1") }, "i1"},
+		"103": {func(m *Msgs, i int) *Msgs { return m.AddSkipOnce("s1") }, "s1"},
+		"104": {func(m *Msgs, i int) *Msgs { return m.AddErrorOnce(fmt.Errorf("e%d", i%2)) }, "e0,e1"},
+		"105": {func(m *Msgs, i int) *Msgs { return m.AddWarnOnce(fmt.Sprintf("w%d", i%3)) }, "w0,w1,w2"},
+		"106": {func(m *Msgs, i int) *Msgs { return m.AddErrorOnce(nil).AddWarnOnce("") }, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := &Msgs{}
+			for i := 0; i < 1000; i++ {
+				if u := mock.add(m, i); u != m {
+					t.Fatalf("Test '%s' failed: expected receiver to be returned", name)
+				}
+			}
+			if descs(m) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(m))
+			}
+		})
+	}
+}
+
+func TestMsgsAddOnceTypes(t *testing.T) {
+	m := (&Msgs{}).AddWarnOnce("x").AddInfoOnce("x").AddSkipOnce("x").AddErrorOnce(errors.New("x")).AddWarnOnce("x")
+	if descs(m) != "x,x,x,x" {
+		t.Fatalf("Test failed: expected same description of different types to be added: actual '%s'", m)
+	}
+}
+
+func TestMsgsAddOnceMergeReset(t *testing.T) {
+	m := (&Msgs{}).AddWarnOnce("w1")
+	m.Merge((&Msgs{}).AddWarn("w2"))
+	m.AddWarn("w3")
+	m.AddWarnOnce("w1").AddWarnOnce("w2").AddWarnOnce("w3").AddWarnOnce("w4")
+	if descs(m) != "w1,w2,w3,w4" {
+		t.Fatalf("Test failed: expected merged and added messages to be seen: actual '%s'", descs(m))
+	}
+	m.Reset()
+	m.AddWarnOnce("w1").AddWarnOnce("w1")
+	if descs(m) != "w1" {
+		t.Fatalf("Test failed: expected reset to clear seen messages: actual '%s'", descs(m))
+	}
+}
+
+func TestMsgsAddOnceCopies(t *testing.T) {
+	m := (&Msgs{}).AddWarnOnce("w1")
+	c := *m
+	c.AddWarnOnce("w2")
+	m.AddWarnOnce("w2")
+	if descs(m) != "w1,w2" || descs(&c) != "w1,w2" {
+		t.Fatalf("Test failed: expected copies to track seen messages independently: actual '%s' '%s'", descs(m), descs(&c))
+	}
+}
+
+func TestMsgsAddOnceStrict(t *testing.T) {
+	m := (&Msgs{}).WithStrictMode(true).AddWarnOnce("w1")
+	m.AddWarnOnce("w1")
+	if len(m.Items) != 1 || m.Items[0].Mtype != ErrMsg {
+		t.Fatalf("Test failed: expected one escalated message: actual '%s'", m)
+	}
+}
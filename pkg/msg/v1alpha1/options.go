@@ -0,0 +1,124 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Option configures the messages built via NewMsgs
+type Option func(m *Msgs)
+
+// NewMsgs returns a new instance of Msgs configured with the provided
+// options. Options apply to messages added after construction and not
+// to messages that are merged.
+func NewMsgs(opts ...Option) (m *Msgs) {
+	m = &Msgs{}
+	for _, o := range opts {
+		o(m)
+	}
+	return
+}
+
+// WithCapacity pre-allocates room for the provided number of messages
+func WithCapacity(n int) Option {
+	return func(m *Msgs) {
+		if n > 0 {
+			m.Items = make([]*msg, 0, n)
+		}
+	}
+}
+
+// WithTimestamps stamps the time on every added message
+func WithTimestamps() Option {
+	return func(m *Msgs) {
+		m.timestamps = true
+	}
+}
+
+// WithCaller stamps the file and line that added the message on every
+// added message
+func WithCaller() Option {
+	return func(m *Msgs) {
+		m.caller = true
+	}
+}
+
+// WithClock sets the clock used to stamp the time on messages
+func WithClock(now func() time.Time) Option {
+	return func(m *Msgs) {
+		m.now = now
+	}
+}
+
+// clock returns the current time as per the configured clock
+func (m *Msgs) clock() time.Time {
+	if m.now == nil {
+		return time.Now()
+	}
+	return m.now()
+}
+
+// WithLimit caps the number of messages as per Msgs.WithLimit
+func WithLimit(n int, p LimitPolicy) Option {
+	return func(m *Msgs) {
+		m.WithLimit(n, p)
+	}
+}
+
+// WithSource stamps the provided source as per Msgs.WithSource
+func WithSource(s string) Option {
+	return func(m *Msgs) {
+		m.WithSource(s)
+	}
+}
+
+// pkgPrefix is the prefix of the functions of this package
+var pkgPrefix = reflect.TypeOf(Msgs{}).PkgPath() + "."
+
+// framesOutside returns at most depth frames of the current stack
+// starting at the nearest caller that is not a function of this
+// package. Tests of this package are considered to be outside.
+func framesOutside(depth int) (outside []runtime.Frame) {
+	pcs := make([]uintptr, depth+16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for len(outside) < depth {
+		f, more := frames.Next()
+		if len(outside) != 0 || !strings.HasPrefix(f.Function, pkgPrefix) || strings.HasSuffix(f.File, "_test.go") {
+			outside = append(outside, f)
+		}
+		if !more {
+			break
+		}
+	}
+	return
+}
+
+// callerOutside returns the file and line of the nearest caller that is
+// not a function of this package
+func callerOutside() string {
+	frames := framesOutside(1)
+	if len(frames) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", filepath.Base(frames[0].File), frames[0].Line)
+}
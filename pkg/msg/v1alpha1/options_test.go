@@ -0,0 +1,92 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewMsgs(t *testing.T) {
+	before := time.Now()
+	tests := map[string]struct {
+		opts     []Option
+		capacity int
+		time     bool
+		caller   bool
+		source   string
+		expected string
+	}{
+		"101": {nil, 0, false, false, "", "i1,w1,e1"},
+		"102": {[]Option{WithCapacity(8)}, 8, false, false, "", "i1,w1,e1"},
+		"103": {[]Option{WithTimestamps()}, 0, true, false, "", "i1,w1,e1"},
+		"104": {[]Option{WithCaller()}, 0, false, true, "", "i1,w1,e1"},
+		"105": {[]Option{WithSource("pool")}, 0, false, false, "pool", "i1,w1,e1"},
+		"106": {[]Option{WithLimit(2, DropNewest)}, 0, false, false, "", "i1,w1,dropped 1 newer messages"},
+		"107": {[]Option{WithCapacity(4), WithTimestamps(), WithCaller(), WithSource("pool"), WithLimit(2, DropOldest)},
+			4, true, true, "pool", "dropped 1 older messages,w1,e1"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := NewMsgs(mock.opts...)
+			if cap(m.Items) != mock.capacity {
+				t.Fatalf("Test '%s' failed: expected capacity %d: actual %d", name, mock.capacity, cap(m.Items))
+			}
+			m.AddInfo("i1").AddWarn("w1").AddError(errors.New("e1"))
+			if descs(m) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(m))
+			}
+			item := m.Items[len(m.Items)-1]
+			if mock.time != (item.Time != nil) || (item.Time != nil && item.Time.Before(before)) {
+				t.Fatalf("Test '%s' failed: expected time %t: actual '%v'", name, mock.time, item.Time)
+			}
+			if mock.caller != strings.HasPrefix(item.Caller, "options_test.go:") {
+				t.Fatalf("Test '%s' failed: expected caller %t: actual '%s'", name, mock.caller, item.Caller)
+			}
+			if item.Source != mock.source {
+				t.Fatalf("Test '%s' failed: expected source '%s': actual '%s'", name, mock.source, item.Source)
+			}
+		})
+	}
+}
+
+func TestNewMsgsMerge(t *testing.T) {
+	m := NewMsgs(WithTimestamps(), WithCaller(), WithSource("pool"))
+	m.Merge((&Msgs{}).AddInfo("merged"))
+	m.AddInfo("added")
+	merged, added := m.Items[0], m.Items[1]
+	if merged.Time != nil || len(merged.Caller) != 0 || len(merged.Source) != 0 {
+		t.Fatalf("Test failed: expected merged message to be untouched: actual '%#v'", merged)
+	}
+	if added.Time == nil || len(added.Caller) == 0 || added.Source != "pool" {
+		t.Fatalf("Test failed: expected added message to be stamped: actual '%#v'", added)
+	}
+}
+
+func TestMsgsZeroValue(t *testing.T) {
+	m := &Msgs{}
+	m.AddInfo("i1")
+	if m.Items[0].Time != nil || len(m.Items[0].Caller) != 0 {
+		t.Fatalf("Test failed: expected zero value to not stamp messages: actual '%#v'", m.Items[0])
+	}
+	if strings.Contains(m.String(), "time") || strings.Contains(m.String(), "caller") {
+		t.Fatalf("Test failed: expected no time or caller in yaml: actual '%s'", m)
+	}
+}
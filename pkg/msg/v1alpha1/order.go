@@ -0,0 +1,50 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// lastSeq is the sequence number of the most recently added message
+var lastSeq uint64
+
+// nextSeq returns the sequence number of a message being added
+func nextSeq() uint64 {
+	return atomic.AddUint64(&lastSeq, 1)
+}
+
+// ToMsgsChronological parses AllMsgs to Msgs in the order the messages
+// were added. Messages that were not added via Add* methods e.g. ones
+// that were deserialized, have no sequence and are placed first while
+// retaining their order from ToMsgs.
+func (a AllMsgs) ToMsgsChronological() (m *Msgs) {
+	m = a.ToMsgs()
+	sort.SliceStable(m.Items, func(i, j int) bool {
+		return seqOf(m.Items[i]) < seqOf(m.Items[j])
+	})
+	return
+}
+
+// seqOf returns the sequence number of the provided message
+func seqOf(given *msg) uint64 {
+	if given == nil {
+		return 0
+	}
+	return given.seq
+}
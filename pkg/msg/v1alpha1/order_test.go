@@ -0,0 +1,48 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAllMsgsToMsgsChronological(t *testing.T) {
+	tests := map[string]struct {
+		msgs      *Msgs
+		expected  string
+		unchanged string
+	}{
+		"101": {(&Msgs{}).AddInfo("i1").AddError(errors.New("e1")).AddSkip("s1").AddWarn("w1").AddInfo("i2").
+			AddDeprecation("d1").AddError(errors.New("e2")),
+			"i1,e1,s1,w1,i2,d1,e2", "e1,e2,w1,d1,i1,i2,s1"},
+		"102": {(&Msgs{}).AddSkip("s1").Merge((&Msgs{}).AddWarn("w1")).AddInfo("i1"), "s1,w1,i1", "w1,i1,s1"},
+		"103": {&Msgs{}, "", ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := mock.msgs.AllMsgs()
+			if actual := descs(a.ToMsgsChronological()); actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, actual)
+			}
+			if actual := descs(a.ToMsgs()); actual != mock.unchanged {
+				t.Fatalf("Test '%s' failed: expected default order '%s': actual '%s'", name, mock.unchanged, actual)
+			}
+		})
+	}
+}
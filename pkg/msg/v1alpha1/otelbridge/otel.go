@@ -0,0 +1,84 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package otelbridge exports messages as OpenTelemetry span events. It
+// lives in its own package so that users of msg/v1alpha1 that do not
+// trace via OpenTelemetry do not pull in the otel dependency.
+package otelbridge
+
+import (
+	"errors"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TypeKey is the event attribute holding the message type
+	TypeKey = attribute.Key("msg.type")
+	// DescKey is the event attribute holding the message description
+	DescKey = attribute.Key("msg.desc")
+)
+
+// Options controls how messages are exported as span events
+type Options struct {
+	// SetErrorStatus sets the span status to codes.Error with the
+	// first error's description if any ErrMsg message is present
+	SetErrorStatus bool
+}
+
+// DefaultOptions exports messages without changing the span status
+var DefaultOptions = Options{}
+
+// AddSpanEvents adds an event to the provided span for every non nil
+// message using DefaultOptions
+func AddSpanEvents(span trace.Span, m msg.Msgs) {
+	AddSpanEventsWithOptions(span, m, DefaultOptions)
+}
+
+// AddSpanEventsWithOptions adds an event to the provided span for every
+// non nil message. The event is named after the message type and has the
+// message type and description as attributes. ErrMsg messages are
+// recorded via span.RecordError instead, which results in an exception
+// event carrying the same attributes.
+func AddSpanEventsWithOptions(span trace.Span, m msg.Msgs, o Options) {
+	for _, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		attrs := trace.WithAttributes(
+			TypeKey.String(string(item.Mtype)),
+			DescKey.String(item.Description()),
+		)
+		if item.Mtype == msg.ErrMsg {
+			err := item.Err
+			if err == nil {
+				err = errors.New(item.Description())
+			}
+			span.RecordError(err, attrs)
+			continue
+		}
+		span.AddEvent(string(item.Mtype), attrs)
+	}
+	if !o.SetErrorStatus {
+		return
+	}
+	if err := m.AllMsgs().Error(); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otelbridge
+
+import (
+	"errors"
+	"testing"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// mockEvent is an event recorded by mockSpan
+type mockEvent struct {
+	name  string
+	err   error
+	attrs []attribute.KeyValue
+}
+
+// mockSpan is a trace.Span that records the events and status
+// set against it
+type mockSpan struct {
+	noop.Span
+	events []mockEvent
+	status codes.Code
+	desc   string
+}
+
+func (s *mockSpan) AddEvent(name string, options ...trace.EventOption) {
+	c := trace.NewEventConfig(options...)
+	s.events = append(s.events, mockEvent{name: name, attrs: c.Attributes()})
+}
+
+func (s *mockSpan) RecordError(err error, options ...trace.EventOption) {
+	c := trace.NewEventConfig(options...)
+	s.events = append(s.events, mockEvent{name: "exception", err: err, attrs: c.Attributes()})
+}
+
+func (s *mockSpan) SetStatus(code codes.Code, description string) {
+	s.status, s.desc = code, description
+}
+
+// mockAttr returns the value of the given attribute key
+func mockAttr(attrs []attribute.KeyValue, key attribute.Key) string {
+	for _, a := range attrs {
+		if a.Key == key {
+			return a.Value.AsString()
+		}
+	}
+	return ""
+}
+
+func TestAddSpanEventsWithOptions(t *testing.T) {
+	tests := map[string]struct {
+		msgs           *msg.Msgs
+		options        Options
+		expectedEvents []string
+		expectedTypes  []string
+		expectedDescs  []string
+		expectedStatus codes.Code
+	}{
+		"101": {(&msg.Msgs{}).AddInfo("i1").AddWarn("w1").AddSkip("s1"), DefaultOptions,
+			[]string{"info", "warn", "skip"}, []string{"info", "warn", "skip"}, []string{"i1", "w1", "s1"}, codes.Unset},
+		"102": {(&msg.Msgs{}).AddWarn("w1").AddError(errors.New("e1")), DefaultOptions,
+			[]string{"warn", "exception"}, []string{"warn", "error"}, []string{"w1", "e1"}, codes.Unset},
+		"103": {(&msg.Msgs{}).AddWarn("w1").AddError(errors.New("e1")), Options{SetErrorStatus: true},
+			[]string{"warn", "exception"}, []string{"warn", "error"}, []string{"w1", "e1"}, codes.Error},
+		"104": {(&msg.Msgs{}).AddWarn("w1"), Options{SetErrorStatus: true},
+			[]string{"warn"}, []string{"warn"}, []string{"w1"}, codes.Unset},
+		"105": {&msg.Msgs{}, Options{SetErrorStatus: true}, nil, nil, nil, codes.Unset},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			span := &mockSpan{}
+			AddSpanEventsWithOptions(span, *mock.msgs, mock.options)
+
+			if len(span.events) != len(mock.expectedEvents) {
+				t.Fatalf("Test '%s' failed: expected events %v: actual events %v", name, mock.expectedEvents, span.events)
+			}
+			for i, e := range span.events {
+				if e.name != mock.expectedEvents[i] {
+					t.Fatalf("Test '%s' failed: expected event '%s': actual event '%s'", name, mock.expectedEvents[i], e.name)
+				}
+				if mtype := mockAttr(e.attrs, TypeKey); mtype != mock.expectedTypes[i] {
+					t.Fatalf("Test '%s' failed: expected type '%s': actual type '%s'", name, mock.expectedTypes[i], mtype)
+				}
+				if desc := mockAttr(e.attrs, DescKey); desc != mock.expectedDescs[i] {
+					t.Fatalf("Test '%s' failed: expected desc '%s': actual desc '%s'", name, mock.expectedDescs[i], desc)
+				}
+				if e.name == "exception" && (e.err == nil || e.err.Error() != mock.expectedDescs[i]) {
+					t.Fatalf("Test '%s' failed: expected recorded error '%s': actual '%v'", name, mock.expectedDescs[i], e.err)
+				}
+			}
+			if span.status != mock.expectedStatus {
+				t.Fatalf("Test '%s' failed: expected status '%s': actual status '%s'", name, mock.expectedStatus, span.status)
+			}
+			if span.status == codes.Error && span.desc != "e1" {
+				t.Fatalf("Test '%s' failed: expected status description 'e1': actual '%s'", name, span.desc)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Phase represents the outcome of an operation derived from its
+// messages e.g. to be set as the status phase of a resource
+type Phase string
+
+const (
+	// FailedPhase represents an operation that failed
+	FailedPhase Phase = "Failed"
+	// DegradedPhase represents an operation that completed with
+	// warnings
+	DegradedPhase Phase = "Degraded"
+	// NoOpPhase represents an operation that skipped everything
+	NoOpPhase Phase = "NoOp"
+	// HealthyPhase represents an operation that completed
+	HealthyPhase Phase = "Healthy"
+	// UnknownPhase represents an operation without any message
+	UnknownPhase Phase = "Unknown"
+)
+
+// PhasePolicy represents the message types that derive a phase. Message
+// types are compared by their severity such that custom types derive
+// the phase of the declared type they are as severe as. A message type
+// that is not set is considered as set in DefaultPhasePolicy.
+type PhasePolicy struct {
+	FailedAt   MsgType // least severe message type that fails
+	DegradedAt MsgType // least severe message type that degrades
+	NoOpAt     MsgType // most severe message type that is a no-op
+}
+
+// DefaultPhasePolicy fails on errors, degrades on warnings and is a
+// no-op if there are skips only
+var DefaultPhasePolicy = PhasePolicy{FailedAt: ErrMsg, DegradedAt: WarnMsg, NoOpAt: SkipMsg}
+
+// orDefault returns the provided message type or the provided default
+// if it is not set
+func orDefault(t, def MsgType) MsgType {
+	if len(t) == 0 {
+		return def
+	}
+	return t
+}
+
+// PhaseWithPolicy returns the phase derived from the messages as per
+// the provided policy i.e. Failed if a message is at least as severe as
+// FailedAt, Degraded if a message is at least as severe as DegradedAt,
+// NoOp if no message is more severe than NoOpAt, Healthy otherwise and
+// Unknown if there are no messages
+func (a AllMsgs) PhaseWithPolicy(p PhasePolicy) Phase {
+	failed := Severity(orDefault(p.FailedAt, DefaultPhasePolicy.FailedAt))
+	degraded := Severity(orDefault(p.DegradedAt, DefaultPhasePolicy.DegradedAt))
+	noop := Severity(orDefault(p.NoOpAt, DefaultPhasePolicy.NoOpAt))
+	var (
+		found bool
+		max   int
+	)
+	for mtype, m := range a {
+		if len(m.nonNil()) == 0 {
+			continue
+		}
+		if s := Severity(mtype); !found || s > max {
+			max = s
+		}
+		found = true
+	}
+	switch {
+	case !found:
+		return UnknownPhase
+	case max >= failed:
+		return FailedPhase
+	case max >= degraded:
+		return DegradedPhase
+	case max <= noop:
+		return NoOpPhase
+	default:
+		return HealthyPhase
+	}
+}
+
+// Phase returns the phase derived from the messages as per
+// DefaultPhasePolicy
+func (a AllMsgs) Phase() Phase {
+	return a.PhaseWithPolicy(DefaultPhasePolicy)
+}
+
+// PhaseWithPolicy returns the phase derived from the messages as per
+// the provided policy
+func (m Msgs) PhaseWithPolicy(p PhasePolicy) Phase {
+	return m.AllMsgs().PhaseWithPolicy(p)
+}
+
+// Phase returns the phase derived from the messages as per
+// DefaultPhasePolicy
+func (m Msgs) Phase() Phase {
+	return m.AllMsgs().Phase()
+}
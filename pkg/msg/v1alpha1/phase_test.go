@@ -0,0 +1,93 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func TestAllMsgsPhase(t *testing.T) {
+	types := []MsgType{ErrMsg, WarnMsg, DeprecationMsg, InfoMsg, SkipMsg}
+	custom := PhasePolicy{DegradedAt: DeprecationMsg, NoOpAt: InfoMsg}
+
+	// expected phases for every combination of the presence of the
+	// buckets of types where bit i is set if types[i] is present
+	expected := func(combination int, p PhasePolicy) Phase {
+		has := func(i int) bool { return combination&(1<<uint(i)) != 0 }
+		switch {
+		case combination == 0:
+			return UnknownPhase
+		case has(0):
+			return FailedPhase
+		case has(1), p == custom && has(2):
+			return DegradedPhase
+		case !has(2) && !has(3), p == custom && !has(2):
+			return NoOpPhase
+		default:
+			return HealthyPhase
+		}
+	}
+
+	for combination := 0; combination < 1<<uint(len(types)); combination++ {
+		a := AllMsgs{InfoMsg: {}, ErrMsg: {Items: []*msg{nil}}}
+		for i, mtype := range types {
+			if combination&(1<<uint(i)) != 0 {
+				a[mtype] = mockMsgsFromType([]MsgType{mtype})
+			}
+		}
+		for name, p := range map[string]PhasePolicy{"default": DefaultPhasePolicy, "custom": custom} {
+			if actual := a.PhaseWithPolicy(p); actual != expected(combination, p) {
+				t.Fatalf("Test '%d/%s' failed: expected '%s': actual '%s'", combination, name, expected(combination, p), actual)
+			}
+		}
+		if a.Phase() != expected(combination, DefaultPhasePolicy) || a.ToMsgs().Phase() != a.Phase() {
+			t.Fatalf("Test '%d' failed: expected '%s': actual '%s' '%s'", combination, a.Phase(), a.Phase(), a.ToMsgs().Phase())
+		}
+	}
+}
+
+func TestMsgsPhaseCustomTypes(t *testing.T) {
+	SetSeverity("test.phase.fatal", 60)
+	SetSeverity("test.phase.notice", 45)
+	SetSeverity("test.phase.trace", 5)
+
+	tests := map[string]struct {
+		types    []MsgType
+		policy   PhasePolicy
+		expected Phase
+	}{
+		"101": {[]MsgType{InfoMsg, "test.phase.fatal"}, DefaultPhasePolicy, FailedPhase},
+		"102": {[]MsgType{InfoMsg, "test.phase.notice"}, DefaultPhasePolicy, DegradedPhase},
+		"103": {[]MsgType{"test.phase.trace"}, DefaultPhasePolicy, NoOpPhase},
+		"104": {[]MsgType{"test.phase.trace", SkipMsg}, DefaultPhasePolicy, NoOpPhase},
+		"105": {[]MsgType{"test.phase.unknown"}, DefaultPhasePolicy, HealthyPhase},
+		"106": {[]MsgType{ProgressMsg, SkipMsg}, DefaultPhasePolicy, HealthyPhase},
+		"107": {[]MsgType{"test.phase.notice"}, PhasePolicy{FailedAt: "test.phase.notice"}, FailedPhase},
+		"108": {[]MsgType{ErrMsg}, PhasePolicy{FailedAt: "test.phase.fatal"}, DegradedPhase},
+		"109": {[]MsgType{}, DefaultPhasePolicy, UnknownPhase},
+		"110": {[]MsgType{}, PhasePolicy{}, UnknownPhase},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := mockMsgsFromType(mock.types)
+			if actual := m.PhaseWithPolicy(mock.policy); actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sync"
+)
+
+// maxPooledCap is the maximum capacity of the messages retained by
+// PutMsgs; larger lists are released to avoid pinning their storage
+const maxPooledCap = 1024
+
+// msgsPool holds the lists of messages released via PutMsgs
+var msgsPool = sync.Pool{
+	New: func() interface{} {
+		return &Msgs{}
+	},
+}
+
+// GetMsgs returns an empty list of messages from a pool. It is same as
+// &Msgs{} apart from possibly reusing the storage of a list released
+// via PutMsgs.
+func GetMsgs() *Msgs {
+	return msgsPool.Get().(*Msgs)
+}
+
+// PutMsgs resets the provided list of messages along with its options,
+// hooks and limit and releases it to the pool used by GetMsgs. The
+// checkpoints taken so far are invalidated. Neither
+// the list nor the messages it held must be used after the call; use
+// Clone to retain any of them.
+//
+// Messages themselves are not pooled since they are shared by the lists
+// derived via Filter, Merge and the like.
+func PutMsgs(m *Msgs) {
+	if m == nil {
+		return
+	}
+	items := m.Items
+	for i := range items {
+		items[i] = nil
+	}
+	if cap(items) > maxPooledCap {
+		items = nil
+	}
+	*m = Msgs{Items: items[:0:cap(items)], epoch: m.epoch + 1}
+	msgsPool.Put(m)
+}
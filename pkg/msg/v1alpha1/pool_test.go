@@ -0,0 +1,106 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestPutMsgs(t *testing.T) {
+	m := GetMsgs()
+	var fired int
+	m.WithLimit(2, DropOldest).WithSource("pool").OnAdd(func(MsgType, string, error) { fired++ })
+	m.AddInfo("i1").AddWarn("w1").AddError(errors.New("e1"))
+	if len(m.String()) == 0 {
+		t.Fatalf("Test failed: expected rendered messages")
+	}
+	backing := m.Items[:cap(m.Items)]
+	PutMsgs(m)
+	for i, item := range backing {
+		if item != nil {
+			t.Fatalf("Test failed: expected released item %d to be cleared: actual '%s'", i, item)
+		}
+	}
+
+	if len(m.Items) != 0 || m.String() != (&Msgs{}).String() || m.Dropped() != 0 {
+		t.Fatalf("Test failed: expected a released list without items or cached string: actual '%s'", m)
+	}
+	// the pool may or may not hand out the released instance
+	r := GetMsgs()
+	if len(r.Items) != 0 || r.String() != (&Msgs{}).String() {
+		t.Fatalf("Test failed: expected a recycled list without items or cached string: actual '%s'", r)
+	}
+	r.AddInfo("i2").AddWarn("w2").AddInfo("i3")
+	if descs(r) != "i2,w2,i3" || r.Items[0].Source != "" {
+		t.Fatalf("Test failed: expected a recycled list without options: actual '%s'", r)
+	}
+	if b, _ := json.Marshal(r); string(b) != `{"items":[{"type":"info","desc":"i2"},{"type":"warn","desc":"w2"},{"type":"info","desc":"i3"}]}` {
+		t.Fatalf("Test failed: expected recycled list to marshal as a fresh one: actual '%s'", string(b))
+	}
+	if fired != 3 {
+		t.Fatalf("Test failed: expected hooks to be cleared: actual %d invocations", fired)
+	}
+	PutMsgs(nil)
+}
+
+func TestPutMsgsInvalidatesCheckpoints(t *testing.T) {
+	m := GetMsgs().AddInfo("i1").AddInfo("i2")
+	stale := m.Checkpoint()
+	PutMsgs(m)
+	// m stands for the released instance as handed out by a later GetMsgs
+	m.AddInfo("i3").AddWarn("w3").AddInfo("i4")
+	if since := m.SinceCheckpoint(stale); descs(&since) != "i3,w3,i4" {
+		t.Fatalf("Test failed: expected every message since a stale checkpoint: actual '%s'", descs(&since))
+	}
+}
+
+func TestPutMsgsReleasesLargeStorage(t *testing.T) {
+	m := NewMsgs(WithCapacity(maxPooledCap + 1))
+	PutMsgs(m)
+	if m.Items != nil {
+		t.Fatalf("Test failed: expected large storage to be released: actual capacity %d", cap(m.Items))
+	}
+	m = NewMsgs(WithCapacity(8))
+	PutMsgs(m)
+	if cap(m.Items) != 8 || len(m.Items) != 0 {
+		t.Fatalf("Test failed: expected storage to be retained: actual capacity %d", cap(m.Items))
+	}
+}
+
+func BenchmarkGetMsgs(b *testing.B) {
+	reconcile := func(m *Msgs) {
+		for i := 0; i < 32; i++ {
+			m.AddInfo("volume is healthy")
+		}
+	}
+	b.Run("New", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			reconcile(&Msgs{})
+		}
+	})
+	b.Run("Pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m := GetMsgs()
+			reconcile(m)
+			PutMsgs(m)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sort"
+)
+
+// AddInfoP appends a new InfoMsg with the provided priority and
+// description
+func (m *Msgs) AddInfoP(priority int, i string) (u *Msgs) {
+	m.mustNotBeNil("AddInfoP")
+	if len(i) == 0 {
+		return m
+	}
+	return m.add(m.newMsg(msg{Mtype: InfoMsg, Desc: i, Priority: priority}))
+}
+
+// AddWarnP appends a new WarnMsg with the provided priority and
+// description
+func (m *Msgs) AddWarnP(priority int, w string) (u *Msgs) {
+	m.mustNotBeNil("AddWarnP")
+	if len(w) == 0 {
+		return m
+	}
+	return m.add(m.newMsg(msg{Mtype: WarnMsg, Desc: w, Priority: priority}))
+}
+
+// AddErrorP appends a new ErrMsg with the provided priority and error
+func (m *Msgs) AddErrorP(priority int, e error) (u *Msgs) {
+	m.mustNotBeNil("AddErrorP")
+	if e == nil {
+		return m
+	}
+	return m.add(m.newMsg(msg{Mtype: ErrMsg, Desc: e.Error(), Err: e, Priority: priority}))
+}
+
+// PriorityAtLeast returns a predicate that is true for messages whose
+// priority is at or above the provided one
+func PriorityAtLeast(p int) msgPredicate {
+	return func(given *msg) bool {
+		return given != nil && given.Priority >= p
+	}
+}
+
+// TopN returns at most n non nil messages of the highest priority.
+// Messages of the same priority are ordered by the severity of their
+// type followed by the order they were added in. Messages are shared
+// with the receiver.
+func (m Msgs) TopN(n int) (f Msgs) {
+	if n <= 0 {
+		return
+	}
+	f = m.Filter(func(*msg) bool { return true })
+	sort.SliceStable(f.Items, func(i, j int) bool {
+		a, b := f.Items[i], f.Items[j]
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		return Severity(a.Mtype) > Severity(b.Mtype)
+	})
+	if len(f.Items) > n {
+		f.Items = f.Items[:n:n]
+	}
+	return
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMsgsTopN(t *testing.T) {
+	m := (&Msgs{}).AddWarnP(5, "w5").AddWarn("w0").AddInfoP(5, "i5").AddErrorP(5, errors.New("e5")).
+		AddWarnP(-1, "w-1").AddWarnP(9, "w9").AddInfo("i0").AddWarnP(5, "w5b").AddError(errors.New("e0"))
+	m.Items = append(m.Items, nil)
+	tests := map[string]struct {
+		n        int
+		expected string
+	}{
+		"101": {1, "w9"},
+		"102": {3, "w9,e5,w5"},
+		"103": {5, "w9,e5,w5,w5b,i5"},
+		"104": {8, "w9,e5,w5,w5b,i5,e0,w0,i0"},
+		"105": {20, "w9,e5,w5,w5b,i5,e0,w0,i0,w-1"},
+		"106": {0, ""},
+		"107": {-1, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			before := strings.Join(m.Descriptions(), ",")
+			f := m.TopN(mock.n)
+			if descs(&f) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(&f))
+			}
+			if after := strings.Join(m.Descriptions(), ","); after != before {
+				t.Fatalf("Test '%s' failed: expected messages to be unchanged: actual '%s'", name, after)
+			}
+		})
+	}
+}
+
+func TestPriorityAtLeast(t *testing.T) {
+	m := (&Msgs{}).AddWarnP(5, "w5").AddWarn("w0").AddInfoP(-2, "i-2").AddErrorP(1, errors.New("e1"))
+	tests := map[string]struct {
+		p        int
+		expected string
+	}{
+		"101": {1, "w5,e1"},
+		"102": {0, "w5,w0,e1"},
+		"103": {-2, "w5,w0,i-2,e1"},
+		"104": {6, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := m.Filter(PriorityAtLeast(mock.p))
+			if descs(&f) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(&f))
+			}
+		})
+	}
+}
+
+func TestMsgsPrioritySerialization(t *testing.T) {
+	m := (&Msgs{}).AddWarnP(5, "w5").AddInfo("i0")
+	if m.GoString() != `(&v1alpha1.Msgs{}).AddWarnP(5, "w5").AddInfo("i0")` {
+		t.Fatalf("Test failed: expected priority in go syntax: actual '%s'", m.GoString())
+	}
+	for name, roundTrip := range mockRoundTrips() {
+		actual, err := roundTrip(m)
+		if err != nil || !m.EqualStrict(*actual) {
+			t.Fatalf("Test '%s' failed: expected priority to round trip: actual '%v' '%v'", name, actual, err)
+		}
+	}
+}
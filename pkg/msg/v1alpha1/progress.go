@@ -0,0 +1,62 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"math"
+)
+
+// IsProgress returns true if given message is of type ProgressMsg
+func IsProgress(given *msg) (ok bool) {
+	if given == nil {
+		return
+	}
+	return given.Mtype == ProgressMsg
+}
+
+// AddProgress appends a new ProgressMsg to messages and initializes
+// it with the passed description and percent. The percent is clamped
+// to the range [0, 100].
+func (m *Msgs) AddProgress(p string, pct float64) (u *Msgs) {
+	m.mustNotBeNil("AddProgress")
+	if len(p) == 0 {
+		return m
+	}
+	switch {
+	case math.IsNaN(pct) || pct < 0:
+		pct = 0
+	case pct > 100:
+		pct = 100
+	}
+	return m.add(m.newMsg(msg{Mtype: ProgressMsg, Desc: p, Percent: pct}))
+}
+
+// Progresses filters ProgressMsg messages
+func (m Msgs) Progresses() (f Msgs) {
+	return m.Filter(IsProgress)
+}
+
+// LatestProgress returns the description and percent of the most
+// recently added ProgressMsg
+func (m Msgs) LatestProgress() (desc string, pct float64, ok bool) {
+	for i := len(m.Items) - 1; i >= 0; i-- {
+		if IsProgress(m.Items[i]) {
+			return m.Items[i].Description(), m.Items[i].Percent, true
+		}
+	}
+	return
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestMsgsAddProgress(t *testing.T) {
+	tests := map[string]struct {
+		pct      float64
+		expected float64
+	}{
+		"101": {-5, 0},
+		"102": {0, 0},
+		"103": {42.5, 42.5},
+		"104": {100, 100},
+		"105": {250, 100},
+		"106": {math.NaN(), 0},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := (&Msgs{}).AddProgress("migrate", mock.pct)
+			if len(m.Progresses().Items) != 1 {
+				t.Fatalf("Test '%s' failed: expected 1 progress: actual %d", name, len(m.Progresses().Items))
+			}
+			if m.Items[0].Percent != mock.expected {
+				t.Fatalf("Test '%s' failed: expected percent %v: actual %v", name, mock.expected, m.Items[0].Percent)
+			}
+		})
+	}
+}
+
+func TestMsgsLatestProgress(t *testing.T) {
+	tests := map[string]struct {
+		msgs         *Msgs
+		expectedDesc string
+		expectedPct  float64
+		expectedOk   bool
+	}{
+		"101": {(&Msgs{}).AddProgress("copy", 10).AddInfo("i1").AddProgress("copy", 20).AddWarn("w1"), "copy", 20, true},
+		"102": {(&Msgs{}).AddProgress("copy", 100).AddProgress("verify", 5), "verify", 5, true},
+		"103": {(&Msgs{}).AddInfo("i1"), "", 0, false},
+		"104": {&Msgs{Items: []*msg{&msg{Mtype: ProgressMsg, Desc: "copy", Percent: 1}, nil}}, "copy", 1, true},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc, pct, ok := mock.msgs.LatestProgress()
+			if desc != mock.expectedDesc || pct != mock.expectedPct || ok != mock.expectedOk {
+				t.Fatalf("Test '%s' failed: expected '%s' %v %t: actual '%s' %v %t",
+					name, mock.expectedDesc, mock.expectedPct, mock.expectedOk, desc, pct, ok)
+			}
+		})
+	}
+}
+
+func TestMsgsProgressCompact(t *testing.T) {
+	m := (&Msgs{}).WithLimit(2, CompactDuplicates).AddInfo("i1").AddProgress("copy", 10)
+	m.AddProgress("copy", 20).AddProgress("copy", 30)
+	if f := m.Progresses(); len(f.Items) != 1 || f.Items[0].Percent != 30 {
+		t.Fatalf("Test failed: expected only latest progress to be kept: actual '%s'", f)
+	}
+	if !IsInfo(m.Items[1]) {
+		t.Fatalf("Test failed: expected info to be retained: actual '%s'", m)
+	}
+}
+
+func TestMsgsProgressYaml(t *testing.T) {
+	m := (&Msgs{}).AddProgress("copy", 0).AddProgress("verify", 42.5).AddInfo("i1")
+	s := m.String()
+	if strings.Count(s, "percent:") != 2 || !strings.Contains(s, "percent: 0\n") || !strings.Contains(s, "percent: 42.5\n") {
+		t.Fatalf("Test failed: expected percent of progress messages only: actual '%s'", s)
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	var actual Msgs
+	if err := json.Unmarshal(b, &actual); err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	if desc, pct, _ := actual.LatestProgress(); desc != "verify" || pct != 42.5 {
+		t.Fatalf("Test failed: expected percent to survive round trip: actual '%s' %v", desc, pct)
+	}
+}
@@ -0,0 +1,243 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: msgs.proto
+
+package proto
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type MsgProto struct {
+	Type                 string               `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Desc                 string               `protobuf:"bytes,2,opt,name=desc" json:"desc,omitempty"`
+	Error                string               `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=timestamp" json:"timestamp,omitempty"`
+	Code                 string               `protobuf:"bytes,5,opt,name=code" json:"code,omitempty"`
+	Source               string               `protobuf:"bytes,6,opt,name=source" json:"source,omitempty"`
+	Labels               map[string]string    `protobuf:"bytes,7,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Percent              float64              `protobuf:"fixed64,8,opt,name=percent" json:"percent,omitempty"`
+	Id                   string               `protobuf:"bytes,9,opt,name=id" json:"id,omitempty"`
+	ErrCode              string               `protobuf:"bytes,10,opt,name=err_code,json=errCode" json:"err_code,omitempty"`
+	Reason               string               `protobuf:"bytes,11,opt,name=reason" json:"reason,omitempty"`
+	Priority             int64                `protobuf:"varint,12,opt,name=priority" json:"priority,omitempty"`
+	Category             string               `protobuf:"bytes,13,opt,name=category" json:"category,omitempty"`
+	Fields               []byte               `protobuf:"bytes,14,opt,name=fields,proto3" json:"fields,omitempty"`
+	Object               string               `protobuf:"bytes,15,opt,name=object" json:"object,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *MsgProto) Reset()         { *m = MsgProto{} }
+func (m *MsgProto) String() string { return proto.CompactTextString(m) }
+func (*MsgProto) ProtoMessage()    {}
+func (*MsgProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor_msgs_7a0052eadda5949f, []int{0}
+}
+func (m *MsgProto) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MsgProto.Unmarshal(m, b)
+}
+func (m *MsgProto) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MsgProto.Marshal(b, m, deterministic)
+}
+func (dst *MsgProto) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgProto.Merge(dst, src)
+}
+func (m *MsgProto) XXX_Size() int {
+	return xxx_messageInfo_MsgProto.Size(m)
+}
+func (m *MsgProto) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgProto.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgProto proto.InternalMessageInfo
+
+func (m *MsgProto) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *MsgProto) GetDesc() string {
+	if m != nil {
+		return m.Desc
+	}
+	return ""
+}
+
+func (m *MsgProto) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *MsgProto) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *MsgProto) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *MsgProto) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *MsgProto) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *MsgProto) GetPercent() float64 {
+	if m != nil {
+		return m.Percent
+	}
+	return 0
+}
+
+func (m *MsgProto) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *MsgProto) GetErrCode() string {
+	if m != nil {
+		return m.ErrCode
+	}
+	return ""
+}
+
+func (m *MsgProto) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *MsgProto) GetPriority() int64 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *MsgProto) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+func (m *MsgProto) GetFields() []byte {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *MsgProto) GetObject() string {
+	if m != nil {
+		return m.Object
+	}
+	return ""
+}
+
+type MsgsProto struct {
+	Items                []*MsgProto `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *MsgsProto) Reset()         { *m = MsgsProto{} }
+func (m *MsgsProto) String() string { return proto.CompactTextString(m) }
+func (*MsgsProto) ProtoMessage()    {}
+func (*MsgsProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor_msgs_7a0052eadda5949f, []int{1}
+}
+func (m *MsgsProto) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MsgsProto.Unmarshal(m, b)
+}
+func (m *MsgsProto) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MsgsProto.Marshal(b, m, deterministic)
+}
+func (dst *MsgsProto) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgsProto.Merge(dst, src)
+}
+func (m *MsgsProto) XXX_Size() int {
+	return xxx_messageInfo_MsgsProto.Size(m)
+}
+func (m *MsgsProto) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgsProto.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgsProto proto.InternalMessageInfo
+
+func (m *MsgsProto) GetItems() []*MsgProto {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*MsgProto)(nil), "proto.MsgProto")
+	proto.RegisterMapType((map[string]string)(nil), "proto.MsgProto.LabelsEntry")
+	proto.RegisterType((*MsgsProto)(nil), "proto.MsgsProto")
+}
+
+func init() { proto.RegisterFile("msgs.proto", fileDescriptor_msgs_7a0052eadda5949f) }
+
+var fileDescriptor_msgs_7a0052eadda5949f = []byte{
+	// 364 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x91, 0x4f, 0xcb, 0xd3, 0x40,
+	0x10, 0xc6, 0xd9, 0xe4, 0x4d, 0x9a, 0x4c, 0x5e, 0x5b, 0x59, 0x44, 0xc6, 0x78, 0x30, 0x14, 0x84,
+	0x9c, 0x52, 0x68, 0x2f, 0xd5, 0xab, 0x78, 0xb3, 0x20, 0xc1, 0xbb, 0xe4, 0xcf, 0x34, 0x44, 0x93,
+	0x6e, 0xd8, 0xdd, 0x0a, 0xf9, 0xbc, 0x7e, 0x11, 0xd9, 0xdd, 0xa4, 0x8a, 0xa7, 0x9d, 0xdf, 0x33,
+	0xb3, 0xf3, 0xcc, 0xee, 0x00, 0x8c, 0xaa, 0x53, 0xc5, 0x24, 0x85, 0x16, 0x3c, 0xb0, 0x47, 0xfa,
+	0xae, 0x13, 0xa2, 0x1b, 0xe8, 0x60, 0xa9, 0xbe, 0x5f, 0x0f, 0xba, 0x1f, 0x49, 0xe9, 0x6a, 0x9c,
+	0x5c, 0xdd, 0xfe, 0xb7, 0x0f, 0xd1, 0x45, 0x75, 0x5f, 0xed, 0x25, 0x0e, 0x4f, 0x7a, 0x9e, 0x08,
+	0x59, 0xc6, 0xf2, 0xb8, 0xb4, 0xb1, 0xd1, 0x5a, 0x52, 0x0d, 0x7a, 0x4e, 0x33, 0x31, 0x7f, 0x05,
+	0x01, 0x49, 0x29, 0x24, 0xfa, 0x56, 0x74, 0xc0, 0xcf, 0x10, 0x3f, 0xba, 0xe3, 0x53, 0xc6, 0xf2,
+	0xe4, 0x98, 0x16, 0xce, 0xbf, 0x58, 0xfd, 0x8b, 0x6f, 0x6b, 0x45, 0xf9, 0xb7, 0xd8, 0x78, 0x34,
+	0xa2, 0x25, 0x0c, 0x9c, 0x87, 0x89, 0xf9, 0x6b, 0x08, 0x95, 0xb8, 0xcb, 0x86, 0x30, 0xb4, 0xea,
+	0x42, 0xfc, 0x04, 0xe1, 0x50, 0xd5, 0x34, 0x28, 0xdc, 0x64, 0x7e, 0x9e, 0x1c, 0xdf, 0xba, 0xde,
+	0xc5, 0xfa, 0x88, 0xe2, 0x8b, 0xcd, 0x7e, 0xbe, 0x69, 0x39, 0x97, 0x4b, 0x29, 0x47, 0xd8, 0x4c,
+	0x24, 0x1b, 0xba, 0x69, 0x8c, 0x32, 0x96, 0xb3, 0x72, 0x45, 0xbe, 0x05, 0xaf, 0x6f, 0x31, 0xb6,
+	0x16, 0x5e, 0xdf, 0xf2, 0x37, 0x10, 0x91, 0x94, 0xdf, 0xed, 0x38, 0x60, 0xd5, 0x0d, 0x49, 0xf9,
+	0x69, 0x99, 0x48, 0x52, 0xa5, 0xc4, 0x0d, 0x13, 0x37, 0x91, 0x23, 0x9e, 0x42, 0x34, 0xc9, 0x5e,
+	0xc8, 0x5e, 0xcf, 0xf8, 0x9c, 0xb1, 0xdc, 0x2f, 0x1f, 0x6c, 0x72, 0x4d, 0xa5, 0xa9, 0x13, 0x72,
+	0xc6, 0x17, 0xf6, 0xd6, 0x83, 0x4d, 0xbf, 0x6b, 0x4f, 0x43, 0xab, 0x70, 0x9b, 0xb1, 0xfc, 0xb9,
+	0x5c, 0xc8, 0xe8, 0xa2, 0xfe, 0x41, 0x8d, 0xc6, 0x9d, 0xf3, 0x71, 0x94, 0x7e, 0x80, 0xe4, 0x9f,
+	0xb7, 0xf1, 0x97, 0xe0, 0xff, 0xa4, 0x79, 0xd9, 0x95, 0x09, 0xcd, 0x5a, 0x7e, 0x55, 0xc3, 0x9d,
+	0x96, 0x5d, 0x39, 0xf8, 0xe8, 0x9d, 0xd9, 0xfe, 0x08, 0xf1, 0x45, 0x75, 0xca, 0x6d, 0xf9, 0x3d,
+	0x04, 0xbd, 0xa6, 0x51, 0x21, 0xb3, 0x1f, 0xb8, 0xfb, 0xef, 0x03, 0x4b, 0x97, 0xad, 0x43, 0x2b,
+	0x9f, 0xfe, 0x0c, 0x00, 0xe4, 0x96, 0x3a, 0xd2, 0x56, 0x02, 0x00, 0x00,
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	msgproto "github.com/openebs/maya/pkg/msg/v1alpha1/proto"
+)
+
+// ToProto returns the non nil messages as their protobuf
+// representation. Errors are flattened to their string form.
+func (m Msgs) ToProto() *msgproto.MsgsProto {
+	p := &msgproto.MsgsProto{}
+	for _, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		item = item.forOutput()
+		i := &msgproto.MsgProto{
+			Type:     string(item.Mtype),
+			Desc:     item.Description(),
+			Code:     item.Code,
+			Source:   item.Source,
+			Labels:   mergeLabels(nil, item.Labels),
+			Percent:  item.Percent,
+			Id:       item.ID,
+			Reason:   string(item.Reason),
+			Priority: int64(item.Priority),
+			Category: string(item.Category),
+			Fields:   encodeFields(item.Fields),
+			Object:   objectOf(item).String(),
+		}
+		if item.Err != nil {
+			i.Error, i.ErrCode = item.Err.Error(), item.ErrCode
+		}
+		if item.Time != nil {
+			i.Timestamp, _ = ptypes.TimestampProto(*item.Time)
+		}
+		p.Items = append(p.Items, i)
+	}
+	return p
+}
+
+// MsgsFromProto returns the messages represented by the provided
+// protobuf. Unknown message types are preserved as is, nil items are
+// skipped and errors are restored such that they wrap the error
+// registered against their code if any.
+func MsgsFromProto(p *msgproto.MsgsProto) (m *Msgs) {
+	m = &Msgs{}
+	for _, i := range p.GetItems() {
+		if i == nil {
+			continue
+		}
+		item := &msg{
+			Mtype:    MsgType(i.GetType()),
+			Desc:     i.GetDesc(),
+			Code:     i.GetCode(),
+			Source:   i.GetSource(),
+			Labels:   mergeLabels(nil, i.GetLabels()),
+			Percent:  i.GetPercent(),
+			ID:       i.GetId(),
+			Reason:   SkipReason(i.GetReason()),
+			Priority: int(i.GetPriority()),
+			Category: ErrorCategory(i.GetCategory()),
+			Fields:   decodeFields(i.GetFields()),
+			Object:   parseObjectPtr(i.GetObject()),
+			seq:      nextSeq(),
+		}
+		if len(i.GetError()) != 0 {
+			item.Err, item.ErrCode = restoreError(i.GetError(), i.GetErrCode()), i.GetErrCode()
+		}
+		if t, err := ptypes.Timestamp(i.GetTimestamp()); err == nil {
+			item.Time = &t
+		}
+		m.Items = append(m.Items, item)
+	}
+	m.touch()
+	return
+}
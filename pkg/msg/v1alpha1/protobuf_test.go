@@ -0,0 +1,134 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	msgproto "github.com/openebs/maya/pkg/msg/v1alpha1/proto"
+)
+
+// oldMsgProto mocks the MsgProto known to an older client that is
+// aware of the type and description only
+type oldMsgProto struct {
+	Type string `protobuf:"bytes,1,opt,name=type"`
+	Desc string `protobuf:"bytes,2,opt,name=desc"`
+}
+
+func (m *oldMsgProto) Reset()         { *m = oldMsgProto{} }
+func (m *oldMsgProto) String() string { return proto.CompactTextString(m) }
+func (*oldMsgProto) ProtoMessage()    {}
+
+// oldMsgsProto mocks the MsgsProto known to an older client
+type oldMsgsProto struct {
+	Items []*oldMsgProto `protobuf:"bytes,1,rep,name=items"`
+}
+
+func (m *oldMsgsProto) Reset()         { *m = oldMsgsProto{} }
+func (m *oldMsgsProto) String() string { return proto.CompactTextString(m) }
+func (*oldMsgsProto) ProtoMessage()    {}
+
+func TestMsgsProtoRoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		msgs     *Msgs
+		expected []string
+	}{
+		"101": {&Msgs{}, nil},
+		"102": {(&Msgs{}).AddInfo("i1").AddWarn("w1").AddSkip("s1").AddError(errors.New("e1")),
+			[]string{"info:i1:", "warn:w1:", "skip:s1:", "error:e1:e1"}},
+		"103": {&Msgs{Items: []*msg{nil, &msg{Mtype: "future", Desc: "f1"}, nil}},
+			[]string{"future:f1:"}},
+		"104": {(&Msgs{}).AddInfoLazy(func() string { return "lazy" }), []string{"info:lazy:"}},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, err := proto.Marshal(mock.msgs.ToProto())
+			if err != nil {
+				t.Fatalf("Test '%s' failed: expected no marshal error: actual '%s'", name, err)
+			}
+			p := &msgproto.MsgsProto{}
+			if err := proto.Unmarshal(b, p); err != nil {
+				t.Fatalf("Test '%s' failed: expected no unmarshal error: actual '%s'", name, err)
+			}
+			actual := MsgsFromProto(p)
+			if len(actual.Items) != len(mock.expected) {
+				t.Fatalf("Test '%s' failed: expected %d items: actual %d items", name, len(mock.expected), len(actual.Items))
+			}
+			for i, item := range actual.Items {
+				var errStr string
+				if item.Err != nil {
+					errStr = item.Err.Error()
+				}
+				if s := string(item.Mtype) + ":" + item.Desc + ":" + errStr; s != mock.expected[i] {
+					t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected[i], s)
+				}
+			}
+		})
+	}
+}
+
+func TestMsgsProtoOptionalFields(t *testing.T) {
+	m := NewMsgs(WithIDs()).WithSource("pool").WithLabels(map[string]string{"replica": "2"}).
+		AddErrorCode("PoolDown", errors.New("e1")).AddProgress("p1", 40)
+	ts := time.Date(2018, 6, 1, 10, 0, 0, 5, time.UTC)
+	m.Items[0].Time = &ts
+	b, err := proto.Marshal(m.ToProto())
+	if err != nil {
+		t.Fatalf("Test failed: expected no marshal error: actual '%s'", err)
+	}
+	p := &msgproto.MsgsProto{}
+	if err := proto.Unmarshal(b, p); err != nil {
+		t.Fatalf("Test failed: expected no unmarshal error: actual '%s'", err)
+	}
+	actual := MsgsFromProto(p)
+	if len(actual.Items) != 2 {
+		t.Fatalf("Test failed: expected 2 items: actual %d items", len(actual.Items))
+	}
+	e, pr := actual.Items[0], actual.Items[1]
+	if e.Code != "PoolDown" || e.Source != "pool" || e.Labels["replica"] != "2" {
+		t.Fatalf("Test failed: expected code, source and labels to round trip: actual '%#v'", e)
+	}
+	if e.ID != "msg-000001" || pr.ID != "msg-000002" {
+		t.Fatalf("Test failed: expected ids to round trip: actual '%s' '%s'", e.ID, pr.ID)
+	}
+	if e.Time == nil || !e.Time.Equal(ts) || pr.Time != nil {
+		t.Fatalf("Test failed: expected time to round trip: actual '%v' '%v'", e.Time, pr.Time)
+	}
+	if pr.Mtype != ProgressMsg || pr.Percent != 40 {
+		t.Fatalf("Test failed: expected progress of 40: actual '%#v'", pr)
+	}
+}
+
+func TestMsgsProtoOlderClient(t *testing.T) {
+	m := (&Msgs{}).WithSource("pool").AddErrorCode("PoolDown", errors.New("e1")).AddInfo("i1")
+	b, err := proto.Marshal(m.ToProto())
+	if err != nil {
+		t.Fatalf("Test failed: expected no marshal error: actual '%s'", err)
+	}
+	old := &oldMsgsProto{}
+	if err := proto.Unmarshal(b, old); err != nil {
+		t.Fatalf("Test failed: expected older client to ignore unknown fields: actual '%s'", err)
+	}
+	if len(old.Items) != 2 || old.Items[0].Type != "error" || old.Items[0].Desc != "e1" ||
+		old.Items[1].Type != "info" || old.Items[1].Desc != "i1" {
+		t.Fatalf("Test failed: expected known fields to be decoded: actual '%v'", old)
+	}
+}
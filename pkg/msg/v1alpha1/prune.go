@@ -0,0 +1,69 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+)
+
+// WithPruneUntimestamped sets whether PruneOlderThan and Recent drop the
+// messages without a timestamp. Such messages are kept by default.
+func (m *Msgs) WithPruneUntimestamped(prune bool) (u *Msgs) {
+	m.mustNotBeNil("WithPruneUntimestamped")
+	m.pruneUntimed = prune
+	return m
+}
+
+// WithPruneUntimestamped drops messages without a timestamp while
+// pruning as per Msgs.WithPruneUntimestamped
+func WithPruneUntimestamped() Option {
+	return func(m *Msgs) {
+		m.WithPruneUntimestamped(true)
+	}
+}
+
+// newerThan returns a predicate that is true for messages that were
+// last seen within the provided duration as per the configured clock.
+// The time a message was last merged via MergeCounted takes precedence
+// over the time it was added.
+func (m Msgs) newerThan(d time.Duration) msgPredicate {
+	cutoff := m.clock().Add(-d)
+	return func(given *msg) bool {
+		at := given.LastSeen
+		if at == nil {
+			at = given.Time
+		}
+		if at == nil {
+			return !m.pruneUntimed
+		}
+		return !at.Before(cutoff)
+	}
+}
+
+// PruneOlderThan removes the messages that are older than the provided
+// duration, see WithTimestamps. Messages without a timestamp are kept
+// unless WithPruneUntimestamped is set.
+func (m *Msgs) PruneOlderThan(d time.Duration) (u *Msgs) {
+	m.mustNotBeNil("PruneOlderThan")
+	return m.FilterInPlace(m.newerThan(d))
+}
+
+// Recent returns the messages that are not older than the provided
+// duration as in PruneOlderThan. The receiver is not modified.
+func (m Msgs) Recent(d time.Duration) (f Msgs) {
+	return m.Filter(m.newerThan(d))
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMsgsPruneOlderThan(t *testing.T) {
+	tests := map[string]struct {
+		advance      time.Duration
+		pruneUntimed bool
+		expected     string
+	}{
+		"101": {0, false, "u1,i2,i3"},
+		"102": {10 * time.Minute, false, "u1,i2,i3"},
+		"103": {11 * time.Minute, false, "u1,i3"},
+		"104": {30 * time.Minute, false, "u1,i3"},
+		"105": {31 * time.Minute, false, "u1"},
+		"106": {-10 * time.Minute, false, "u1,i1,i2,i3"},
+		"107": {-10 * time.Minute, true, "i1,i2,i3"},
+		"108": {11 * time.Minute, true, "i3"},
+		"109": {31 * time.Minute, true, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			now := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+			m := NewMsgs(WithClock(func() time.Time { return now }))
+			m.WithPruneUntimestamped(mock.pruneUntimed)
+			// u1 has no timestamp while i1, i2 and i3 are stamped at
+			// 10:00, 10:20 and 10:40 respectively
+			m.AddInfo("u1")
+			m.timestamps = true
+			for _, d := range []string{"i1", "i2", "i3"} {
+				m.AddInfo(d)
+				now = now.Add(20 * time.Minute)
+			}
+			// now is 10:40 advanced by the mock duration
+			now = now.Add(-20*time.Minute + mock.advance)
+			before := m.String()
+
+			recent := m.Recent(30 * time.Minute)
+			if descs(&recent) != mock.expected || m.String() != before {
+				t.Fatalf("Test '%s' failed: expected recent '%s' without modifying messages: actual '%s'", name, mock.expected, descs(&recent))
+			}
+			if m.PruneOlderThan(30*time.Minute) != m || descs(m) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(m))
+			}
+			if m.String() != recent.String() || m.Hash() != recent.Hash() {
+				t.Fatalf("Test '%s' failed: expected cached string and hash to be refreshed: actual '%s'", name, m)
+			}
+		})
+	}
+}
+
+func TestMsgsPruneOlderThanLastSeen(t *testing.T) {
+	clock := mockClock()
+	m := NewMsgs(WithClock(clock), WithTimestamps())
+	m.AddWarn("w1").AddInfo("i1")
+	// w1 is seen again at 10:03 while i1 was added at 10:02
+	m.MergeCounted((&Msgs{}).AddWarn("w1"))
+	clock()
+	clock()
+	// now is 10:06
+	m.PruneOlderThan(3 * time.Minute)
+	if descs(m) != "w1" {
+		t.Fatalf("Test failed: expected 'w1' seen within the duration: actual '%s'", descs(m))
+	}
+}
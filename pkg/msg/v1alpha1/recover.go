@@ -0,0 +1,100 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// maxStackFrames is the maximum number of stack frames included in the
+// description of a recovered panic
+const maxStackFrames = 10
+
+// Fatal is implemented by panic values that must not be swallowed.
+// Such panics are recorded and then panicked again.
+type Fatal interface {
+	Fatal()
+}
+
+// RecoverInto recovers from a panic and records it as an ErrMsg in the
+// provided messages. It is meant to be deferred directly i.e.
+// 'defer RecoverInto(msgs)'. The panic is swallowed unless the panic
+// value implements Fatal. A panic is recovered even if the provided
+// messages is nil, but is not recorded.
+func RecoverInto(m *Msgs) {
+	if r := recover(); r != nil {
+		m.recovered(r)
+	}
+}
+
+// Run runs the provided function and records the returned error as an
+// ErrMsg. A panic in the function is recovered and recorded similar to
+// RecoverInto.
+func (m *Msgs) Run(fn func() error) (u *Msgs) {
+	u = m
+	if fn == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			m.recovered(r)
+		}
+	}()
+	if err := fn(); err != nil && m != nil {
+		m.AddError(err)
+	}
+	return
+}
+
+// recovered records the provided panic value as an ErrMsg along with a
+// trimmed stack trace
+func (m *Msgs) recovered(r interface{}) {
+	if m != nil {
+		var err error
+		if e, ok := r.(error); ok {
+			err = fmt.Errorf("recovered from panic: %w", e)
+		} else {
+			err = fmt.Errorf("recovered from panic: %v", r)
+		}
+		m.add(m.newMsg(msg{Mtype: ErrMsg, Desc: err.Error() + "\n" + trimmedStack(), Err: err}))
+	}
+	if _, ok := r.(Fatal); ok {
+		panic(r)
+	}
+}
+
+// trimmedStack returns the stack trace of the panicking goroutine
+// starting at the frame that panicked
+func trimmedStack() string {
+	lines := strings.Split(strings.TrimSpace(string(debug.Stack())), "\n")
+	// each frame is a function line followed by a file line; skip past
+	// the frames of the panic itself
+	start := 1
+	for i := 1; i+1 < len(lines); i += 2 {
+		if strings.HasPrefix(lines[i], "panic(") {
+			start = i + 2
+			break
+		}
+	}
+	lines = lines[start:]
+	if len(lines) > 2*maxStackFrames {
+		lines = lines[:2*maxStackFrames]
+	}
+	return strings.Join(lines, "\n")
+}
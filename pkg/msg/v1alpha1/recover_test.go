@@ -0,0 +1,128 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var errMockPanic = errors.New("mock panic")
+
+type mockFatal string
+
+func (mockFatal) Fatal() {}
+
+func TestRecoverInto(t *testing.T) {
+	tests := map[string]struct {
+		value       interface{}
+		expectedErr string
+	}{
+		"101": {errMockPanic, "recovered from panic: mock panic"},
+		"102": {"boom", "recovered from panic: boom"},
+		"103": {42, "recovered from panic: 42"},
+		"104": {struct{ Code int }{7}, "recovered from panic: {7}"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := &Msgs{}
+			func() {
+				defer RecoverInto(m)
+				panic(mock.value)
+			}()
+			errs := m.Errors()
+			if len(errs.Items) != 1 {
+				t.Fatalf("Test '%s' failed: expected 1 error: actual %d", name, len(errs.Items))
+			}
+			e := errs.Items[0]
+			if e.Err.Error() != mock.expectedErr {
+				t.Fatalf("Test '%s' failed: expected error '%s': actual '%s'", name, mock.expectedErr, e.Err)
+			}
+			if !strings.HasPrefix(e.Desc, mock.expectedErr+"\n") || !strings.Contains(e.Desc, "TestRecoverInto") {
+				t.Fatalf("Test '%s' failed: expected description with stack trace: actual '%s'", name, e.Desc)
+			}
+			if strings.Contains(e.Desc, "runtime/debug.Stack") {
+				t.Fatalf("Test '%s' failed: expected trimmed stack trace: actual '%s'", name, e.Desc)
+			}
+		})
+	}
+}
+
+func TestRecoverIntoErrorChain(t *testing.T) {
+	m := &Msgs{}
+	func() {
+		defer RecoverInto(m)
+		panic(errMockPanic)
+	}()
+	if !errors.Is(m.Items[0].Err, errMockPanic) {
+		t.Fatalf("Test failed: expected recovered error to wrap the panic value: actual '%s'", m.Items[0].Err)
+	}
+}
+
+func TestRecoverIntoNilMsgs(t *testing.T) {
+	func() {
+		defer RecoverInto(nil)
+		panic("boom")
+	}()
+	var m *Msgs
+	if u := m.Run(func() error { panic("boom") }); u != nil {
+		t.Fatalf("Test failed: expected nil messages: actual '%v'", u)
+	}
+}
+
+func TestRecoverIntoFatal(t *testing.T) {
+	m := &Msgs{}
+	defer func() {
+		if r := recover(); r != mockFatal("fatal") {
+			t.Fatalf("Test failed: expected fatal panic to propagate: actual '%v'", r)
+		}
+		if len(m.Errors().Items) != 1 {
+			t.Fatalf("Test failed: expected fatal panic to be recorded: actual %d errors", len(m.Errors().Items))
+		}
+	}()
+	defer RecoverInto(m)
+	panic(mockFatal("fatal"))
+}
+
+func TestMsgsRun(t *testing.T) {
+	tests := map[string]struct {
+		fn             func() error
+		expectedErrors int
+		expectedErr    string
+	}{
+		"101": {func() error { return nil }, 0, ""},
+		"102": {func() error { return errMockPanic }, 1, "mock panic"},
+		"103": {func() error { panic("boom") }, 1, "recovered from panic: boom"},
+		"104": {func() error { panic(errMockPanic) }, 1, "recovered from panic: mock panic"},
+		"105": {nil, 0, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := (&Msgs{}).Run(mock.fn)
+			errs := m.Errors()
+			if len(errs.Items) != mock.expectedErrors {
+				t.Fatalf("Test '%s' failed: expected errors %d: actual %d", name, mock.expectedErrors, len(errs.Items))
+			}
+			if mock.expectedErrors != 0 && errs.Items[0].Err.Error() != mock.expectedErr {
+				t.Fatalf("Test '%s' failed: expected error '%s': actual '%s'", name, mock.expectedErr, errs.Items[0].Err)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"regexp"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Redacted replaces the sensitive content of messages
+const Redacted = "[REDACTED]"
+
+var (
+	redactionsMu sync.RWMutex
+	// redactions holds the patterns of sensitive content applied by
+	// auto redaction and by Redact when invoked without patterns
+	redactions []*regexp.Regexp
+
+	// autoRedactionFlag is set if messages are redacted when rendered
+	autoRedactionFlag int32
+
+	// redactionGen changes whenever auto redaction changes such that
+	// renderings cached earlier are invalidated
+	redactionGen uint64
+)
+
+// RegisterRedaction registers the provided pattern of sensitive content
+// e.g. a connection string or a token, see EnableAutoRedaction
+func RegisterRedaction(re *regexp.Regexp) {
+	if re == nil {
+		return
+	}
+	redactionsMu.Lock()
+	defer redactionsMu.Unlock()
+	redactions = append(redactions, re)
+	atomic.AddUint64(&redactionGen, 1)
+}
+
+// registeredRedactions returns the registered patterns
+func registeredRedactions() []*regexp.Regexp {
+	redactionsMu.RLock()
+	defer redactionsMu.RUnlock()
+	return redactions
+}
+
+// EnableAutoRedaction redacts the registered patterns whenever messages
+// are rendered e.g. via String, as json or via the binary formats. The
+// stored messages are not changed; Contains and Filter still see the
+// original content.
+func EnableAutoRedaction() {
+	atomic.StoreInt32(&autoRedactionFlag, 1)
+	atomic.AddUint64(&redactionGen, 1)
+}
+
+// DisableAutoRedaction stops redacting messages when they are rendered
+func DisableAutoRedaction() {
+	atomic.StoreInt32(&autoRedactionFlag, 0)
+	atomic.AddUint64(&redactionGen, 1)
+}
+
+// autoRedactions returns the patterns to be redacted when messages are
+// rendered or nil if auto redaction is not enabled
+func autoRedactions() []*regexp.Regexp {
+	if atomic.LoadInt32(&autoRedactionFlag) != 1 {
+		return nil
+	}
+	return registeredRedactions()
+}
+
+// redactString replaces every match of the provided patterns in the
+// provided string. Matches of different patterns that overlap or touch
+// are replaced once as a whole.
+func redactString(s string, patterns []*regexp.Regexp) string {
+	var matches [][]int
+	for _, re := range patterns {
+		matches = append(matches, re.FindAllStringIndex(s, -1)...)
+	}
+	if len(matches) == 0 {
+		return s
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i][0] < matches[j][0] })
+	var (
+		b    []byte
+		last int
+	)
+	for i := 0; i < len(matches); i++ {
+		start, end := matches[i][0], matches[i][1]
+		for i+1 < len(matches) && matches[i+1][0] <= end {
+			i++
+			if matches[i][1] > end {
+				end = matches[i][1]
+			}
+		}
+		if start == end {
+			continue
+		}
+		b = append(b, s[last:start]...)
+		b = append(b, Redacted...)
+		last = end
+	}
+	return string(append(b, s[last:]...))
+}
+
+// redactValue returns the provided field value with every string it
+// holds redacted. Values other than strings and their slices and maps
+// are returned as is.
+func redactValue(v interface{}, patterns []*regexp.Regexp) interface{} {
+	switch t := v.(type) {
+	case string:
+		return redactString(t, patterns)
+	case []string:
+		r := make([]string, len(t))
+		for i, s := range t {
+			r[i] = redactString(s, patterns)
+		}
+		return r
+	case []interface{}:
+		r := make([]interface{}, len(t))
+		for i, e := range t {
+			r[i] = redactValue(e, patterns)
+		}
+		return r
+	case map[string]string:
+		r := make(map[string]string, len(t))
+		for k, s := range t {
+			r[k] = redactString(s, patterns)
+		}
+		return r
+	case map[string]interface{}:
+		r := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			r[k] = redactValue(e, patterns)
+		}
+		return r
+	}
+	return v
+}
+
+// redactedError is an error whose message was redacted. It wraps the
+// original error such that errors.Is and errors.As still match it.
+type redactedError struct {
+	msg string
+	err error
+}
+
+// Error is an implementation of error interface
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the original error
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// redact returns this message with the provided patterns redacted from
+// its description, error and fields. A copy is returned if anything
+// was redacted and this message otherwise.
+func (m *msg) redact(patterns []*regexp.Regexp) *msg {
+	if m == nil || len(patterns) == 0 {
+		return m
+	}
+	desc := m.Description()
+	rdesc := redactString(desc, patterns)
+	var rerr error
+	if m.Err != nil {
+		if s := m.Err.Error(); redactString(s, patterns) != s {
+			rerr = &redactedError{msg: redactString(s, patterns), err: m.Err}
+		}
+	}
+	var fields map[string]interface{}
+	if len(m.Fields) != 0 {
+		fields = redactValue(m.Fields, patterns).(map[string]interface{})
+	}
+	if rdesc == desc && rerr == nil && fieldsEqual(fields, m.Fields) {
+		return m
+	}
+	c := m.clone()
+	c.Desc, c.lazy = rdesc, nil
+	c.FullDesc = redactString(m.FullDesc, patterns)
+	if rerr != nil {
+		c.Err = rerr
+	}
+	c.Fields = fields
+	return c
+}
+
+// forOutput returns this message with the registered patterns redacted
+// if auto redaction is enabled, see EnableAutoRedaction
+func (m *msg) forOutput() *msg {
+	return m.redact(autoRedactions())
+}
+
+// orRegistered returns the provided patterns or the registered ones if
+// none are provided
+func orRegistered(patterns []*regexp.Regexp) []*regexp.Regexp {
+	if len(patterns) != 0 {
+		return patterns
+	}
+	return registeredRedactions()
+}
+
+// Redact returns a copy of the messages where every match of the
+// provided patterns is replaced by Redacted in the descriptions, the
+// errors and the string values of the fields of the messages. The
+// registered patterns are used if none are provided, see
+// RegisterRedaction. Redacted errors wrap the original ones. The
+// receiver is not modified.
+func (m Msgs) Redact(patterns ...*regexp.Regexp) (r Msgs) {
+	r = m.Clone()
+	return *r.RedactInPlace(patterns...)
+}
+
+// RedactInPlace is same as Redact but replaces the messages of the
+// receiver that have matches with their redacted copies. Other lists
+// sharing these messages e.g. via Merge are not affected.
+func (m *Msgs) RedactInPlace(patterns ...*regexp.Regexp) (u *Msgs) {
+	m.mustNotBeNil("RedactInPlace")
+	patterns = orRegistered(patterns)
+	for i, item := range m.Items {
+		m.Items[i] = item.redact(patterns)
+	}
+	m.touch()
+	return m
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRedactString(t *testing.T) {
+	token := regexp.MustCompile(`token=\w+`)
+	secret := regexp.MustCompile(`\w+secret\w*`)
+	password := regexp.MustCompile(`password=\S+`)
+
+	tests := map[string]struct {
+		s        string
+		patterns []*regexp.Regexp
+		expected string
+	}{
+		"101": {"login with token=abc123 failed", []*regexp.Regexp{token}, "login with [REDACTED] failed"},
+		"102": {"token=mysecretvalue done", []*regexp.Regexp{token, secret}, "[REDACTED] done"},
+		"103": {"x token=a1 y password=p2 z", []*regexp.Regexp{password, token}, "x [REDACTED] y [REDACTED] z"},
+		"104": {"token=a1password=p2", []*regexp.Regexp{token, password}, "[REDACTED]"},
+		"105": {"nothing to hide", []*regexp.Regexp{token, password}, "nothing to hide"},
+		"106": {"token=a1", nil, "token=a1"},
+		"107": {"abc", []*regexp.Regexp{regexp.MustCompile(`x*`)}, "abc"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := redactString(mock.s, mock.patterns); actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMsgsRedact(t *testing.T) {
+	password := regexp.MustCompile(`password=\S+`)
+	sentinel := errors.New("password=hunter2")
+	wrapped := fmt.Errorf("failed to connect to db: %w", sentinel)
+	RegisterTemplate("test.redact.Connect", "connecting as {{.user}}")
+	m := (&Msgs{}).
+		AddInfo("using password=hunter2").
+		AddError(wrapped).
+		AddInfoT("test.redact.Connect", map[string]interface{}{"user": "admin password=hunter2", "args": []interface{}{"password=x", 1}}).
+		AddWarn("w1")
+
+	r := m.Redact(password)
+	expected := "using [REDACTED],failed to connect to db: [REDACTED],connecting as admin password=hunter2,w1"
+	if actual := strings.Join(r.Descriptions(), ","); actual != "using [REDACTED],failed to connect to db: [REDACTED],connecting as admin [REDACTED],w1" {
+		t.Fatalf("Test failed: expected redacted descriptions: actual '%s'", actual)
+	}
+	if r.Items[1].Err.Error() != "failed to connect to db: [REDACTED]" || !errors.Is(r.Items[1].Err, sentinel) {
+		t.Fatalf("Test failed: expected redacted error wrapping the original: actual '%v'", r.Items[1].Err)
+	}
+	if v, _ := r.Items[2].Field("user"); v != "admin [REDACTED]" {
+		t.Fatalf("Test failed: expected redacted field: actual '%v'", v)
+	}
+	if v, _ := r.Items[2].Field("args"); fmt.Sprint(v) != "[[REDACTED] 1]" {
+		t.Fatalf("Test failed: expected redacted nested field: actual '%v'", v)
+	}
+	if actual := strings.Join(m.Descriptions(), ","); actual != strings.Replace(expected, "[REDACTED]", "password=hunter2", -1) ||
+		m.Items[1].Err != wrapped {
+		t.Fatalf("Test failed: expected originals to be unmodified: actual '%s'", actual)
+	}
+	w1 := m.Items[3]
+	m.RedactInPlace(password)
+	if m.Items[3] != w1 {
+		t.Fatalf("Test failed: expected messages without matches to be kept as is")
+	}
+	if !m.EqualStrict(r) {
+		t.Fatalf("Test failed: expected in place redaction to match: actual '%s'", strings.Join(m.Descriptions(), ","))
+	}
+}
+
+func TestMsgsAutoRedaction(t *testing.T) {
+	defer func(r []*regexp.Regexp) {
+		DisableAutoRedaction()
+		redactionsMu.Lock()
+		redactions = r
+		redactionsMu.Unlock()
+	}(registeredRedactions())
+	RegisterRedaction(regexp.MustCompile(`token=\w+`))
+
+	m := (&Msgs{}).AddWarn("retrying with token=abc123").AddError(errors.New("denied token=abc123"))
+	before := m.String()
+	if !strings.Contains(before, "token=abc123") {
+		t.Fatalf("Test failed: expected no redaction till enabled: actual '%s'", before)
+	}
+	if r := m.Redact(); r.Items[0].Description() != "retrying with [REDACTED]" {
+		t.Fatalf("Test failed: expected registered patterns by default: actual '%s'", r.Items[0].Description())
+	}
+	EnableAutoRedaction()
+
+	b, _ := json.Marshal(m)
+	var csv strings.Builder
+	m.WriteCSV(&csv)
+	outputs := map[string]string{
+		"string": m.String(),
+		"json":   string(b),
+		"csv":    csv.String(),
+		"format": fmt.Sprintf("%v", m.Items[1]),
+		"go":     m.GoString(),
+	}
+	for name, out := range outputs {
+		if strings.Contains(out, "abc123") || !strings.Contains(out, Redacted) {
+			t.Fatalf("Test '%s' failed: expected redacted output: actual '%s'", name, out)
+		}
+	}
+	for name, roundTrip := range mockRoundTrips() {
+		actual, err := roundTrip(m)
+		if err != nil || actual.Items[1].Err.Error() != "denied [REDACTED]" {
+			t.Fatalf("Test '%s' failed: expected redacted serialization: actual '%v' '%v'", name, actual, err)
+		}
+	}
+	if !m.Contains(WarnMsg, "retrying with token=abc123") || len(m.Filter(func(given *msg) bool {
+		return strings.Contains(given.Description(), "abc123")
+	}).Items) != 2 {
+		t.Fatalf("Test failed: expected originals to be retained")
+	}
+	DisableAutoRedaction()
+	if m.String() != before {
+		t.Fatalf("Test failed: expected no redaction once disabled: actual '%s'", m.String())
+	}
+}
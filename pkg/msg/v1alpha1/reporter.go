@@ -0,0 +1,111 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sync"
+	"time"
+)
+
+// Reporter periodically logs the messages added to guarded messages
+// since it last logged them such that the progress of a long running
+// operation is visible while it runs
+type Reporter struct {
+	s     *SafeMsgs
+	l     func(string, ...interface{})
+	last  Checkpoint // messages logged so far
+	stop  func()     // stops the ticks
+	quit  chan struct{}
+	done  chan struct{}
+	close sync.Once
+}
+
+// NewReporter returns a new reporter that logs, every interval, the
+// messages added to the provided messages since its previous report
+// followed by a summary of all the messages. Nothing is logged if no
+// message was added in the meantime. Messages are reported only when
+// stopped if the interval is not positive. The default logger is used
+// if the provided logger is nil, see SetDefaultLogger.
+func NewReporter(s *SafeMsgs, interval time.Duration, l func(string, ...interface{})) *Reporter {
+	var (
+		ticks <-chan time.Time
+		stop  = func() {}
+	)
+	if interval > 0 {
+		t := time.NewTicker(interval)
+		ticks, stop = t.C, t.Stop
+	}
+	return newReporter(s, ticks, stop, l)
+}
+
+// newReporter returns a new reporter that reports whenever the provided
+// channel ticks
+func newReporter(s *SafeMsgs, ticks <-chan time.Time, stop func(), l func(string, ...interface{})) *Reporter {
+	r := &Reporter{
+		s:    s,
+		l:    l,
+		stop: stop,
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go r.run(ticks)
+	return r
+}
+
+// run reports on every tick till the reporter is stopped
+func (r *Reporter) run(ticks <-chan time.Time) {
+	defer close(r.done)
+	for {
+		select {
+		case <-ticks:
+			r.report()
+		case <-r.quit:
+			return
+		}
+	}
+}
+
+// report logs the messages added since the previous report followed
+// by the summary of all the messages. Every message is logged again if
+// messages were removed in the meantime, see SinceCheckpoint.
+func (r *Reporter) report() {
+	snapshot := r.s.Snapshot()
+	added := snapshot.SinceCheckpoint(r.last)
+	r.last = snapshot.Checkpoint()
+	if len(added.Items) == 0 {
+		return
+	}
+	l := orDefaultLogger(r.l)
+	if l == nil {
+		return
+	}
+	added.threshold = snapshot.threshold
+	added.Log(l)
+	l("%s", snapshot.SummaryString())
+}
+
+// Stop stops the reporter after logging the messages added since its
+// previous report. It waits for the reporter to terminate and is safe
+// to invoke more than once.
+func (r *Reporter) Stop() {
+	r.close.Do(func() {
+		r.stop()
+		close(r.quit)
+		<-r.done
+		r.report()
+	})
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// reported returns the descriptions of the messages and the summaries
+// logged as per mockLogger in the order they were logged
+func reported(lines []string) string {
+	var r []string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "errors: ") {
+			r = append(r, "["+line+"]")
+			continue
+		}
+		for _, l := range strings.Split(line, "\n") {
+			if strings.HasPrefix(l, "desc: ") {
+				r = append(r, strings.TrimPrefix(l, "desc: "))
+			}
+		}
+	}
+	return strings.Join(r, ",")
+}
+
+func TestReporter(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		lines []string
+	)
+	snapshot := func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return reported(lines)
+	}
+	s := NewSafeMsgs()
+	ticks := make(chan time.Time)
+	stopped := false
+	r := newReporter(s, ticks, func() { stopped = true }, func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		mockLogger(&lines)(format, args...)
+	})
+
+	s.AddInfo("i1").AddWarn("w1")
+	ticks <- time.Now()
+	// a tick is received only once the previous report is complete
+	ticks <- time.Now()
+	if actual := snapshot(); actual != "i1,w1,[errors: 0, warns: 1, infos: 1, skips: 0]" {
+		t.Fatalf("Test failed: expected first report: actual '%s'", actual)
+	}
+	s.AddError(errors.New("e1"))
+	ticks <- time.Now()
+	ticks <- time.Now()
+	if actual := snapshot(); actual != "i1,w1,[errors: 0, warns: 1, infos: 1, skips: 0],e1,[errors: 1, warns: 1, infos: 1, skips: 0]" {
+		t.Fatalf("Test failed: expected second report: actual '%s'", actual)
+	}
+	s.AddSkip("s1")
+	r.Stop()
+	r.Stop()
+	expected := "i1,w1,[errors: 0, warns: 1, infos: 1, skips: 0],e1,[errors: 1, warns: 1, infos: 1, skips: 0],s1,[errors: 1, warns: 1, infos: 1, skips: 1]"
+	if actual := snapshot(); actual != expected {
+		t.Fatalf("Test failed: expected final report on stop: actual '%s'", actual)
+	}
+	select {
+	case <-r.done:
+	default:
+		t.Fatalf("Test failed: expected reporter to be terminated")
+	}
+	if !stopped {
+		t.Fatalf("Test failed: expected ticks to be stopped")
+	}
+	s.AddInfo("i2")
+	r.Stop()
+	if actual := snapshot(); actual != expected {
+		t.Fatalf("Test failed: expected no report once stopped: actual '%s'", actual)
+	}
+}
+
+func TestReporterConcurrentAdds(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		lines []string
+	)
+	s := NewSafeMsgs()
+	r := NewReporter(s, time.Millisecond, func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		mockLogger(&lines)(format, args...)
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				s.AddInfo("i1")
+			}
+		}()
+	}
+	wg.Wait()
+	r.Stop()
+	if actual := strings.Count(reported(lines), "i1"); actual != 1000 {
+		t.Fatalf("Test failed: expected every message to be reported once: actual %d", actual)
+	}
+	if !strings.HasSuffix(reported(lines), "[errors: 0, warns: 0, infos: 1000, skips: 0]") {
+		t.Fatalf("Test failed: expected a final summary: actual '%s'", lines[len(lines)-1])
+	}
+}
+
+func TestReporterNilLogger(t *testing.T) {
+	defer SetDefaultLogger(defaultLogger)
+	var lines []string
+	SetDefaultLogger(mockLogger(&lines))
+	s := NewSafeMsgs().AddWarn("w1")
+	NewReporter(s, 0, nil).Stop()
+	if actual := reported(lines); actual != "w1,[errors: 0, warns: 1, infos: 0, skips: 0]" {
+		t.Fatalf("Test failed: expected report via the default logger: actual '%s'", actual)
+	}
+}
@@ -0,0 +1,59 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Result is the outcome of an operation i.e. its value along with the
+// messages recorded while producing it. It is meant to be returned in
+// place of a value, messages and an error such that checking Err is
+// the only check callers need.
+type Result[T any] struct {
+	Value T
+	Msgs  *Msgs
+}
+
+// Ok returns a result holding the provided value and no messages
+func Ok[T any](v T) Result[T] {
+	return Result[T]{Value: v, Msgs: &Msgs{}}
+}
+
+// Fail returns a result holding the zero value and the provided error
+// recorded as an ErrMsg. A nil error is not recorded.
+func Fail[T any](err error) Result[T] {
+	return Result[T]{Msgs: (&Msgs{}).AddError(err)}
+}
+
+// Err returns the errors recorded in the messages of this result as a
+// single error as per Msgs.Err or nil if none were recorded
+func (r Result[T]) Err() error {
+	if r.Msgs == nil {
+		return nil
+	}
+	return r.Msgs.Err()
+}
+
+// Merge merges the provided messages e.g. of an earlier step with the
+// messages of this result
+func (r *Result[T]) Merge(other *Msgs) *Result[T] {
+	if r.Msgs == nil {
+		r.Msgs = &Msgs{}
+	}
+	r.Msgs.Merge(other)
+	return r
+}
@@ -0,0 +1,97 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// mockVolume is the value produced by the steps of mockBuildVolume
+type mockVolume struct {
+	name string
+	size int
+}
+
+// mockBuildVolume builds a volume in steps where every step records its
+// messages and fails if asked to
+func mockBuildVolume(failAt int) Result[*mockVolume] {
+	r := Ok(&mockVolume{name: "v1"})
+	for step := 1; step <= 3; step++ {
+		if step == failAt {
+			f := Fail[*mockVolume](errors.New("step failed"))
+			return *r.Merge(f.Msgs)
+		}
+		r.Merge((&Msgs{}).AddInfo("step done"))
+		r.Value.size += step
+	}
+	return r
+}
+
+func TestResult(t *testing.T) {
+	tests := map[string]struct {
+		r              Result[*mockVolume]
+		expectedErr    string
+		expectedDescs  string
+		expectedSize   int
+		expectedNilVal bool
+	}{
+		"101": {mockBuildVolume(0), "", "step done,step done,step done", 6, false},
+		"102": {mockBuildVolume(2), "step failed", "step done,step failed", 1, false},
+		"103": {mockBuildVolume(1), "step failed", "step failed", 0, false},
+		"104": {Fail[*mockVolume](errors.New("e1")), "e1", "e1", 0, true},
+		"105": {Fail[*mockVolume](nil), "", "", 0, true},
+		"106": {Result[*mockVolume]{}, "", "", 0, true},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if (mock.r.Err() == nil) != (len(mock.expectedErr) == 0) || (mock.r.Err() != nil && mock.r.Err().Error() != mock.expectedErr) {
+				t.Fatalf("Test '%s' failed: expected error '%s': actual '%v'", name, mock.expectedErr, mock.r.Err())
+			}
+			if mock.r.Msgs != nil {
+				if actual := strings.Join(mock.r.Msgs.Descriptions(), ","); actual != mock.expectedDescs {
+					t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expectedDescs, actual)
+				}
+			}
+			if (mock.r.Value == nil) != mock.expectedNilVal || (mock.r.Value != nil && mock.r.Value.size != mock.expectedSize) {
+				t.Fatalf("Test '%s' failed: expected size %d: actual '%v'", name, mock.expectedSize, mock.r.Value)
+			}
+		})
+	}
+}
+
+func TestResultErrAggregation(t *testing.T) {
+	e1, e2 := errors.New("e1"), errors.New("e2")
+	var r Result[int]
+	r.Merge((&Msgs{}).AddWarn("w1").AddError(e1)).Merge((&Msgs{}).AddError(e2)).Merge(nil)
+	err := r.Err()
+	if err == nil || err.Error() != "e1; e2" {
+		t.Fatalf("Test failed: expected 'e1; e2': actual '%v'", err)
+	}
+	wrapped, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(wrapped.Unwrap()) != 2 || wrapped.Unwrap()[0] != e1 || wrapped.Unwrap()[1] != e2 {
+		t.Fatalf("Test failed: expected the aggregated errors to be unwrapped: actual '%v'", err)
+	}
+	if single := Fail[int](e1); single.Err() != e1 {
+		t.Fatalf("Test failed: expected a single error as is: actual '%v'", single.Err())
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sync"
+)
+
+// SafeMsgs guards messages such that they can be added to and read
+// from several goroutines e.g. by a controller while a debug endpoint
+// serves them. Msgs itself is not safe for concurrent use.
+type SafeMsgs struct {
+	mu   sync.RWMutex
+	msgs Msgs
+}
+
+// NewSafeMsgs returns a new instance of SafeMsgs whose messages are
+// configured with the provided options
+func NewSafeMsgs(opts ...Option) *SafeMsgs {
+	return &SafeMsgs{msgs: *NewMsgs(opts...)}
+}
+
+// Update invokes the provided function with the guarded messages while
+// holding the lock. The messages must not be retained beyond the
+// invocation. Hooks of the messages must not use this instance.
+func (s *SafeMsgs) Update(fn func(m *Msgs)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.msgs)
+}
+
+// AddInfo appends a new InfoMsg to the guarded messages
+func (s *SafeMsgs) AddInfo(i string) *SafeMsgs {
+	s.Update(func(m *Msgs) { m.AddInfo(i) })
+	return s
+}
+
+// AddWarn appends a new WarnMsg to the guarded messages
+func (s *SafeMsgs) AddWarn(w string) *SafeMsgs {
+	s.Update(func(m *Msgs) { m.AddWarn(w) })
+	return s
+}
+
+// AddSkip appends a new SkipMsg to the guarded messages
+func (s *SafeMsgs) AddSkip(sk string) *SafeMsgs {
+	s.Update(func(m *Msgs) { m.AddSkip(sk) })
+	return s
+}
+
+// AddError appends a new ErrMsg to the guarded messages
+func (s *SafeMsgs) AddError(e error) *SafeMsgs {
+	s.Update(func(m *Msgs) { m.AddError(e) })
+	return s
+}
+
+// Merge merges the provided messages with the guarded messages
+func (s *SafeMsgs) Merge(other *Msgs) *SafeMsgs {
+	s.Update(func(m *Msgs) { m.Merge(other) })
+	return s
+}
+
+// Snapshot returns a consistent deep copy of the guarded messages.
+// Changes to the copy do not affect the guarded messages.
+func (s *SafeMsgs) Snapshot() Msgs {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.msgs.Clone()
+}
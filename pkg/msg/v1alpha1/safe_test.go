@@ -0,0 +1,64 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSafeMsgsSnapshot(t *testing.T) {
+	s := NewSafeMsgs(WithSource("pool")).AddInfo("i1").AddWarn("w1")
+	snap := s.Snapshot()
+	snap.AddInfo("i2")
+	snap.Items[0].Desc = "changed"
+	s.AddSkip("s1").AddError(errors.New("e1")).Merge((&Msgs{}).AddInfo("i3"))
+
+	actual := s.Snapshot()
+	if d := strings.Join(actual.Descriptions(), ","); d != "i1,w1,s1,e1,i3" {
+		t.Fatalf("Test failed: expected snapshot to be isolated: actual '%s'", d)
+	}
+	if actual.Items[0].Source != "pool" {
+		t.Fatalf("Test failed: expected options to apply: actual source '%s'", actual.Items[0].Source)
+	}
+}
+
+func TestSafeMsgsConcurrent(t *testing.T) {
+	s := NewSafeMsgs()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.Update(func(m *Msgs) { m.AddInfo("i1").AddWarn("w1") })
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.Snapshot().SummaryString()
+			}
+		}()
+	}
+	wg.Wait()
+	if actual := s.Snapshot(); len(actual.Items) != 2000 {
+		t.Fatalf("Test failed: expected 2000 messages: actual %d", len(actual.Items))
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// sampler keeps added messages of the sampled types with the
+// configured probability
+type sampler struct {
+	rates map[MsgType]float64 // probability of keeping a message per type
+	out   map[MsgType]int     // count of messages sampled out per type
+	src   *rand.Rand          // source of the samples; math/rand if nil
+}
+
+// alwaysKept returns true if messages of the provided type are never
+// sampled out
+func alwaysKept(t MsgType) bool {
+	return t == ErrMsg || t == WarnMsg
+}
+
+// clone returns a copy of this sampler that shares its source
+func (s *sampler) clone() *sampler {
+	if s == nil {
+		return nil
+	}
+	c := &sampler{rates: map[MsgType]float64{}, out: map[MsgType]int{}, src: s.src}
+	for t, r := range s.rates {
+		c.rates[t] = r
+	}
+	for t, n := range s.out {
+		c.out[t] = n
+	}
+	return c
+}
+
+// sample returns a sample in [0, 1)
+func (s *sampler) sample() float64 {
+	if s.src == nil {
+		return rand.Float64()
+	}
+	return s.src.Float64()
+}
+
+// keep returns true if the provided message is to be kept and counts
+// it as sampled out otherwise
+func (s *sampler) keep(item *msg) bool {
+	if s == nil || alwaysKept(item.Mtype) {
+		return true
+	}
+	rate, found := s.rates[item.Mtype]
+	if !found || s.sample() < rate {
+		return true
+	}
+	s.out[item.Mtype]++
+	return false
+}
+
+// samplerOf returns the sampler of the messages creating it if needed
+func (m *Msgs) samplerOf() *sampler {
+	if m.sampler == nil {
+		m.sampler = &sampler{rates: map[MsgType]float64{}, out: map[MsgType]int{}}
+	}
+	return m.sampler
+}
+
+// WithSampling keeps the messages of the provided type that are added
+// from now on with the provided probability e.g. 0.1 keeps about one in
+// ten messages. A rate of 1 or more keeps every message while a rate of
+// 0 or less keeps none. Errors and warnings are always kept and hence
+// can not be sampled. Messages that are merged are not sampled.
+func (m *Msgs) WithSampling(t MsgType, rate float64) (u *Msgs) {
+	m.mustNotBeNil("WithSampling")
+	if alwaysKept(t) {
+		return m
+	}
+	if rate >= 1 {
+		if m.sampler != nil {
+			delete(m.sampler.rates, t)
+		}
+		return m
+	}
+	m.samplerOf().rates[t] = rate
+	return m
+}
+
+// WithSamplingSource sets the source of the samples used by
+// WithSampling e.g. a seeded source for deterministic sampling. It
+// defaults to the source of math/rand.
+func (m *Msgs) WithSamplingSource(src rand.Source) (u *Msgs) {
+	m.mustNotBeNil("WithSamplingSource")
+	if src == nil {
+		m.samplerOf().src = nil
+		return m
+	}
+	m.samplerOf().src = rand.New(src)
+	return m
+}
+
+// WithSampling samples the added messages of the provided type as per
+// Msgs.WithSampling
+func WithSampling(t MsgType, rate float64) Option {
+	return func(m *Msgs) {
+		m.WithSampling(t, rate)
+	}
+}
+
+// WithSamplingSource sets the source of the samples as per
+// Msgs.WithSamplingSource
+func WithSamplingSource(src rand.Source) Option {
+	return func(m *Msgs) {
+		m.WithSamplingSource(src)
+	}
+}
+
+// SampledOut returns the count of messages of the provided type that
+// were not kept due to sampling
+func (m Msgs) SampledOut(t MsgType) int {
+	if m.sampler == nil {
+		return 0
+	}
+	return m.sampler.out[t]
+}
+
+// sampledOutSummary returns the count of sampled out messages per type
+// in sorted order of types e.g. ', sampled out info: 37' or an empty
+// string if none were sampled out
+func (m Msgs) sampledOutSummary() (summary string) {
+	if m.sampler == nil {
+		return
+	}
+	var types []string
+	for t, n := range m.sampler.out {
+		if n != 0 {
+			types = append(types, string(t))
+		}
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		summary += fmt.Sprintf(", sampled out %s: %d", t, m.sampler.out[MsgType(t)])
+	}
+	return
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestMsgsWithSampling(t *testing.T) {
+	tests := map[string]struct {
+		mtype      MsgType
+		rate       float64
+		minKept    int
+		maxKept    int
+		expectedOK bool
+	}{
+		"101": {InfoMsg, 0.1, 800, 1200, true},
+		"102": {InfoMsg, 0.5, 4700, 5300, true},
+		"103": {InfoMsg, 1, 10000, 10000, true},
+		"104": {InfoMsg, 0, 0, 0, true},
+		"105": {SkipMsg, 0.25, 2300, 2700, true},
+		"106": {ErrMsg, 0, 10000, 10000, false},
+		"107": {WarnMsg, 0.1, 10000, 10000, false},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := NewMsgs(WithSamplingSource(rand.NewSource(1)), WithSampling(mock.mtype, mock.rate))
+			for i := 0; i < 10000; i++ {
+				switch mock.mtype {
+				case ErrMsg:
+					m.AddError(errors.New("e1"))
+				case WarnMsg:
+					m.AddWarn("w1")
+				case SkipMsg:
+					m.AddSkip("s1")
+				default:
+					m.AddInfo("i1")
+				}
+			}
+			kept := len(m.Items)
+			if kept < mock.minKept || kept > mock.maxKept {
+				t.Fatalf("Test '%s' failed: expected %d to %d kept: actual %d", name, mock.minKept, mock.maxKept, kept)
+			}
+			if m.SampledOut(mock.mtype) != 10000-kept {
+				t.Fatalf("Test '%s' failed: expected %d sampled out: actual %d", name, 10000-kept, m.SampledOut(mock.mtype))
+			}
+			if m.SampledOut(mock.mtype) == 0 && m.sampledOutSummary() != "" {
+				t.Fatalf("Test '%s' failed: expected no sampled out summary: actual '%s'", name, m.sampledOutSummary())
+			}
+		})
+	}
+}
+
+func TestMsgsSamplingDeterministic(t *testing.T) {
+	build := func() *Msgs {
+		m := NewMsgs(WithSampling(InfoMsg, 0.3), WithSampling(SkipMsg, 0.5), WithSamplingSource(rand.NewSource(42)))
+		for i := 0; i < 100; i++ {
+			m.AddInfo("i1").AddSkip("s1").AddWarn("w1").AddError(errors.New("e1"))
+		}
+		return m
+	}
+	a, b := build(), build()
+	if !a.EqualStrict(*b) || a.SummaryString() != b.SummaryString() {
+		t.Fatalf("Test failed: expected same samples for the same seed: actual '%s' '%s'", a.SummaryString(), b.SummaryString())
+	}
+	counts := a.counts()
+	if counts[ErrMsg] != 100 || counts[WarnMsg] != 100 {
+		t.Fatalf("Test failed: expected errors and warns to be kept: actual '%s'", a.SummaryString())
+	}
+	if counts[InfoMsg]+a.SampledOut(InfoMsg) != 100 || counts[SkipMsg]+a.SampledOut(SkipMsg) != 100 {
+		t.Fatalf("Test failed: expected every sampled out message to be counted: actual '%s'", a.SummaryString())
+	}
+	expected := "errors: 100, warns: 100, infos: " + strconv.Itoa(counts[InfoMsg]) + ", skips: " + strconv.Itoa(counts[SkipMsg]) +
+		", sampled out info: " + strconv.Itoa(a.SampledOut(InfoMsg)) + ", sampled out skip: " + strconv.Itoa(a.SampledOut(SkipMsg))
+	if a.SummaryString() != expected {
+		t.Fatalf("Test failed: expected '%s': actual '%s'", expected, a.SummaryString())
+	}
+
+	c := a.Clone()
+	c.AddInfo("i1")
+	a.WithSampling(InfoMsg, 1).AddInfo("i1")
+	if a.SampledOut(InfoMsg) != b.SampledOut(InfoMsg) || counts[InfoMsg]+1 != a.counts()[InfoMsg] {
+		t.Fatalf("Test failed: expected sampling to be disabled: actual '%s'", a.SummaryString())
+	}
+	if c.SampledOut(InfoMsg) < b.SampledOut(InfoMsg) {
+		t.Fatalf("Test failed: expected clone to retain the counts: actual '%s'", c.SummaryString())
+	}
+	m := (&Msgs{}).Merge(b)
+	if m.SampledOut(InfoMsg) != 0 || m.WithSampling(InfoMsg, 0).Merge(b).counts()[InfoMsg] != 2*counts[InfoMsg] {
+		t.Fatalf("Test failed: expected merged messages not to be sampled: actual '%s'", m.SummaryString())
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SchemaVersion is the version of the json representation of messages
+// emitted by MarshalMsgs. It is bumped whenever a stored document can
+// no longer be parsed as is, along with a migration from the previous
+// version, see ParseMsgs.
+const SchemaVersion = 1
+
+// ErrUnsupportedSchema is returned while parsing messages whose schema
+// version is newer than SchemaVersion e.g. ones stored by a newer
+// producer
+type ErrUnsupportedSchema struct {
+	Version int // version of the parsed document
+}
+
+// Error is an implementation of error interface
+func (e ErrUnsupportedSchema) Error() string {
+	return fmt.Sprintf("unsupported schema version %d of messages: supported versions are 0 to %d", e.Version, SchemaVersion)
+}
+
+// msgsV0 is the json representation of messages prior to versioning
+// i.e. a document without a schema version
+type msgsV0 struct {
+	Items []*msg `json:"items,omitempty"`
+}
+
+// msgsV1 is the json representation of messages as per the current
+// schema version
+type msgsV1 struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Items         []*msg `json:"items,omitempty"`
+}
+
+// schemaOf returns the schema version of the provided document. A
+// document without a version is of version 0.
+func schemaOf(b []byte) (version int, err error) {
+	var v struct {
+		SchemaVersion int `json:"schemaVersion"`
+	}
+	if err = json.Unmarshal(b, &v); err != nil {
+		return
+	}
+	return v.SchemaVersion, nil
+}
+
+// migrateV0toV1 maps messages of version 0 onto version 1. An error of
+// version 0 was marshaled as an empty object and is hence restored from
+// the description while unmarshaling. A message without a description
+// is described by its error while an ErrMsg without an error gets one
+// holding its description, same as the messages added via AddError.
+func migrateV0toV1(v0 msgsV0) (v1 msgsV1) {
+	v1.SchemaVersion = 1
+	v1.Items = v0.Items
+	for _, item := range v1.Items {
+		if item == nil {
+			continue
+		}
+		if len(item.Desc) == 0 && item.Err != nil {
+			item.Desc = item.Err.Error()
+		}
+		if item.Mtype == ErrMsg && item.Err == nil {
+			item.Err = errors.New(item.Desc)
+		}
+	}
+	return
+}
+
+// ParseMsgs parses messages serialized via MarshalMsgs or marshaled as
+// is. Documents of older schema versions, including the ones stored
+// before versioning, are migrated to the current version while
+// documents of newer versions fail with ErrUnsupportedSchema.
+func ParseMsgs(b []byte) (m *Msgs, err error) {
+	version, err := schemaOf(b)
+	if err != nil {
+		return nil, err
+	}
+	var v1 msgsV1
+	switch version {
+	case 0:
+		var v0 msgsV0
+		if err = json.Unmarshal(b, &v0); err != nil {
+			return nil, err
+		}
+		v1 = migrateV0toV1(v0)
+	case 1:
+		if err = json.Unmarshal(b, &v1); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnsupportedSchema{Version: version}
+	}
+	return &Msgs{Items: v1.Items}, nil
+}
+
+// MarshalMsgs returns the messages as json along with the schema
+// version such that they can be parsed via ParseMsgs e.g.
+//
+//	{"schemaVersion":1,"items":[{"type":"warn","desc":"..."}]}
+//
+// The version is emitted by this function rather than by Msgs itself
+// since Msgs is embedded by structs that are marshaled as a whole.
+func MarshalMsgs(m Msgs) ([]byte, error) {
+	return json.Marshal(msgsV1{SchemaVersion: SchemaVersion, Items: m.Items})
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// v0 documents as marshaled by json.Marshal of Msgs prior to schema
+// versioning. An error was marshaled as an empty object then.
+const (
+	mockV0Msgs        = `{"items":[{"type":"info","desc":"i1"},{"type":"warn","desc":"w1"},{"type":"skip","desc":"s1"},{"type":"error","desc":"e1","err":{}}]}`
+	mockV0MsgsEmpty   = `{}`
+	mockV0MsgsVersion = `{"schemaVersion":0,"items":[{"type":"info","desc":"i1"}]}`
+)
+
+func TestParseMsgs(t *testing.T) {
+	tests := map[string]struct {
+		doc      string
+		expected string
+	}{
+		"101": {mockV0Msgs, "i1,w1,s1,e1"},
+		"102": {mockV0MsgsEmpty, ""},
+		"103": {mockV0MsgsVersion, "i1"},
+		"104": {`{"schemaVersion":1,"items":[{"type":"warn","desc":"w1"}]}`, "w1"},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			m, err := ParseMsgs([]byte(mock.doc))
+			if err != nil {
+				t.Fatalf("Test '%s' failed: expected no error: actual '%s'", name, err)
+			}
+			if actual := strings.Join(m.Descriptions(), ","); actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseMsgsV0Err(t *testing.T) {
+	m, err := ParseMsgs([]byte(mockV0Msgs))
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	if err := m.Items[3].Err; err == nil || err.Error() != "e1" {
+		t.Fatalf("Test failed: expected error to be restored from its desc: actual '%#v'", m.Items[3])
+	}
+	if m.Items[0].Err != nil {
+		t.Fatalf("Test failed: expected no error for info: actual '%#v'", m.Items[0])
+	}
+}
+
+func TestParseMsgsV0RoundTrip(t *testing.T) {
+	m, err := ParseMsgs([]byte(mockV0Msgs))
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	b, err := MarshalMsgs(*m)
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	expected := `{"schemaVersion":1,` + strings.Replace(mockV0Msgs[1:], `"err":{}`, `"err":"e1"`, 1)
+	if string(b) != expected {
+		t.Fatalf("Test failed: expected '%s': actual '%s'", expected, b)
+	}
+	again, err := ParseMsgs(b)
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%s'", err)
+	}
+	if !again.EqualStrict(*m) {
+		t.Fatalf("Test failed: expected round trip to retain messages: actual '%s'", again)
+	}
+}
+
+func TestParseMsgsUnsupported(t *testing.T) {
+	doc := `{"schemaVersion":2,"entries":[{"kind":"info","message":"i1"}]}`
+	m, err := ParseMsgs([]byte(doc))
+	var unsupported ErrUnsupportedSchema
+	if m != nil || !errors.As(err, &unsupported) || unsupported.Version != 2 {
+		t.Fatalf("Test failed: expected unsupported schema error: actual '%v' '%v'", m, err)
+	}
+}
+
+func TestParseMsgsInvalid(t *testing.T) {
+	for _, doc := range []string{`not json`, `{"schemaVersion":"1"}`, `{"items":{}}`} {
+		if _, err := ParseMsgs([]byte(doc)); err == nil {
+			t.Fatalf("Test failed: expected error for '%s': actual nil", doc)
+		}
+	}
+}
+
+func TestMsgsEmbeddedJSON(t *testing.T) {
+	type command struct {
+		ID string
+		*Msgs
+	}
+	c := command{ID: "c1", Msgs: (&Msgs{}).AddWarn("w1")}
+	b, err := json.Marshal(c)
+	expected := `{"ID":"c1","items":[{"type":"warn","desc":"w1"}]}`
+	if err != nil || string(b) != expected {
+		t.Fatalf("Test failed: expected '%s': actual '%s' '%v'", expected, b, err)
+	}
+	var again command
+	if err := json.Unmarshal(b, &again); err != nil || again.ID != "c1" || descs(again.Msgs) != "w1" {
+		t.Fatalf("Test failed: expected embedding struct to retain its fields: actual '%#v' '%v'", again, err)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// ScopeLabel is the label that holds the scope of messages added via a
+// scoped list of messages, see Msgs.Scope
+const ScopeLabel = "scope"
+
+// scope links a scoped list of messages to its parent
+type scope struct {
+	name      string
+	parent    *Msgs
+	live      bool // messages reach the parent as soon as they are added
+	committed int  // count of messages that reached the parent on Commit
+}
+
+// Scope returns a child list of messages whose messages are labeled
+// with the provided scope and are appended to the receiver as soon as
+// they are added. Scopes of nested children are joined by '/' e.g.
+// 'provision/validate'. The child is configured as the receiver apart
+// from its hooks and limit.
+func (m *Msgs) Scope(name string) (child *Msgs) {
+	m.mustNotBeNil("Scope")
+	return m.child(name, true)
+}
+
+// ScopeDeferred returns a child list of messages as in Scope whose
+// messages are appended to the receiver only on Commit. Abandoning the
+// child without a Commit leaves the receiver untouched.
+func (m *Msgs) ScopeDeferred(name string) (child *Msgs) {
+	m.mustNotBeNil("ScopeDeferred")
+	return m.child(name, false)
+}
+
+// child returns a scoped child of this list of messages
+func (m *Msgs) child(name string, live bool) (child *Msgs) {
+	if m.scope != nil {
+		name = m.scope.name + "/" + name
+	}
+	child = &Msgs{
+		source:     m.source,
+		labels:     mergeLabels(m.labels, map[string]string{ScopeLabel: name}),
+		strict:     m.strict,
+		timestamps: m.timestamps,
+		caller:     m.caller,
+		ids:        m.ids,
+		now:        m.now,
+		stackDepth: m.stackDepth,
+		maxDescLen: m.maxDescLen,
+		fullDescs:  m.fullDescs,
+		scope:      &scope{name: name, parent: m, live: live},
+	}
+	return
+}
+
+// propagate appends the provided message to the parent of a live scope
+func (m *Msgs) propagate(item *msg) {
+	if m.scope == nil || !m.scope.live {
+		return
+	}
+	m.scope.parent.Merge(&Msgs{Items: []*msg{item}})
+}
+
+// Commit appends the messages of a child returned by ScopeDeferred to
+// its parent. Only the messages added since the last Commit are
+// appended. It is a no-op for other lists of messages.
+func (m *Msgs) Commit() (u *Msgs) {
+	m.mustNotBeNil("Commit")
+	if m.scope == nil || m.scope.live {
+		return m
+	}
+	if m.scope.committed > len(m.Items) {
+		m.scope.committed = 0
+	}
+	m.scope.parent.Merge(&Msgs{Items: m.Items[m.scope.committed:]})
+	m.scope.committed = len(m.Items)
+	return m
+}
+
+// ByScope returns the non nil messages keyed by their scope. Messages
+// without a scope are keyed by an empty string.
+func (m Msgs) ByScope() (scopes map[string]Msgs) {
+	scopes = map[string]Msgs{}
+	for _, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		s := scopes[item.Labels[ScopeLabel]]
+		s.Items = append(s.Items, item)
+		scopes[item.Labels[ScopeLabel]] = s
+	}
+	return
+}
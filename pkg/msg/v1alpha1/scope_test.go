@@ -0,0 +1,102 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+)
+
+// scopeDescs returns the descriptions of the messages of the provided
+// scope
+func scopeDescs(m *Msgs, scope string) string {
+	s := m.ByScope()[scope]
+	return descs(&s)
+}
+
+func TestMsgsScope(t *testing.T) {
+	m := (&Msgs{}).WithSource("provisioner")
+	m.AddInfo("start")
+	validate := m.Scope("validate")
+	validate.AddInfo("v1")
+	if descs(m) != "start,v1" {
+		t.Fatalf("Test failed: expected live scope to reach the parent: actual '%s'", descs(m))
+	}
+	pool := m.Scope("create-pool")
+	disks := pool.Scope("disks")
+	pool.AddWarn("p1")
+	disks.AddError(errors.New("d1"))
+	validate.AddInfo("v2")
+	m.AddInfo("end")
+
+	tests := map[string]struct {
+		scope    string
+		expected string
+	}{
+		"101": {"", "start,end"},
+		"102": {"validate", "v1,v2"},
+		"103": {"create-pool", "p1"},
+		"104": {"create-pool/disks", "d1"},
+		"105": {"unknown", ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if scopeDescs(m, mock.scope) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, scopeDescs(m, mock.scope))
+			}
+		})
+	}
+	if descs(m) != "start,v1,p1,d1,v2,end" || descs(pool) != "p1,d1" || len(m.ByScope()) != 4 {
+		t.Fatalf("Test failed: expected messages in order: actual '%s' '%s'", descs(m), descs(pool))
+	}
+	if m.Items[3].Source != "provisioner" {
+		t.Fatalf("Test failed: expected child to inherit the source: actual '%s'", m.Items[3].Source)
+	}
+}
+
+func TestMsgsScopeDeferred(t *testing.T) {
+	m := &Msgs{}
+	m.AddInfo("start")
+	abandoned := m.ScopeDeferred("validate")
+	abandoned.AddError(errors.New("v1"))
+
+	target := m.ScopeDeferred("create-target")
+	target.AddInfo("t1").AddWarn("t2")
+	luns := target.Scope("luns")
+	luns.AddInfo("l1")
+	if descs(m) != "start" || descs(target) != "t1,t2,l1" {
+		t.Fatalf("Test failed: expected parent to be untouched before commit: actual '%s' '%s'", descs(m), descs(target))
+	}
+	target.Commit()
+	target.AddInfo("t3")
+	target.Commit().Commit()
+	if descs(m) != "start,t1,t2,l1,t3" {
+		t.Fatalf("Test failed: expected committed messages once: actual '%s'", descs(m))
+	}
+	if scopeDescs(m, "create-target/luns") != "l1" || scopeDescs(m, "validate") != "" {
+		t.Fatalf("Test failed: expected nested scope and no abandoned messages: actual '%v'", m.ByScope())
+	}
+	if m.Commit() != m || (&Msgs{}).Scope("s").Commit() == nil {
+		t.Fatalf("Test failed: expected commit to be a no-op for other lists")
+	}
+	c := luns.Clone()
+	c.AddInfo("l2")
+	if target.Contains(InfoMsg, "l2") {
+		t.Fatalf("Test failed: expected a clone to be detached from the parent")
+	}
+}
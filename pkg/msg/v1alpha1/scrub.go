@@ -0,0 +1,78 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sync"
+)
+
+var (
+	scrubberMu sync.RWMutex
+	// scrubber scrubs the descriptions of messages as they are added
+	scrubber func(string) string
+)
+
+// SetScrubber sets the function that scrubs the description and the
+// error text of every message as it is added e.g. to mask addresses or
+// credentials. Unlike Redact, the original text is never stored in any
+// list of messages; an error whose text is scrubbed is replaced by one
+// holding only the scrubbed text. It wraps the sentinel registered via
+// RegisterError that the original error matches if any, such that
+// errors.Is still matches the sentinel. A message whose description is scrubbed
+// to an empty string is not added, same as a message added with an
+// empty description. The description of a lazy message is scrubbed once
+// rendered. A nil function removes the scrubber.
+//
+// Messages added before the call are not scrubbed.
+func SetScrubber(fn func(string) string) {
+	scrubberMu.Lock()
+	defer scrubberMu.Unlock()
+	scrubber = fn
+}
+
+// currentScrubber returns the scrubber if any
+func currentScrubber() func(string) string {
+	scrubberMu.RLock()
+	defer scrubberMu.RUnlock()
+	return scrubber
+}
+
+// scrub scrubs the description and the error of the provided message
+// as per the current scrubber. It returns false if the message should
+// not be added since its description was scrubbed to an empty string.
+func scrub(item *msg) (ok bool) {
+	fn := currentScrubber()
+	if fn == nil {
+		return true
+	}
+	if item.lazy != nil {
+		inner := item.lazy.fn
+		item.lazy.fn = func() string { return fn(render(inner)) }
+	} else {
+		item.Desc = fn(item.Desc)
+		if len(item.Desc) == 0 {
+			return false
+		}
+	}
+	if item.Err != nil {
+		s := item.Err.Error()
+		if scrubbed := fn(s); scrubbed != s {
+			item.Err = restoreError(scrubbed, registeredCode(item.Err))
+		}
+	}
+	return true
+}
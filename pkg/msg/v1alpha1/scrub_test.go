@@ -0,0 +1,132 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// mockIPScrubber masks ipv4 addresses
+func mockIPScrubber(desc string) string {
+	return regexp.MustCompile(`\b\d{1,3}(\.\d{1,3}){3}\b`).ReplaceAllString(desc, "<ip>")
+}
+
+// mockSecretScrubber empties descriptions mentioning a secret
+func mockSecretScrubber(desc string) string {
+	if strings.Contains(desc, "secret") {
+		return ""
+	}
+	return desc
+}
+
+func TestSetScrubber(t *testing.T) {
+	refused := errors.New("dial 10.0.0.1:3260: connection refused")
+	tests := map[string]struct {
+		scrubber func(string) string
+		add      func(m *Msgs)
+		expected string
+	}{
+		"101": {nil, func(m *Msgs) { m.AddInfo("target 10.0.0.1") }, "target 10.0.0.1"},
+		"102": {mockIPScrubber, func(m *Msgs) { m.AddInfo("target 10.0.0.1") }, "target <ip>"},
+		"103": {mockIPScrubber, func(m *Msgs) { m.AddWarn("portal 10.0.0.1 and 10.0.0.2") }, "portal <ip> and <ip>"},
+		"104": {mockIPScrubber, func(m *Msgs) { m.AddSkip("10.0.0.1 is reserved") }, "<ip> is reserved"},
+		"105": {mockIPScrubber, func(m *Msgs) { m.AddError(refused) }, "dial <ip>:3260: connection refused"},
+		"106": {mockIPScrubber, func(m *Msgs) { m.AddErrorWithContext("iscsi login", refused) }, "iscsi login: dial <ip>:3260: connection refused"},
+		"107": {mockSecretScrubber, func(m *Msgs) { m.AddInfo("secret is abc").AddInfo("i1") }, "i1"},
+		"108": {mockSecretScrubber, func(m *Msgs) { m.AddError(errors.New("bad secret")) }, ""},
+		"109": {mockIPScrubber, func(m *Msgs) { m.AddInfoIff(true, "target %s", "10.0.0.1") }, "target <ip>"},
+		"110": {mockIPScrubber, func(m *Msgs) { m.AddErrorIff(true, "login to %s failed", "10.0.0.1") }, "login to <ip> failed"},
+		"111": {mockIPScrubber, func(m *Msgs) { m.AddWarnOnce("portal 10.0.0.1").AddWarnOnce("portal 10.0.0.2") }, "portal <ip>"},
+		"112": {mockIPScrubber, func(m *Msgs) { m.AddInfoLazy(func() string { return "target 10.0.0.1" }) }, "target <ip>"},
+		"113": {mockSecretScrubber, func(m *Msgs) { m.AddSkipIff(true, "%s expired", "secret") }, ""},
+		"114": {mockIPScrubber, func(m *Msgs) { m.Append(MsgView{Type: WarnMsg, Desc: "portal 10.0.0.1"}) }, "portal <ip>"},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			SetScrubber(mock.scrubber)
+			defer SetScrubber(nil)
+			m := &Msgs{}
+			mock.add(m)
+			if actual := strings.Join(m.Descriptions(), ","); actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSetScrubberError(t *testing.T) {
+	SetScrubber(mockIPScrubber)
+	defer SetScrubber(nil)
+	refused := errors.New("dial 10.0.0.1:3260: connection refused")
+	m := (&Msgs{}).AddError(refused).AddWarnOnce("portal 10.0.0.1")
+	err := m.Items[0].Err
+	if err.Error() != "dial <ip>:3260: connection refused" || errors.Is(err, refused) || errors.Unwrap(err) != nil {
+		t.Fatalf("Test failed: expected scrubbed error not retaining the original: actual '%#v'", err)
+	}
+	b, err := json.Marshal(m)
+	if err != nil || strings.Contains(string(b), "10.0.0.1") {
+		t.Fatalf("Test failed: expected original text to not be retained: actual '%s' '%v'", b, err)
+	}
+	for k := range m.seen.keys {
+		if strings.Contains(k.desc, "10.0.0.1") {
+			t.Fatalf("Test failed: expected original text to not be retained as key: actual '%v'", k)
+		}
+	}
+}
+
+func TestSetScrubberRegisteredError(t *testing.T) {
+	SetScrubber(mockIPScrubber)
+	defer SetScrubber(nil)
+	quota := fmt.Errorf("pool 10.0.0.1: %w", errMockQuotaExceeded)
+	err := (&Msgs{}).AddError(quota).Items[0].Err
+	if err.Error() != "pool <ip>: quota exceeded" || !errors.Is(err, errMockQuotaExceeded) || errors.Is(err, quota) {
+		t.Fatalf("Test failed: expected scrubbed error matching only the registered sentinel: actual '%#v'", err)
+	}
+}
+
+func TestSetScrubberConcurrent(t *testing.T) {
+	defer SetScrubber(nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetScrubber(mockIPScrubber)
+			SetScrubber(nil)
+		}()
+		go func() {
+			defer wg.Done()
+			m := &Msgs{}
+			for j := 0; j < 10; j++ {
+				m.AddInfo("target 10.0.0.1")
+			}
+			for _, d := range m.Descriptions() {
+				if d != "target 10.0.0.1" && d != "target <ip>" {
+					t.Errorf("Test failed: expected whole description to be scrubbed or not: actual '%s'", d)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
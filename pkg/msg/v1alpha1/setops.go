@@ -0,0 +1,48 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// suppressionKeyOf returns the key that matches the provided message
+// against a list of known messages. Messages having a code are matched
+// by their type and code regardless of their description while the
+// others are matched by their type and description.
+func suppressionKeyOf(given *msg) msgKey {
+	if len(given.Code) != 0 {
+		return msgKey{mtype: given.Mtype, code: given.Code}
+	}
+	return msgKey{mtype: given.Mtype, desc: given.Description()}
+}
+
+// Subtract returns the messages that are not in the provided messages
+// e.g. a list of known and accepted warnings. Messages are matched by
+// their type and code if they have a code and by their type and
+// description otherwise. Duplicates are matched as many times as they
+// occur e.g. subtracting one warning from two identical ones results
+// in one warning. The order of the receiver is preserved and neither
+// list is modified.
+func (m Msgs) Subtract(other Msgs) (s Msgs) {
+	return partition(m.Items, countKeys(other.Items, suppressionKeyOf), suppressionKeyOf, false)
+}
+
+// Intersect returns the messages that are also in the provided
+// messages. Messages are matched as in Subtract such that a message
+// that occurs twice in the receiver and once in the provided messages
+// is returned once. The order of the receiver is preserved and neither
+// list is modified.
+func (m Msgs) Intersect(other Msgs) (i Msgs) {
+	return partition(m.Items, countKeys(other.Items, suppressionKeyOf), suppressionKeyOf, true)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMsgsSubtractIntersect(t *testing.T) {
+	tests := map[string]struct {
+		m, other          *Msgs
+		expectedSubtract  string
+		expectedIntersect string
+	}{
+		"101": {(&Msgs{}).AddWarn("w1").AddInfo("i1").AddWarn("w1").AddWarn("w1"),
+			(&Msgs{}).AddWarn("w1").AddWarn("w1"),
+			"i1,w1", "w1,w1"},
+		"102": {(&Msgs{}).AddWarnCode("Degraded", "pool p1 is degraded").AddWarnCode("Degraded", "pool p2 is degraded").AddWarn("w1"),
+			(&Msgs{}).AddWarnCode("Degraded", "pool is degraded"),
+			"pool p2 is degraded,w1", "pool p1 is degraded"},
+		"103": {(&Msgs{}).AddWarnCode("Degraded", "w1").AddWarn("w1"),
+			(&Msgs{}).AddWarn("w1"),
+			"w1", "w1"},
+		"104": {(&Msgs{}).AddWarn("w1").AddInfo("w1").AddError(errors.New("e1")),
+			(&Msgs{}).AddInfo("w1").AddWarn("e1"),
+			"w1,e1", "w1"},
+		"105": {(&Msgs{}).AddWarn("w1").AddInfo("i1"), &Msgs{}, "w1,i1", ""},
+		"106": {&Msgs{}, (&Msgs{}).AddWarn("w1"), "", ""},
+		"107": {&Msgs{Items: []*msg{nil, {Mtype: WarnMsg, Desc: "w1"}}}, &Msgs{Items: []*msg{nil}}, "w1", ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			before := len(mock.m.Items)
+			s := mock.m.Subtract(*mock.other)
+			if actual := strings.Join(s.Descriptions(), ","); actual != mock.expectedSubtract {
+				t.Fatalf("Test '%s' failed: expected subtract '%s': actual '%s'", name, mock.expectedSubtract, actual)
+			}
+			i := mock.m.Intersect(*mock.other)
+			if actual := strings.Join(i.Descriptions(), ","); actual != mock.expectedIntersect {
+				t.Fatalf("Test '%s' failed: expected intersect '%s': actual '%s'", name, mock.expectedIntersect, actual)
+			}
+			if len(mock.m.Items) != before {
+				t.Fatalf("Test '%s' failed: expected receiver to be unmodified: actual %d messages", name, len(mock.m.Items))
+			}
+		})
+	}
+}
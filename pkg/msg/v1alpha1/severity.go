@@ -0,0 +1,101 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sync"
+)
+
+// DefaultSeverity is the severity of a message type whose severity
+// was not set; it is same as that of InfoMsg
+const DefaultSeverity = 20
+
+var (
+	severitiesMu sync.RWMutex
+	// severities holds the severity of message types where a message
+	// type with a higher severity is more important
+	severities = map[MsgType]int{
+		SkipMsg:        10,
+		InfoMsg:        20,
+		ProgressMsg:    20,
+		DeprecationMsg: 30,
+		WarnMsg:        40,
+		ErrMsg:         50,
+	}
+)
+
+// Severity returns the severity of the provided message type
+func Severity(t MsgType) int {
+	severitiesMu.RLock()
+	defer severitiesMu.RUnlock()
+	s, found := severities[t]
+	if !found {
+		return DefaultSeverity
+	}
+	return s
+}
+
+// SetSeverity sets the severity of the provided message type. It can
+// be used to position custom message types relative to the declared
+// ones.
+func SetSeverity(t MsgType, s int) {
+	severitiesMu.Lock()
+	defer severitiesMu.Unlock()
+	severities[t] = s
+}
+
+// AtLeast returns a predicate that is true for messages whose severity
+// is at or above that of the provided message type
+func AtLeast(t MsgType) msgPredicate {
+	min := Severity(t)
+	return func(given *msg) bool {
+		if given == nil {
+			return false
+		}
+		return Severity(given.Mtype) >= min
+	}
+}
+
+// AboveVerbosity filters messages whose severity is at or above that
+// of the provided message type
+func (m Msgs) AboveVerbosity(t MsgType) (f Msgs) {
+	return m.Filter(AtLeast(t))
+}
+
+// LogAtLeast logs non nil messages whose severity is at or above that
+// of the provided message type
+func (m Msgs) LogAtLeast(t MsgType, l func(string, ...interface{})) {
+	m.logIf(AtLeast(t), l)
+}
+
+// SetLogThreshold sets the least severe message type that is logged by
+// Log, LogNonInfos, LogNonErrors, LogErrors and LogLeveled. An empty
+// message type logs every message.
+func (m *Msgs) SetLogThreshold(t MsgType) (u *Msgs) {
+	m.mustNotBeNil("SetLogThreshold")
+	m.threshold = t
+	return m
+}
+
+// atThreshold returns true if the provided message is at or above the
+// log threshold
+func (m Msgs) atThreshold(given *msg) bool {
+	if len(m.threshold) == 0 {
+		return true
+	}
+	return Severity(given.Mtype) >= Severity(m.threshold)
+}
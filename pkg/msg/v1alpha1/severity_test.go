@@ -0,0 +1,86 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMsgsAboveVerbosity(t *testing.T) {
+	SetSeverity("trace", 0)
+	SetSeverity("fatal", 100)
+	m := (&Msgs{}).AddSkip("s1").AddInfo("i1").AddDeprecation("d1").AddWarn("w1").AddError(errors.New("e1"))
+	m.Items = append(m.Items, &msg{Mtype: "custom", Desc: "c1"})
+
+	tests := map[string]struct {
+		threshold MsgType
+		expected  string
+	}{
+		"101": {"trace", "s1,i1,d1,w1,e1,c1"},
+		"102": {SkipMsg, "s1,i1,d1,w1,e1,c1"},
+		"103": {InfoMsg, "i1,d1,w1,e1,c1"},
+		"104": {DeprecationMsg, "d1,w1,e1"},
+		"105": {WarnMsg, "w1,e1"},
+		"106": {ErrMsg, "e1"},
+		"107": {"fatal", ""},
+		"108": {"custom", "i1,d1,w1,e1,c1"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := m.AboveVerbosity(mock.threshold)
+			if descs(&f) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(&f))
+			}
+			var lines []string
+			m.LogAtLeast(mock.threshold, mockLogger(&lines))
+			if len(lines) != len(f.Items) {
+				t.Fatalf("Test '%s' failed: expected logged lines %d: actual %d", name, len(f.Items), len(lines))
+			}
+		})
+	}
+}
+
+func TestMsgsSetLogThreshold(t *testing.T) {
+	tests := map[string]struct {
+		threshold        MsgType
+		expectedLog      int
+		expectedNonInfos int
+		expectedErrors   int
+	}{
+		"101": {"", 4, 3, 1},
+		"102": {SkipMsg, 4, 3, 1},
+		"103": {InfoMsg, 3, 2, 1},
+		"104": {WarnMsg, 2, 2, 1},
+		"105": {ErrMsg, 1, 1, 1},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := (&Msgs{}).SetLogThreshold(mock.threshold).AddSkip("s1").AddInfo("i1").AddWarn("w1").AddError(errors.New("e1"))
+			var log, nonInfos, errs []string
+			m.Log(mockLogger(&log))
+			m.LogNonInfos(mockLogger(&nonInfos))
+			m.LogErrors(mockLogger(&errs))
+			if len(log) != mock.expectedLog || len(nonInfos) != mock.expectedNonInfos || len(errs) != mock.expectedErrors {
+				t.Fatalf("Test '%s' failed: expected %d %d %d: actual %d %d %d", name,
+					mock.expectedLog, mock.expectedNonInfos, mock.expectedErrors, len(log), len(nonInfos), len(errs))
+			}
+		})
+	}
+}
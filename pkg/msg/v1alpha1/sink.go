@@ -0,0 +1,219 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// sinkQueueBatches is the number of batches that a BufferedSink queues
+// before it starts dropping messages
+const sinkQueueBatches = 4
+
+// Sink receives a copy of every message added to the messages that it
+// is attached to e.g. an audit backend
+type Sink interface {
+	Accept(t MsgType, desc string, err error)
+	Flush() error
+	Close() error
+}
+
+// AttachSink forwards every message subsequently added to the provided
+// sink, see OnAdd
+func (m *Msgs) AttachSink(s Sink) (u *Msgs) {
+	m.mustNotBeNil("AttachSink")
+	if s == nil {
+		return m
+	}
+	return m.OnAdd(s.Accept)
+}
+
+// sinkEntry is a message accepted by a BufferedSink
+type sinkEntry struct {
+	mtype MsgType
+	desc  string
+	err   error
+}
+
+// BufferedSink is a Sink that accepts messages without blocking and
+// forwards them in batches to an underlying sink from a background
+// goroutine. A batch is forwarded and the underlying sink flushed when
+// the batch is full, when the flush interval elapses, on Flush and on
+// Close. Messages accepted while the internal queue is full are dropped
+// and counted.
+type BufferedSink struct {
+	underlying Sink
+	maxBatch   int
+
+	queue   chan sinkEntry
+	flushes chan chan error
+	done    chan struct{}
+	stop    func() // stops the flush timer
+
+	// mu guards closed; Accept holds it for reading while queueing so
+	// that the queue is never closed in the middle of a send
+	mu     sync.RWMutex
+	closed bool
+
+	dropped int64 // accessed atomically
+	err     error // last error of the underlying sink; owned by run
+}
+
+// NewBufferedSink returns a new sink that forwards messages in batches
+// of at most maxBatch messages to the underlying sink and flushes it at
+// least every interval. The timer is disabled if interval is not
+// positive.
+func NewBufferedSink(underlying Sink, maxBatch int, interval time.Duration) *BufferedSink {
+	var (
+		ticks <-chan time.Time
+		stop  = func() {}
+	)
+	if interval > 0 {
+		t := time.NewTicker(interval)
+		ticks, stop = t.C, t.Stop
+	}
+	return newBufferedSink(underlying, maxBatch, ticks, stop)
+}
+
+// newBufferedSink returns a new sink whose batches are flushed whenever
+// the provided channel ticks
+func newBufferedSink(underlying Sink, maxBatch int, ticks <-chan time.Time, stop func()) *BufferedSink {
+	if maxBatch < 1 {
+		maxBatch = 1
+	}
+	b := &BufferedSink{
+		underlying: underlying,
+		maxBatch:   maxBatch,
+		queue:      make(chan sinkEntry, maxBatch*sinkQueueBatches),
+		flushes:    make(chan chan error),
+		done:       make(chan struct{}),
+		stop:       stop,
+	}
+	go b.run(ticks)
+	return b
+}
+
+// run batches the queued messages till the queue is closed
+func (b *BufferedSink) run(ticks <-chan time.Time) {
+	defer close(b.done)
+	batch := make([]sinkEntry, 0, b.maxBatch)
+	for {
+		select {
+		case e, ok := <-b.queue:
+			if !ok {
+				b.forward(batch)
+				return
+			}
+			if batch = append(batch, e); len(batch) >= b.maxBatch {
+				batch = b.forward(batch)
+			}
+		case <-ticks:
+			batch = b.forward(batch)
+		case reply := <-b.flushes:
+			// forward the messages queued before the flush was requested
+			for n := len(b.queue); n > 0; n-- {
+				if batch = append(batch, <-b.queue); len(batch) >= b.maxBatch {
+					batch = b.forward(batch)
+				}
+			}
+			batch = b.forward(batch)
+			reply <- b.err
+			b.err = nil
+		}
+	}
+}
+
+// forward passes the provided batch to the underlying sink and flushes
+// it. It returns the emptied batch.
+func (b *BufferedSink) forward(batch []sinkEntry) []sinkEntry {
+	if len(batch) == 0 {
+		return batch
+	}
+	for _, e := range batch {
+		b.underlying.Accept(e.mtype, e.desc, e.err)
+	}
+	if err := b.underlying.Flush(); err != nil {
+		b.err = err
+	}
+	return batch[:0]
+}
+
+// Accept queues the provided message. The message is dropped if the
+// queue is full or the sink is closed.
+func (b *BufferedSink) Accept(t MsgType, desc string, err error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		atomic.AddInt64(&b.dropped, 1)
+		return
+	}
+	select {
+	case b.queue <- sinkEntry{mtype: t, desc: desc, err: err}:
+	default:
+		atomic.AddInt64(&b.dropped, 1)
+	}
+}
+
+// Dropped returns the number of messages dropped so far
+func (b *BufferedSink) Dropped() int {
+	return int(atomic.LoadInt64(&b.dropped))
+}
+
+// Flush forwards the queued messages to the underlying sink and flushes
+// it. It returns the last error of the underlying sink since the
+// previous Flush if any.
+func (b *BufferedSink) Flush() error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return nil
+	}
+	reply := make(chan error)
+	b.flushes <- reply
+	return <-reply
+}
+
+// Close forwards the queued messages, flushes and closes the underlying
+// sink. The returned error reports the messages that were dropped along
+// with the last error of the underlying sink.
+func (b *BufferedSink) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.queue)
+	b.mu.Unlock()
+	<-b.done
+	b.stop()
+
+	err := b.err
+	if cerr := b.underlying.Close(); cerr != nil {
+		err = cerr
+	}
+	if dropped := b.Dropped(); dropped != 0 {
+		if err != nil {
+			return fmt.Errorf("sink dropped %d messages: %s", dropped, err)
+		}
+		return fmt.Errorf("sink dropped %d messages", dropped)
+	}
+	return err
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockSink records the accepted messages and signals every flush
+type mockSink struct {
+	mu       sync.Mutex
+	accepted []string
+	flushed  chan int // receives the count of accepted messages
+	block    chan struct{}
+	blocked  chan struct{}
+	closed   bool
+	err      error
+}
+
+func newMockSink() *mockSink {
+	return &mockSink{flushed: make(chan int, 100)}
+}
+
+func (s *mockSink) Accept(t MsgType, desc string, err error) {
+	if s.block != nil {
+		s.blocked <- struct{}{}
+		<-s.block
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accepted = append(s.accepted, string(t)+":"+desc)
+}
+
+func (s *mockSink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flushed <- len(s.accepted)
+	return s.err
+}
+
+func (s *mockSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *mockSink) descs() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return strings.Join(s.accepted, ",")
+}
+
+// waitFlush waits for the next flush of the provided sink and returns
+// the count of messages accepted by then
+func waitFlush(t *testing.T, s *mockSink) int {
+	select {
+	case n := <-s.flushed:
+		return n
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Test failed: expected a flush of the underlying sink")
+	}
+	return 0
+}
+
+func TestBufferedSinkSizeFlush(t *testing.T) {
+	s := newMockSink()
+	b := newBufferedSink(s, 2, nil, func() {})
+	m := (&Msgs{}).AttachSink(b)
+	m.AddInfo("i1").AddWarn("w1").AddSkip("s1")
+	if n := waitFlush(t, s); n != 2 {
+		t.Fatalf("Test failed: expected a flush of 2 messages: actual %d", n)
+	}
+	if err := b.Close(); err != nil {
+		t.Fatalf("Test failed: expected no error on close: actual '%v'", err)
+	}
+	if s.descs() != "info:i1,warn:w1,skip:s1" || !s.closed {
+		t.Fatalf("Test failed: expected closed sink with 'info:i1,warn:w1,skip:s1': actual '%s' %t", s.descs(), s.closed)
+	}
+}
+
+func TestBufferedSinkTimerFlush(t *testing.T) {
+	s := newMockSink()
+	ticks := make(chan time.Time)
+	var stopped bool
+	b := newBufferedSink(s, 100, ticks, func() { stopped = true })
+	m := (&Msgs{}).AttachSink(b)
+	m.AddInfo("i1").AddError(errors.New("e1"))
+	// the tick is received only after the messages were queued since the
+	// unbuffered send blocks till the sink's goroutine selects it
+	for len(b.queue) != 0 {
+		time.Sleep(time.Millisecond)
+	}
+	ticks <- time.Time{}
+	if n := waitFlush(t, s); n != 2 {
+		t.Fatalf("Test failed: expected a timed flush of 2 messages: actual %d", n)
+	}
+	ticks <- time.Time{}
+	b.Close()
+	if !stopped || s.descs() != "info:i1,error:e1" {
+		t.Fatalf("Test failed: expected stopped timer and 'info:i1,error:e1': actual %t '%s'", stopped, s.descs())
+	}
+}
+
+func TestBufferedSinkFlushAndClose(t *testing.T) {
+	s := newMockSink()
+	b := newBufferedSink(s, 100, nil, func() {})
+	m := (&Msgs{}).AttachSink(b)
+	m.AddInfo("i1")
+	if err := b.Flush(); err != nil || s.descs() != "info:i1" {
+		t.Fatalf("Test failed: expected 'info:i1' on flush: actual '%s' '%v'", s.descs(), err)
+	}
+	s.err = errors.New("backend down")
+	m.AddInfo("i2").AddInfo("i3")
+	if err := b.Close(); err == nil || err.Error() != "backend down" {
+		t.Fatalf("Test failed: expected the underlying error on close: actual '%v'", err)
+	}
+	if s.descs() != "info:i1,info:i2,info:i3" {
+		t.Fatalf("Test failed: expected close to drain the queue: actual '%s'", s.descs())
+	}
+	m.AddInfo("i4")
+	if b.Dropped() != 1 || b.Close() != nil || b.Flush() != nil {
+		t.Fatalf("Test failed: expected messages accepted after close to be dropped: actual %d", b.Dropped())
+	}
+}
+
+func TestBufferedSinkBackpressure(t *testing.T) {
+	s := newMockSink()
+	s.block, s.blocked = make(chan struct{}), make(chan struct{})
+	b := newBufferedSink(s, 1, nil, func() {})
+	b.Accept(InfoMsg, "i0", nil)
+	// the sink's goroutine is now blocked forwarding the first message
+	<-s.blocked
+	capacity := sinkQueueBatches
+	for i := 0; i < capacity+5; i++ {
+		b.Accept(InfoMsg, "i", nil)
+	}
+	if b.Dropped() != 5 {
+		t.Fatalf("Test failed: expected 5 dropped messages: actual %d", b.Dropped())
+	}
+	go func() {
+		for range s.blocked {
+		}
+	}()
+	close(s.block)
+	err := b.Close()
+	if err == nil || err.Error() != "sink dropped 5 messages" {
+		t.Fatalf("Test failed: expected dropped messages to be reported: actual '%v'", err)
+	}
+	close(s.blocked)
+	if n := len(strings.Split(s.descs(), ",")); n != capacity+1 {
+		t.Fatalf("Test failed: expected %d forwarded messages: actual %d", capacity+1, n)
+	}
+}
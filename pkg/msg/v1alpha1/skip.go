@@ -0,0 +1,97 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sync"
+)
+
+// SkipReason represents why an operation was skipped
+type SkipReason string
+
+const (
+	// AlreadyExists represents an operation that was skipped since its
+	// result already exists
+	AlreadyExists SkipReason = "AlreadyExists"
+	// NotApplicable represents an operation that does not apply
+	NotApplicable SkipReason = "NotApplicable"
+	// Disabled represents an operation that was disabled
+	Disabled SkipReason = "Disabled"
+	// DependencyMissing represents an operation whose precondition was
+	// not met
+	DependencyMissing SkipReason = "DependencyMissing"
+)
+
+var (
+	skipReasonsMu sync.RWMutex
+	// skipReasons holds the valid skip reasons
+	skipReasons = map[SkipReason]bool{
+		AlreadyExists:     true,
+		NotApplicable:     true,
+		Disabled:          true,
+		DependencyMissing: true,
+	}
+)
+
+// RegisterSkipReason registers the provided custom skip reason as a
+// valid one
+func RegisterSkipReason(r SkipReason) {
+	skipReasonsMu.Lock()
+	defer skipReasonsMu.Unlock()
+	skipReasons[r] = true
+}
+
+// IsValid returns true if the skip reason is either a declared or a
+// registered one
+func (r SkipReason) IsValid() bool {
+	skipReasonsMu.RLock()
+	defer skipReasonsMu.RUnlock()
+	return skipReasons[r]
+}
+
+// SkipReasonIs returns a predicate that is true for skip messages with
+// the provided reason
+func SkipReasonIs(r SkipReason) msgPredicate {
+	return func(given *msg) bool {
+		return IsSkip(given) && given.Reason == r
+	}
+}
+
+// AddSkipReason appends a new SkipMsg with the provided reason and
+// description. The reason is recorded as is even if it is not valid.
+func (m *Msgs) AddSkipReason(r SkipReason, s string) (u *Msgs) {
+	m.mustNotBeNil("AddSkipReason")
+	if len(s) == 0 {
+		return m
+	}
+	return m.add(m.newMsg(msg{Mtype: SkipMsg, Desc: s, Reason: r}))
+}
+
+// SkipsByReason returns the skip messages grouped by their reason.
+// Skips without a reason are grouped against an empty reason.
+func (m Msgs) SkipsByReason() (b map[SkipReason]Msgs) {
+	b = map[SkipReason]Msgs{}
+	for _, item := range m.Items {
+		if !IsSkip(item) {
+			continue
+		}
+		s := b[item.Reason]
+		s.Items = append(s.Items, item)
+		b[item.Reason] = s
+	}
+	return
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMsgsSkipReasons(t *testing.T) {
+	m := (&Msgs{}).AddSkipReason(AlreadyExists, "volume exists").AddSkip("s1").
+		AddSkipReason(DependencyMissing, "pool missing").AddSkipReason(AlreadyExists, "snapshot exists").
+		AddWarn("w1").AddSkipReason(Disabled, "")
+	tests := map[string]struct {
+		reason   SkipReason
+		expected string
+	}{
+		"101": {AlreadyExists, "volume exists,snapshot exists"},
+		"102": {DependencyMissing, "pool missing"},
+		"103": {Disabled, ""},
+		"104": {"", "s1"},
+		"105": {NotApplicable, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := m.Filter(SkipReasonIs(mock.reason))
+			if descs(&f) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(&f))
+			}
+			b := m.SkipsByReason()[mock.reason]
+			if descs(&b) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected group '%s': actual '%s'", name, mock.expected, descs(&b))
+			}
+		})
+	}
+	if len(m.SkipsByReason()) != 3 {
+		t.Fatalf("Test failed: expected 3 groups: actual %d", len(m.SkipsByReason()))
+	}
+}
+
+func TestSkipReasonIsValid(t *testing.T) {
+	if !DependencyMissing.IsValid() || SkipReason("Throttled").IsValid() {
+		t.Fatalf("Test failed: expected only declared skip reasons to be valid")
+	}
+	RegisterSkipReason("Throttled")
+	defer func() {
+		skipReasonsMu.Lock()
+		delete(skipReasons, "Throttled")
+		skipReasonsMu.Unlock()
+	}()
+	if !SkipReason("Throttled").IsValid() {
+		t.Fatalf("Test failed: expected registered skip reason to be valid")
+	}
+}
+
+func TestMsgsSkipReasonSerialization(t *testing.T) {
+	m := (&Msgs{}).AddSkipReason(AlreadyExists, "volume exists").AddSkip("s1")
+	if !strings.Contains(m.String(), "reason: AlreadyExists") {
+		t.Fatalf("Test failed: expected reason in yaml: actual '%s'", m)
+	}
+	b, err := json.Marshal(m)
+	if err != nil || string(b) != `{"items":[{"type":"skip","desc":"volume exists","reason":"AlreadyExists"},{"type":"skip","desc":"s1"}]}` {
+		t.Fatalf("Test failed: expected reason in json: actual '%s' '%v'", string(b), err)
+	}
+	for name, roundTrip := range mockRoundTrips() {
+		actual, err := roundTrip(m)
+		if err != nil || len(actual.Items) != 2 || actual.Items[0].Reason != AlreadyExists || actual.Items[1].Reason != "" {
+			t.Fatalf("Test '%s' failed: expected reason to round trip: actual '%v' '%v'", name, actual, err)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// SourceIs returns a predicate that is true for messages reported by
+// the provided source
+func SourceIs(s string) msgPredicate {
+	return func(given *msg) bool {
+		if given == nil {
+			return false
+		}
+		return given.Source == s
+	}
+}
+
+// WithSource sets the source that is stamped on messages subsequently
+// added without a source
+func (m *Msgs) WithSource(s string) (u *Msgs) {
+	m.mustNotBeNil("WithSource")
+	m.source = s
+	return m
+}
+
+// MergeFrom merges the passed messages into the receiver while
+// stamping the provided source on merged messages that do not have a
+// source. Messages of the passed list are not modified.
+func (m *Msgs) MergeFrom(source string, s *Msgs) (u *Msgs) {
+	m.mustNotBeNil("MergeFrom")
+	if s == nil {
+		return m
+	}
+	stamped := &Msgs{Items: make([]*msg, 0, len(s.Items))}
+	for _, item := range s.Items {
+		if item != nil && len(item.Source) == 0 {
+			c := *item
+			c.Source = source
+			item = &c
+		}
+		stamped.Items = append(stamped.Items, item)
+	}
+	return m.Merge(stamped)
+}
+
+// BySource returns the non nil messages grouped by their source.
+// Messages without a source are grouped against an empty key.
+func (m Msgs) BySource() (b map[string]Msgs) {
+	b = map[string]Msgs{}
+	for _, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		s := b[item.Source]
+		s.Items = append(s.Items, item)
+		b[item.Source] = s
+	}
+	return
+}
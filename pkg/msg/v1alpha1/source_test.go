@@ -0,0 +1,79 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMsgsWithSource(t *testing.T) {
+	m := (&Msgs{}).AddInfo("i0").WithSource("snapshot").AddInfo("i1").AddError(errors.New("e1"))
+	m.Items = append(m.Items, &msg{Mtype: WarnMsg, Desc: "w1", Source: "pool"})
+	m.WithSource("").AddSkip("s1")
+
+	expected := []string{"", "snapshot", "snapshot", "pool", ""}
+	for i, item := range m.Items {
+		if item.Source != expected[i] {
+			t.Fatalf("Test '%d' failed: expected source '%s': actual '%s'", i, expected[i], item.Source)
+		}
+	}
+}
+
+func TestMsgsMergeFrom(t *testing.T) {
+	snapshot := (&Msgs{}).AddInfo("i1").AddError(errors.New("e1"))
+	pool := (&Msgs{}).AddWarn("w1")
+	pool.Items = append(pool.Items, &msg{Mtype: InfoMsg, Desc: "i2", Source: "disk"}, nil)
+	target := (&Msgs{}).WithSource("target").AddInfo("i3")
+
+	m := (&Msgs{}).
+		MergeFrom("snapshot", snapshot).
+		MergeFrom("pool", pool).
+		MergeFrom("deployer", target).
+		MergeFrom("other", nil)
+
+	tests := map[string]struct {
+		source   string
+		expected string
+	}{
+		"101": {"snapshot", "i1,e1"},
+		"102": {"pool", "w1"},
+		"103": {"disk", "i2"},
+		"104": {"target", "i3"},
+		"105": {"deployer", ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := m.Filter(SourceIs(mock.source))
+			if descs(&f) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(&f))
+			}
+			b := m.BySource()[mock.source]
+			if descs(&b) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected by source '%s': actual '%s'", name, mock.expected, descs(&b))
+			}
+		})
+	}
+
+	if len(m.BySource()) != 4 {
+		t.Fatalf("Test failed: expected 4 sources: actual %d", len(m.BySource()))
+	}
+	if snapshot.Items[0].Source != "" {
+		t.Fatalf("Test failed: expected merged messages to be unmodified: actual source '%s'", snapshot.Items[0].Source)
+	}
+}
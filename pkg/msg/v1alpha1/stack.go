@@ -0,0 +1,100 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+)
+
+// stackTracesInJSONFlag is set if stack traces are included in json
+var stackTracesInJSONFlag int32
+
+// IncludeStackTracesInJSON sets whether stack traces of errors are
+// included when messages are marshaled as json. Stack traces are always
+// included in the yaml formatted string of messages.
+func IncludeStackTracesInJSON(include bool) {
+	var flag int32
+	if include {
+		flag = 1
+	}
+	atomic.StoreInt32(&stackTracesInJSONFlag, flag)
+}
+
+// stackTracesInJSON returns true if stack traces are included in json
+func stackTracesInJSON() bool {
+	return atomic.LoadInt32(&stackTracesInJSONFlag) == 1
+}
+
+// WithStackTraces sets whether a stack trace of at most maxStackFrames
+// frames is captured when an ErrMsg is added
+func (m *Msgs) WithStackTraces(enabled bool) (u *Msgs) {
+	m.mustNotBeNil("WithStackTraces")
+	if !enabled {
+		return m.WithStackDepth(0)
+	}
+	return m.WithStackDepth(maxStackFrames)
+}
+
+// WithStackDepth sets the maximum number of frames of the stack trace
+// captured when an ErrMsg is added. Stack traces are not captured if
+// the depth is not positive.
+func (m *Msgs) WithStackDepth(depth int) (u *Msgs) {
+	m.mustNotBeNil("WithStackDepth")
+	if depth < 0 {
+		depth = 0
+	}
+	m.stackDepth = depth
+	return m
+}
+
+// stackOutside returns at most depth frames of the current stack
+// skipping the frames of this package. Each frame is formatted as
+// 'function file:line'.
+func stackOutside(depth int) (stack []string) {
+	for _, f := range framesOutside(depth) {
+		stack = append(stack, fmt.Sprintf("%s %s:%d", f.Function, filepath.Base(f.File), f.Line))
+	}
+	return
+}
+
+// detailedMsg renders a message along with its stack trace
+type detailedMsg msg
+
+// MarshalJSON is an implementation of json.Marshaler interface
+func (d *detailedMsg) MarshalJSON() ([]byte, error) {
+	return (*msg)(d).marshalJSON(true)
+}
+
+// detailedMsgs renders messages along with their stack traces
+type detailedMsgs struct {
+	Items []*detailedMsg `json:"items,omitempty"`
+}
+
+// detailed returns the messages to be rendered along with their stack
+// traces
+func (m Msgs) detailed() (d detailedMsgs) {
+	if m.Items == nil {
+		return
+	}
+	d.Items = make([]*detailedMsg, len(m.Items))
+	for i, item := range m.Items {
+		d.Items[i] = (*detailedMsg)(item)
+	}
+	return
+}
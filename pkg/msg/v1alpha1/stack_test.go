@@ -0,0 +1,113 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMsgsWithStackTraces(t *testing.T) {
+	tests := map[string]struct {
+		m        *Msgs
+		expected int
+	}{
+		"101": {&Msgs{}, 0},
+		"102": {(&Msgs{}).WithStackTraces(true), 2},
+		"103": {(&Msgs{}).WithStackTraces(true).WithStackTraces(false), 0},
+		"104": {(&Msgs{}).WithStackDepth(1), 1},
+		"105": {(&Msgs{}).WithStackDepth(-1), 0},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock.m.AddError(errors.New("e1")).AddWarn("w1")
+			stack := mock.m.Items[0].Stack
+			if mock.expected == 0 {
+				if stack != nil {
+					t.Fatalf("Test '%s' failed: expected no stack: actual '%v'", name, stack)
+				}
+				return
+			}
+			// frames beyond the test function belong to the testing
+			// package
+			if len(stack) < mock.expected || len(stack) > maxStackFrames {
+				t.Fatalf("Test '%s' failed: expected at least %d frames: actual '%v'", name, mock.expected, stack)
+			}
+			if !strings.HasPrefix(stack[0], "github.com/openebs/maya/pkg/msg/v1alpha1.TestMsgsWithStackTraces.func1 stack_test.go:") {
+				t.Fatalf("Test '%s' failed: expected top frame to be the test: actual '%s'", name, stack[0])
+			}
+			if mock.m.Items[1].Stack != nil {
+				t.Fatalf("Test '%s' failed: expected no stack for a warning", name)
+			}
+		})
+	}
+}
+
+func TestMsgsStackTraceOutput(t *testing.T) {
+	m := (&Msgs{}).WithStackTraces(true).AddError(errors.New("e1"))
+	if s := fmt.Sprintf("%+v", *m); !strings.Contains(s, "stack:") {
+		t.Fatalf("Test failed: expected stack in detailed output: actual '%s'", s)
+	}
+	if s := m.Items[0].String(); !strings.Contains(s, "stack:") {
+		t.Fatalf("Test failed: expected stack in message yaml: actual '%s'", s)
+	}
+	if y, _ := m.AllMsgs().YAML(); !strings.Contains(y, "stack:") {
+		t.Fatalf("Test failed: expected stack in all messages yaml: actual '%s'", y)
+	}
+	if s := fmt.Sprintf("%v", *m); strings.Contains(s, "stack") {
+		t.Fatalf("Test failed: expected no stack in compact output: actual '%s'", s)
+	}
+	if b, _ := json.Marshal(m); strings.Contains(string(b), "stack") {
+		t.Fatalf("Test failed: expected no stack in json by default: actual '%s'", string(b))
+	}
+
+	IncludeStackTracesInJSON(true)
+	defer IncludeStackTracesInJSON(false)
+	b, _ := json.Marshal(m)
+	if !strings.Contains(string(b), `"stack":["github.com/openebs/maya/pkg/msg/v1alpha1.TestMsgsStackTraceOutput`) {
+		t.Fatalf("Test failed: expected stack in json: actual '%s'", string(b))
+	}
+	var actual Msgs
+	if err := json.Unmarshal(b, &actual); err != nil || len(actual.Items[0].Stack) != len(m.Items[0].Stack) {
+		t.Fatalf("Test failed: expected stack to round trip: actual '%v' '%v'", actual.Items, err)
+	}
+}
+
+func TestMsgsStackTracesOffAllocs(t *testing.T) {
+	err := errors.New("e1")
+	off := testing.AllocsPerRun(100, func() { (&Msgs{}).AddError(err) })
+	on := testing.AllocsPerRun(100, func() { (&Msgs{}).WithStackTraces(true).AddError(err) })
+	if off >= on {
+		t.Fatalf("Test failed: expected stack traces to allocate only if enabled: actual off %v on %v", off, on)
+	}
+}
+
+func BenchmarkMsgsAddErrorStackTraces(b *testing.B) {
+	err := errors.New("e1")
+	for _, enabled := range []bool{false, true} {
+		b.Run(fmt.Sprintf("enabled=%t", enabled), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				(&Msgs{}).WithStackTraces(enabled).AddError(err)
+			}
+		})
+	}
+}
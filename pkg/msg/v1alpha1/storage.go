@@ -0,0 +1,71 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// msgChunkLen is the number of messages allocated at once by the value
+// storage
+const msgChunkLen = 256
+
+// msgArena allocates messages by value from chunks of msgChunkLen
+// messages. A chunk is never grown once allocated and hence the
+// pointers into it remain valid for as long as they are referenced.
+type msgArena struct {
+	chunk []msg // chunk being filled
+}
+
+// alloc returns a pointer to the next free message of the current
+// chunk, allocating a new chunk if the current one is full
+func (a *msgArena) alloc() *msg {
+	if len(a.chunk) == cap(a.chunk) {
+		a.chunk = make([]msg, 0, msgChunkLen)
+	}
+	a.chunk = a.chunk[:len(a.chunk)+1]
+	return &a.chunk[len(a.chunk)-1]
+}
+
+// newMsg returns a pointer to a copy of the provided message. The copy
+// is allocated from the value storage if enabled and on its own
+// otherwise.
+func (m *Msgs) newMsg(v msg) *msg {
+	var item *msg
+	if m.arena == nil {
+		item = new(msg)
+	} else {
+		item = m.arena.alloc()
+	}
+	*item = v
+	return item
+}
+
+// WithValueStorage stores the added messages by value in chunks of
+// messages instead of allocating every message on its own. This
+// amortizes the allocation of messages when a large number of them
+// are added.
+//
+// Items continue to hold pointers to the messages and these pointers
+// remain valid across later additions, such that the messages returned
+// by Filter, View and the like neither move nor change when more
+// messages are added. However a chunk is released only once none of its
+// messages is referenced; messages dropped or removed from the list
+// retain their chunk until then.
+//
+// Messages added via Merge, Append or the like keep their own storage.
+func WithValueStorage() Option {
+	return func(m *Msgs) {
+		m.arena = &msgArena{}
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"testing"
+)
+
+// mockStorages returns the constructors of messages per storage
+func mockStorages() map[string]func() *Msgs {
+	return map[string]func() *Msgs{
+		"Pointer": func() *Msgs { return NewMsgs() },
+		"Value":   func() *Msgs { return NewMsgs(WithValueStorage()) },
+	}
+}
+
+func TestWithValueStorageFilterAfterAdds(t *testing.T) {
+	for name, build := range mockStorages() {
+		name, build := name, build
+		t.Run(name, func(t *testing.T) {
+			m := build()
+			for i := 0; i < msgChunkLen+10; i++ {
+				m.AddWarn(fmt.Sprintf("w%d", i)).AddInfo(fmt.Sprintf("i%d", i))
+			}
+			f := m.Filter(IsWarn)
+			first, last := m.Items[0], m.Items[len(m.Items)-1]
+			for i := 0; i < 2*msgChunkLen; i++ {
+				m.AddWarn(fmt.Sprintf("later w%d", i))
+			}
+			if len(f.Items) != msgChunkLen+10 {
+				t.Fatalf("Test '%s' failed: expected %d warns: actual %d", name, msgChunkLen+10, len(f.Items))
+			}
+			for i, item := range f.Items {
+				if item != m.Items[2*i] || item.Desc != fmt.Sprintf("w%d", i) {
+					t.Fatalf("Test '%s' failed: expected filtered message %d to remain valid: actual '%#v'", name, i, item)
+				}
+			}
+			if m.Items[0] != first || m.Items[2*(msgChunkLen+10)-1] != last || last.Desc != fmt.Sprintf("i%d", msgChunkLen+9) {
+				t.Fatalf("Test '%s' failed: expected pointers of messages to be stable", name)
+			}
+		})
+	}
+}
+
+func TestWithValueStorageAllocs(t *testing.T) {
+	add := func(m *Msgs) func() {
+		return func() {
+			m.Items = m.Items[:0]
+			for i := 0; i < msgChunkLen; i++ {
+				m.AddInfo("volume is healthy")
+			}
+		}
+	}
+	pointer := testing.AllocsPerRun(10, add(NewMsgs(WithCapacity(msgChunkLen))))
+	value := testing.AllocsPerRun(10, add(NewMsgs(WithCapacity(msgChunkLen), WithValueStorage())))
+	if value > 2 || pointer < msgChunkLen {
+		t.Fatalf("Test failed: expected value storage to allocate once per chunk: actual %v vs %v allocs", value, pointer)
+	}
+}
+
+func TestWithValueStorageClone(t *testing.T) {
+	m := NewMsgs(WithValueStorage()).AddInfo("i1")
+	c := m.Clone()
+	c.AddInfo("i2")
+	m.AddInfo("i3")
+	if c.arena == nil || c.arena == m.arena {
+		t.Fatalf("Test failed: expected clone to have its own storage")
+	}
+	if c.Items[1].Desc != "i2" || m.Items[1].Desc != "i3" {
+		t.Fatalf("Test failed: expected copies to not share messages: actual '%s' '%s'", c, m)
+	}
+}
+
+func BenchmarkMsgsStorage(b *testing.B) {
+	const n = 100000
+	fill := func(m *Msgs) *Msgs {
+		for i := 0; i < n; i++ {
+			if i%10 == 0 {
+				m.AddWarn("volume is degraded")
+				continue
+			}
+			m.AddInfo("volume is healthy")
+		}
+		return m
+	}
+	for name, build := range mockStorages() {
+		build := build
+		b.Run("Add/"+name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				fill(build())
+			}
+		})
+		b.Run("Filter/"+name, func(b *testing.B) {
+			m := fill(build())
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				m.Filter(IsWarn)
+			}
+		})
+		b.Run("Merge/"+name, func(b *testing.B) {
+			s := fill(build())
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				build().Merge(s)
+			}
+		})
+	}
+}
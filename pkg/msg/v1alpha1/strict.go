@@ -0,0 +1,58 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+)
+
+// escalate converts the provided message to an ErrMsg if it is a
+// WarnMsg
+func escalate(item *msg) {
+	if item.Mtype != WarnMsg {
+		return
+	}
+	item.Mtype = ErrMsg
+	if item.Err == nil {
+		item.Err = errors.New(item.Description())
+	}
+}
+
+// WithStrictMode sets if warnings subsequently added via AddWarn and
+// its variants are recorded as errors instead
+func (m *Msgs) WithStrictMode(strict bool) (u *Msgs) {
+	m.mustNotBeNil("WithStrictMode")
+	m.strict = strict
+	return m
+}
+
+// EscalateWarnsToErrors returns a new list of messages where every
+// WarnMsg is converted to an ErrMsg. The receiver is not modified.
+func (m Msgs) EscalateWarnsToErrors() (f Msgs) {
+	for _, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		if item.Mtype == WarnMsg {
+			c := *item
+			escalate(&c)
+			item = &c
+		}
+		f.Items = append(f.Items, item)
+	}
+	return
+}
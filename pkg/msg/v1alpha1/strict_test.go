@@ -0,0 +1,71 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+)
+
+// mockStrictInput adds the same mix of messages to the provided list
+func mockStrictInput(m *Msgs) *Msgs {
+	return m.AddInfo("i1").AddWarn("w1").AddSkip("s1").AddWarnCode("Degraded", "w2")
+}
+
+func TestMsgsStrictMode(t *testing.T) {
+	tests := map[string]struct {
+		msgs           Msgs
+		expectedErrors int
+		expectedWarns  int
+		expectedErr    string
+	}{
+		"101": {*mockStrictInput(&Msgs{}), 0, 2, ""},
+		"102": {*mockStrictInput((&Msgs{}).WithStrictMode(true)), 2, 0, "w1"},
+		"103": {mockStrictInput(&Msgs{}).EscalateWarnsToErrors(), 2, 0, "w1"},
+		"104": {*mockStrictInput((&Msgs{}).WithStrictMode(true).WithStrictMode(false)), 0, 2, ""},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := mock.msgs.AllMsgs()
+			if len(a[ErrMsg].Items) != mock.expectedErrors || len(a[WarnMsg].Items) != mock.expectedWarns {
+				t.Fatalf("Test '%s' failed: expected errors %d warns %d: actual '%s'", name, mock.expectedErrors, mock.expectedWarns, a)
+			}
+			if a.HasError() != (mock.expectedErrors != 0) {
+				t.Fatalf("Test '%s' failed: expected has error '%t'", name, mock.expectedErrors != 0)
+			}
+			if err := a.Error(); (err == nil) != (mock.expectedErr == "") || (err != nil && err.Error() != mock.expectedErr) {
+				t.Fatalf("Test '%s' failed: expected error '%s': actual '%v'", name, mock.expectedErr, err)
+			}
+			if len(a[InfoMsg].Items) != 1 || len(a[SkipMsg].Items) != 1 {
+				t.Fatalf("Test '%s' failed: expected infos and skips to be untouched: actual '%s'", name, a)
+			}
+		})
+	}
+}
+
+func TestMsgsEscalateWarnsToErrors(t *testing.T) {
+	w := errors.New("w1 cause")
+	m := &Msgs{Items: []*msg{&msg{Mtype: WarnMsg, Desc: "w1", Err: w}, nil, &msg{Mtype: WarnMsg, Desc: "w2"}}}
+	f := m.EscalateWarnsToErrors()
+	if f.Items[0].Err != w || f.Items[1].Err.Error() != "w2" || f.Items[1].Code != "" {
+		t.Fatalf("Test failed: expected existing errors to be retained: actual '%s'", f)
+	}
+	if !IsWarn(m.Items[0]) || m.Items[2].Err != nil {
+		t.Fatalf("Test failed: expected receiver to be unmodified: actual '%s'", m)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// tee returns a logger that dispatches every log line to all the
+// provided non nil loggers. A logger that panics does not prevent the
+// rest of the loggers from receiving the line.
+func tee(loggers ...func(string, ...interface{})) func(string, ...interface{}) {
+	return func(format string, args ...interface{}) {
+		for _, l := range loggers {
+			if l == nil {
+				continue
+			}
+			func() {
+				defer func() { recover() }()
+				l(format, args...)
+			}()
+		}
+	}
+}
+
+// LogTee logs non nil messages at or above the log threshold to all
+// the provided loggers. Each message is rendered only once.
+func (m Msgs) LogTee(loggers ...func(string, ...interface{})) {
+	m.Log(tee(loggers...))
+}
+
+// LogNonInfosTee logs all messages but ones of type InfoMsg to all the
+// provided loggers
+func (m Msgs) LogNonInfosTee(loggers ...func(string, ...interface{})) {
+	m.LogNonInfos(tee(loggers...))
+}
+
+// LogErrorsTee logs all messages of type ErrMsg to all the provided
+// loggers
+func (m Msgs) LogErrorsTee(loggers ...func(string, ...interface{})) {
+	m.LogErrors(tee(loggers...))
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMsgsLogTee(t *testing.T) {
+	m := (&Msgs{}).AddInfo("i1").AddWarn("w1").AddError(errors.New("e1"))
+	tests := map[string]struct {
+		log      func(m Msgs, loggers ...func(string, ...interface{}))
+		expected []string
+	}{
+		"101": {Msgs.LogTee, []string{"i1", "w1", "e1"}},
+		"102": {Msgs.LogNonInfosTee, []string{"w1", "e1"}},
+		"103": {Msgs.LogErrorsTee, []string{"e1"}},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			var pod, audit []string
+			panicky := func(string, ...interface{}) { panic("sink down") }
+			mock.log(*m, mockLogger(&pod), nil, panicky, mockLogger(&audit))
+			if len(pod) != len(mock.expected) || len(audit) != len(mock.expected) {
+				t.Fatalf("Test '%s' failed: expected %d lines: actual '%v' '%v'", name, len(mock.expected), pod, audit)
+			}
+			for i, desc := range mock.expected {
+				if pod[i] != audit[i] {
+					t.Fatalf("Test '%s' failed: expected same line to every logger: actual '%s' '%s'", name, pod[i], audit[i])
+				}
+				if !strings.Contains(pod[i], "desc: "+desc) {
+					t.Fatalf("Test '%s' failed: expected line %d to contain '%s': actual '%s'", name, i, desc, pod[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMsgsLogTeeNoLoggers(t *testing.T) {
+	m := (&Msgs{}).AddInfo("i1")
+	m.LogTee()
+	m.LogTee(nil, nil)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"text/template"
+)
+
+// registeredTemplate is a description template registered against a
+// name along with the error if the template could not be parsed
+type registeredTemplate struct {
+	tmpl *template.Template
+	err  error
+}
+
+var (
+	templatesMu sync.RWMutex
+	// templates holds the registered description templates by name
+	templates = map[string]registeredTemplate{}
+)
+
+// RegisterTemplate registers the provided text/template against the
+// provided name to be used by AddInfoT and AddErrorT. A template that
+// is registered again replaces the earlier one. Referring to data keys
+// that are not provided is a rendering failure. A template that can
+// not be parsed is still registered such that its failure is reported
+// by every message that uses it e.g.
+//
+//	RegisterTemplate("CreateFailed", "failed to create {{.kind}} {{.name}}")
+func RegisterTemplate(name, tmpl string) {
+	t, err := template.New(name).Option("missingkey=error").Parse(tmpl)
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+	templates[name] = registeredTemplate{tmpl: t, err: err}
+}
+
+// renderTemplate renders the template registered against the provided
+// name with the provided data
+func renderTemplate(name string, data map[string]interface{}) (string, error) {
+	templatesMu.RLock()
+	r, found := templates[name]
+	templatesMu.RUnlock()
+	if !found {
+		return "", fmt.Errorf("template '%s' is not registered", name)
+	}
+	if r.err != nil {
+		return "", r.err
+	}
+	var b bytes.Buffer
+	if err := r.tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// templateMsg returns a message of the provided type having the
+// description rendered from the template registered against the
+// provided name. The template name is recorded as the code of the
+// message and the data as its fields. A message that fails to render
+// is an ErrMsg that describes the failure instead.
+func templateMsg(t MsgType, name string, data map[string]interface{}) *msg {
+	item := &msg{Mtype: t, Code: name, Fields: copyFields(data)}
+	desc, err := renderTemplate(name, data)
+	if err != nil {
+		item.Mtype = ErrMsg
+		item.Desc = fmt.Sprintf("failed to render template '%s': %s", name, err)
+		item.Err = errors.New(item.Desc)
+		return item
+	}
+	item.Desc = desc
+	return item
+}
+
+// AddInfoT appends a new InfoMsg whose description is rendered from
+// the template registered against the provided name. The data is kept
+// as the fields of the message. An ErrMsg describing the failure is
+// appended instead if the template can not be rendered.
+func (m *Msgs) AddInfoT(name string, data map[string]interface{}) (u *Msgs) {
+	m.mustNotBeNil("AddInfoT")
+	return m.add(templateMsg(InfoMsg, name, data))
+}
+
+// AddErrorT appends a new ErrMsg whose description is rendered from
+// the template registered against the provided name. The data is kept
+// as the fields of the message while the provided error if any is
+// recorded as its error. An ErrMsg describing the failure along with
+// the provided error is appended instead if the template can not be
+// rendered.
+func (m *Msgs) AddErrorT(name string, data map[string]interface{}, err error) (u *Msgs) {
+	m.mustNotBeNil("AddErrorT")
+	item := templateMsg(ErrMsg, name, data)
+	if err == nil {
+		if item.Err == nil {
+			item.Err = errors.New(item.Desc)
+		}
+		return m.add(item)
+	}
+	if item.Err != nil {
+		item.Desc = item.Desc + ": " + err.Error()
+	}
+	item.Err = err
+	return m.add(item)
+}
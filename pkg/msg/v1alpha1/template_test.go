@@ -0,0 +1,133 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMsgsAddT(t *testing.T) {
+	RegisterTemplate("test.CreateFailed", "failed to create {{.kind}} {{.name}}")
+	RegisterTemplate("test.Created", "created {{.kind}} {{.name}}")
+	RegisterTemplate("test.Invalid", "created {{.kind")
+	cause := errors.New("forbidden")
+
+	tests := map[string]struct {
+		add           func(m *Msgs) *Msgs
+		expectedType  MsgType
+		expectedDesc  string
+		expectedErr   string
+		expectedCause bool
+	}{
+		"101": {func(m *Msgs) *Msgs {
+			return m.AddInfoT("test.Created", map[string]interface{}{"kind": "pod", "name": "p1"})
+		}, InfoMsg, "created pod p1", "", false},
+		"102": {func(m *Msgs) *Msgs {
+			return m.AddErrorT("test.CreateFailed", map[string]interface{}{"kind": "pod", "name": "p1"}, cause)
+		}, ErrMsg, "failed to create pod p1", "forbidden", true},
+		"103": {func(m *Msgs) *Msgs {
+			return m.AddErrorT("test.CreateFailed", map[string]interface{}{"kind": "pod", "name": "p1"}, nil)
+		}, ErrMsg, "failed to create pod p1", "failed to create pod p1", false},
+		"104": {func(m *Msgs) *Msgs {
+			return m.AddInfoT("test.Unknown", map[string]interface{}{"kind": "pod"})
+		}, ErrMsg, "failed to render template 'test.Unknown': template 'test.Unknown' is not registered",
+			"failed to render template 'test.Unknown': template 'test.Unknown' is not registered", false},
+		"105": {func(m *Msgs) *Msgs {
+			return m.AddInfoT("test.Created", map[string]interface{}{"kind": "pod"})
+		}, ErrMsg, "failed to render template 'test.Created': ", "failed to render template 'test.Created': ", false},
+		"106": {func(m *Msgs) *Msgs {
+			return m.AddErrorT("test.CreateFailed", nil, cause)
+		}, ErrMsg, "failed to render template 'test.CreateFailed': ", "forbidden", true},
+		"107": {func(m *Msgs) *Msgs {
+			return m.AddInfoT("test.Invalid", nil)
+		}, ErrMsg, "failed to render template 'test.Invalid': ", "failed to render template 'test.Invalid': ", false},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := mock.add(&Msgs{})
+			if len(m.Items) != 1 {
+				t.Fatalf("Test '%s' failed: expected 1 message: actual %d", name, len(m.Items))
+			}
+			item := m.Items[0]
+			if item.Mtype != mock.expectedType {
+				t.Fatalf("Test '%s' failed: expected type '%s': actual '%s'", name, mock.expectedType, item.Mtype)
+			}
+			if !strings.HasPrefix(item.Description(), mock.expectedDesc) {
+				t.Fatalf("Test '%s' failed: expected description '%s': actual '%s'", name, mock.expectedDesc, item.Description())
+			}
+			if !strings.HasPrefix(errString(item.Err), mock.expectedErr) {
+				t.Fatalf("Test '%s' failed: expected error '%s': actual '%s'", name, mock.expectedErr, errString(item.Err))
+			}
+			if mock.expectedCause && item.Err != cause {
+				t.Fatalf("Test '%s' failed: expected the provided error to be recorded: actual '%v'", name, item.Err)
+			}
+		})
+	}
+}
+
+func TestMsgsAddTMissingKey(t *testing.T) {
+	RegisterTemplate("test.Deleted", "deleted {{.kind}} {{.name}}")
+	m := (&Msgs{}).AddInfoT("test.Deleted", map[string]interface{}{"kind": "pod"})
+	if !strings.Contains(m.Items[0].Description(), `"name"`) {
+		t.Fatalf("Test failed: expected description to name the missing key: actual '%s'", m.Items[0].Description())
+	}
+	if v, found := m.Items[0].Field("kind"); !found || v != "pod" {
+		t.Fatalf("Test failed: expected data to be kept on failure: actual '%v'", v)
+	}
+}
+
+func TestMsgsAddTFields(t *testing.T) {
+	RegisterTemplate("test.Created", "created {{.kind}} {{.name}}")
+	data := map[string]interface{}{"kind": "pod", "name": "p1"}
+	m := (&Msgs{}).
+		AddInfoT("test.Created", data).
+		AddInfoT("test.Created", map[string]interface{}{"kind": "pvc", "name": "c1"}).
+		AddInfo("created pod p2")
+	data["name"] = "changed"
+
+	tests := map[string]struct {
+		predicate msgPredicate
+		expected  string
+	}{
+		"101": {FieldIs("kind", "pod"), "created pod p1"},
+		"102": {FieldIs("kind", "pvc"), "created pvc c1"},
+		"103": {FieldIs("kind", "sc"), ""},
+		"104": {FieldIs("unknown", "pod"), ""},
+		"105": {HasField("name"), "created pod p1,created pvc c1"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := m.Filter(mock.predicate)
+			if actual := strings.Join(f.Descriptions(), ","); actual != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, actual)
+			}
+		})
+	}
+	if m.Items[0].Code != "test.Created" {
+		t.Fatalf("Test failed: expected template name as code: actual '%s'", m.Items[0].Code)
+	}
+	for name, roundTrip := range mockRoundTrips() {
+		actual, err := roundTrip(m)
+		if err != nil || !m.EqualStrict(*actual) {
+			t.Fatalf("Test '%s' failed: expected fields to round trip: actual '%v' '%v'", name, actual, err)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Map returns a new list of messages where every non nil message is
+// rewritten by the provided function. A message is dropped if the
+// function returns an empty description. Rest of the fields of the
+// message are retained. The receiver is not modified.
+func (m Msgs) Map(fn func(t MsgType, desc string, err error) (MsgType, string, error)) (f Msgs) {
+	for _, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		mtype, desc, err := fn(item.Mtype, item.Description(), item.Err)
+		if len(desc) == 0 {
+			continue
+		}
+		c := *item
+		c.Mtype, c.Desc, c.Err, c.lazy = mtype, desc, err, nil
+		f.Items = append(f.Items, &c)
+	}
+	return
+}
+
+// PrefixDesc returns a new list of messages whose descriptions are
+// prefixed with the provided prefix e.g. 'pvc-1: '. The receiver is not
+// modified.
+func (m Msgs) PrefixDesc(prefix string) (f Msgs) {
+	return m.Map(func(t MsgType, desc string, err error) (MsgType, string, error) {
+		return t, prefix + desc, err
+	})
+}
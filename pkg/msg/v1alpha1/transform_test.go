@@ -0,0 +1,89 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMsgsMap(t *testing.T) {
+	e1 := errors.New("e1 password=secret")
+
+	tests := map[string]struct {
+		fn            func(MsgType, string, error) (MsgType, string, error)
+		expected      string
+		expectedTypes string
+	}{
+		"101": {func(t MsgType, d string, e error) (MsgType, string, error) {
+			return t, strings.Replace(d, "secret", "***", -1), e
+		}, "i1,w1 password=***,e1 password=***", "info,warn,error"},
+		"102": {func(t MsgType, d string, e error) (MsgType, string, error) {
+			if t == InfoMsg {
+				return t, "", e
+			}
+			return t, d, e
+		}, "w1 password=secret,e1 password=secret", "warn,error"},
+		"103": {func(t MsgType, d string, e error) (MsgType, string, error) {
+			if t == WarnMsg {
+				return ErrMsg, d, errors.New(d)
+			}
+			return t, d, e
+		}, "i1,w1 password=secret,e1 password=secret", "info,error,error"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := (&Msgs{}).AddInfo("i1").AddWarnCode("Secret", "w1 password=secret").AddError(e1)
+			m.Items = append(m.Items, nil)
+			before := m.String()
+
+			f := m.Map(mock.fn)
+			if descs(&f) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(&f))
+			}
+			var types []string
+			for _, item := range f.Items {
+				types = append(types, string(item.Mtype))
+			}
+			if strings.Join(types, ",") != mock.expectedTypes {
+				t.Fatalf("Test '%s' failed: expected types '%s': actual '%s'", name, mock.expectedTypes, strings.Join(types, ","))
+			}
+			if m.String() != before {
+				t.Fatalf("Test '%s' failed: expected receiver to be unmodified: actual '%s'", name, m.String())
+			}
+			if e := f.Errors().Items; mock.expectedTypes != "info,error,error" && e[len(e)-1].Err != e1 {
+				t.Fatalf("Test '%s' failed: expected error identity to be preserved", name)
+			}
+		})
+	}
+}
+
+func TestMsgsPrefixDesc(t *testing.T) {
+	m := (&Msgs{}).AddInfo("i1").AddInfoLazy(func() string { return "i2" }).AddWarnCode("Degraded", "w1")
+	f := m.PrefixDesc("pvc-1: ")
+	if descs(&f) != "pvc-1: i1,pvc-1: i2,pvc-1: w1" {
+		t.Fatalf("Test failed: expected prefixed descriptions: actual '%s'", descs(&f))
+	}
+	if f.Items[2].Code != "Degraded" {
+		t.Fatalf("Test failed: expected code to be retained: actual '%s'", f.Items[2].Code)
+	}
+	if strings.Join(m.Descriptions(), ",") != "i1,i2,w1" {
+		t.Fatalf("Test failed: expected receiver to be unmodified: actual '%v'", m.Descriptions())
+	}
+}
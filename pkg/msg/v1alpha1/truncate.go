@@ -0,0 +1,103 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TruncateOptions represents the options used while truncating messages
+type TruncateOptions struct {
+	// PrioritizeErrors includes ErrMsg messages ahead of the rest
+	PrioritizeErrors bool
+}
+
+// DefaultTruncateOptions prioritizes errors while truncating
+var DefaultTruncateOptions = TruncateOptions{PrioritizeErrors: true}
+
+// Truncate returns at most n messages followed by an InfoMsg that
+// summarizes the messages that were left out. Errors are included
+// ahead of other messages. The receiver is not modified.
+func (m Msgs) Truncate(n int) (t Msgs) {
+	return m.TruncateWithOptions(n, DefaultTruncateOptions)
+}
+
+// TruncateWithOptions returns at most n messages followed by an InfoMsg
+// that summarizes the messages that were left out. Included messages
+// retain their original order. The receiver is not modified.
+func (m Msgs) TruncateWithOptions(n int, o TruncateOptions) (t Msgs) {
+	if n < 0 {
+		n = 0
+	}
+	all := m.Filter(func(*msg) bool { return true })
+	if len(all.Items) <= n {
+		return all
+	}
+	included := make([]bool, len(all.Items))
+	var count int
+	if o.PrioritizeErrors {
+		for i, item := range all.Items {
+			if count == n {
+				break
+			}
+			if IsErr(item) {
+				included[i] = true
+				count++
+			}
+		}
+	}
+	var omitted Msgs
+	for i, item := range all.Items {
+		if !included[i] && count < n {
+			included[i] = true
+			count++
+		}
+		if included[i] {
+			t.Items = append(t.Items, item)
+			continue
+		}
+		omitted.Items = append(omitted.Items, item)
+	}
+	t.Items = append(t.Items, &msg{Mtype: InfoMsg, Desc: omittedDesc(omitted)})
+	return
+}
+
+// omittedDesc returns the description that summarizes the provided
+// omitted messages e.g. '…and 3 more messages (2 errors, 1 warns)'
+func omittedDesc(omitted Msgs) string {
+	counts := omitted.counts()
+	var parts []string
+	for _, d := range []struct {
+		mtype MsgType
+		name  string
+	}{{ErrMsg, "errors"}, {WarnMsg, "warns"}, {InfoMsg, "infos"}, {SkipMsg, "skips"}} {
+		if counts[d.mtype] != 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[d.mtype], d.name))
+		}
+	}
+	for _, mtype := range otherTypes(counts) {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[mtype], mtype))
+	}
+	return fmt.Sprintf("…and %d more messages (%s)", len(omitted.Items), strings.Join(parts, ", "))
+}
+
+// StringLimited returns the yaml formatted string of at most n messages
+// as returned by Truncate
+func (m Msgs) StringLimited(n int) string {
+	return YamlString("msgs", m.Truncate(n).detailed())
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMsgsTruncate(t *testing.T) {
+	tests := map[string]struct {
+		msgs     *Msgs
+		n        int
+		options  TruncateOptions
+		expected string
+	}{
+		"101": {(&Msgs{}).AddInfo("i1").AddWarn("w1").AddError(errors.New("e1")).AddError(errors.New("e2")), 2,
+			DefaultTruncateOptions, "e1,e2,…and 2 more messages (1 warns, 1 infos)"},
+		"102": {(&Msgs{}).AddInfo("i1").AddWarn("w1").AddError(errors.New("e1")).AddError(errors.New("e2")), 2,
+			TruncateOptions{}, "i1,w1,…and 2 more messages (2 errors)"},
+		"103": {(&Msgs{}).AddInfo("i1").AddWarn("w1").AddError(errors.New("e1")), 2,
+			DefaultTruncateOptions, "i1,e1,…and 1 more messages (1 warns)"},
+		"104": {(&Msgs{}).AddInfo("i1").AddWarn("w1"), 2, DefaultTruncateOptions, "i1,w1"},
+		"105": {(&Msgs{}).AddSkip("s1").AddInfo("i1"), 0, DefaultTruncateOptions, "…and 2 more messages (1 infos, 1 skips)"},
+		"106": {&Msgs{Items: []*msg{nil, &msg{Mtype: "custom", Desc: "c1"}, nil}}, -1, DefaultTruncateOptions,
+			"…and 1 more messages (1 custom)"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			before := len(mock.msgs.Items)
+			truncated := mock.msgs.TruncateWithOptions(mock.n, mock.options)
+			if descs(&truncated) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(&truncated))
+			}
+			if len(mock.msgs.Items) != before {
+				t.Fatalf("Test '%s' failed: expected messages to be unmodified: actual %d messages", name, len(mock.msgs.Items))
+			}
+		})
+	}
+}
+
+func TestMsgsTruncateSummary(t *testing.T) {
+	m := &Msgs{}
+	for i := 0; i < 10; i++ {
+		m.AddInfo("i")
+	}
+	for i := 0; i < 312; i++ {
+		m.AddError(errors.New("e"))
+	}
+	for i := 0; i < 175; i++ {
+		m.AddWarn("w")
+	}
+	truncated := m.Truncate(10)
+	last := truncated.Items[len(truncated.Items)-1]
+	expected := "…and 487 more messages (302 errors, 175 warns, 10 infos)"
+	if last.Desc != expected || !IsInfo(last) {
+		t.Fatalf("Test failed: expected summary '%s': actual '%v'", expected, last)
+	}
+	if len(m.Items) != 497 {
+		t.Fatalf("Test failed: expected messages to be unmodified: actual %d messages", len(m.Items))
+	}
+	if s := m.StringLimited(1); !strings.Contains(s, "…and 496 more messages") || strings.Count(s, "type:") != 2 {
+		t.Fatalf("Test failed: expected limited string with 2 messages: actual '%s'", s)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+)
+
+// MsgView is a read only copy of a message
+type MsgView struct {
+	Type    MsgType           // type of the message
+	Desc    string            // description of the message
+	Err     error             // if the message is an error
+	Code    string            // machine readable reason
+	Source  string            // component that reported the message
+	Labels  map[string]string // arbitrary tags of the message
+	Percent float64           // completion if the message is a progress
+
+	ErrCode  string                 // code of the registered error if any
+	ID       string                 // unique id of the message if any
+	Reason   SkipReason             // reason of the message if it is a skip
+	Priority int                    // importance of the message within its type
+	Category ErrorCategory          // kind of the message if it is a classified error
+	Fields   map[string]interface{} // named parameters of the message if any
+	Object   ObjectRef              // object the message is about if any
+	Caller   string                 // file and line that added the message if any
+	Time     time.Time              // when the message was added if stamped
+	Stack    []string               // stack trace of the message if captured
+
+	Count    int       // occurrences of the message, see MergeCounted
+	LastSeen time.Time // when the message last occurred if counted
+}
+
+// view returns a read only copy of this message
+func (m *msg) view() MsgView {
+	return MsgView{
+		Type:     m.Mtype,
+		Desc:     m.Description(),
+		Err:      m.Err,
+		Code:     m.Code,
+		Source:   m.Source,
+		Labels:   mergeLabels(nil, m.Labels),
+		Percent:  m.Percent,
+		ErrCode:  m.ErrCode,
+		ID:       m.ID,
+		Reason:   m.Reason,
+		Priority: m.Priority,
+		Category: m.Category,
+		Fields:   copyFields(m.Fields),
+		Object:   objectOf(m),
+		Caller:   m.Caller,
+		Time:     timeOf(m.Time),
+		Stack:    append([]string(nil), m.Stack...),
+		Count:    m.Occurrences(),
+
+		LastSeen: timeOf(m.LastSeen),
+	}
+}
+
+// timeOf returns the provided time or the zero time if it is not set
+func timeOf(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+// View returns read only copies of the non nil messages in the order
+// they were added. Changes to the returned views do not affect the
+// messages.
+func (m Msgs) View() (views []MsgView) {
+	for _, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		views = append(views, item.view())
+	}
+	return
+}
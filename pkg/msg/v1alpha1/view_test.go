@@ -0,0 +1,63 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMsgsView(t *testing.T) {
+	m := &Msgs{}
+	m.AddInfo("i1").AddError(errors.New("e1"))
+	m.Items = append(m.Items, nil)
+	views := m.View()
+	if len(views) != 2 || views[0].Type != InfoMsg || views[0].Desc != "i1" || views[1].Err == nil || views[1].Count != 1 {
+		t.Fatalf("Test failed: expected views of 'i1,e1': actual '%v'", views)
+	}
+	views[0].Desc, views[1].Type = "changed", WarnMsg
+	views[0] = MsgView{}
+	if m.Items[0].Desc != "i1" || m.Items[1].Mtype != ErrMsg || len(m.Items) != 3 {
+		t.Fatalf("Test failed: expected messages to be unchanged: actual '%s'", m)
+	}
+	if len((Msgs{}).View()) != 0 {
+		t.Fatalf("Test failed: expected no views of empty messages")
+	}
+}
+
+func TestMsgsViewOptionalFields(t *testing.T) {
+	at := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	m := NewMsgs(WithClock(func() time.Time { return at }), WithTimestamps(), WithIDs(), WithSource("cstor"))
+	m.AddWarnCode("PoolFull", "w1").AddSkipReason(AlreadyExists, "s1")
+	m.Items[0].Labels = map[string]string{"pool": "p1"}
+	views := m.View()
+	w, s := views[0], views[1]
+	if !w.Time.Equal(at) || w.Code != "PoolFull" || w.Source != "cstor" || w.Labels["pool"] != "p1" || len(w.ID) == 0 {
+		t.Fatalf("Test failed: expected optional fields to be set: actual '%+v'", w)
+	}
+	if s.Reason != AlreadyExists {
+		t.Fatalf("Test failed: expected reason '%s': actual '%s'", AlreadyExists, s.Reason)
+	}
+	w.Labels["pool"] = "p2"
+	if m.Items[0].Labels["pool"] != "p1" {
+		t.Fatalf("Test failed: expected labels to be unchanged: actual '%v'", m.Items[0].Labels)
+	}
+	if v := (&Msgs{}).AddInfo("i1").View()[0]; !v.Time.IsZero() || v.Code != "" || v.Labels != nil {
+		t.Fatalf("Test failed: expected optional fields to be unset: actual '%+v'", v)
+	}
+}
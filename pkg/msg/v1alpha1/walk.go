@@ -0,0 +1,62 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Each invokes the provided function for every non nil message
+func (m Msgs) Each(fn func(t MsgType, desc string, err error)) {
+	m.Walk(func(t MsgType, desc string, err error) bool {
+		fn(t, desc, err)
+		return true
+	})
+}
+
+// Walk invokes the provided function for every non nil message till
+// the function returns false. It returns true if every message was
+// visited.
+func (m Msgs) Walk(fn func(t MsgType, desc string, err error) bool) (completed bool) {
+	for _, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		if !fn(item.Mtype, item.Description(), item.Err) {
+			return
+		}
+	}
+	return true
+}
+
+// Each invokes the provided function for every non nil message. The
+// buckets are visited in the order errors, warns, deprecations, infos
+// and skips followed by other message types in sorted order.
+func (a AllMsgs) Each(fn func(t MsgType, desc string, err error)) {
+	a.Walk(func(t MsgType, desc string, err error) bool {
+		fn(t, desc, err)
+		return true
+	})
+}
+
+// Walk invokes the provided function for every non nil message till
+// the function returns false. The buckets are visited in the same
+// order as Each. It returns true if every message was visited.
+func (a AllMsgs) Walk(fn func(t MsgType, desc string, err error) bool) (completed bool) {
+	for _, mtype := range a.types() {
+		if !a[mtype].Walk(fn) {
+			return
+		}
+	}
+	return true
+}
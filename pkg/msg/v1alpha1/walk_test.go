@@ -0,0 +1,99 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMsgsWalk(t *testing.T) {
+	m := &Msgs{Items: []*msg{
+		nil,
+		&msg{Mtype: InfoMsg, Desc: "i1"},
+		nil,
+		&msg{Mtype: ErrMsg, Desc: "e1", Err: errors.New("e1")},
+		&msg{Mtype: WarnMsg, Desc: "w1"},
+	}}
+
+	tests := map[string]struct {
+		msgs              *Msgs
+		stopAfter         int
+		expected          string
+		expectedCompleted bool
+	}{
+		"101": {m, 0, "info:i1,error:e1,warn:w1", true},
+		"102": {m, 2, "info:i1,error:e1", false},
+		"103": {m, 3, "info:i1,error:e1,warn:w1", false},
+		"104": {&Msgs{}, 1, "", true},
+		"105": {&Msgs{Items: []*msg{nil, nil}}, 1, "", true},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			var visited []string
+			completed := mock.msgs.Walk(func(mtype MsgType, desc string, err error) bool {
+				visited = append(visited, string(mtype)+":"+desc)
+				return mock.stopAfter == 0 || len(visited) < mock.stopAfter
+			})
+			if strings.Join(visited, ",") != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, strings.Join(visited, ","))
+			}
+			if completed != mock.expectedCompleted {
+				t.Fatalf("Test '%s' failed: expected completed '%t': actual '%t'", name, mock.expectedCompleted, completed)
+			}
+		})
+	}
+}
+
+func TestMsgsEach(t *testing.T) {
+	e1 := errors.New("e1")
+	m := (&Msgs{}).AddSkip("s1").AddInfo("i1").AddError(e1).AddWarn("w1")
+	m.Items = append(m.Items, nil)
+
+	var visited []string
+	var err error
+	m.Each(func(mtype MsgType, desc string, e error) {
+		visited = append(visited, desc)
+		if e != nil {
+			err = e
+		}
+	})
+	if strings.Join(visited, ",") != "s1,i1,e1,w1" || err != e1 {
+		t.Fatalf("Test failed: expected 's1,i1,e1,w1': actual '%s' '%v'", strings.Join(visited, ","), err)
+	}
+
+	visited = nil
+	a := m.AllMsgs()
+	a["custom"] = mockMsgsFromType([]MsgType{"custom"})
+	a.Each(func(mtype MsgType, desc string, e error) {
+		visited = append(visited, string(mtype))
+	})
+	if strings.Join(visited, ",") != "error,warn,info,skip,custom" {
+		t.Fatalf("Test failed: expected buckets in severity order: actual '%s'", strings.Join(visited, ","))
+	}
+
+	visited = nil
+	completed := a.Walk(func(mtype MsgType, desc string, e error) bool {
+		visited = append(visited, desc)
+		return len(visited) < 2
+	})
+	if completed || strings.Join(visited, ",") != "e1,w1" {
+		t.Fatalf("Test failed: expected walk to stop after second message: actual '%s'", strings.Join(visited, ","))
+	}
+}
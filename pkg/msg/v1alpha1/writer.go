@@ -0,0 +1,141 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// DefaultMaxLineLen is the default maximum length in bytes of a message
+// appended by a LineWriter
+const DefaultMaxLineLen = 4096
+
+// ellipsis is appended to lines that were truncated
+const ellipsis = "…"
+
+// errWriterClosed is returned when writing to a closed LineWriter
+var errWriterClosed = errors.New("msg: write to a closed writer")
+
+// LineWriter is an io.WriteCloser that appends a message for every non
+// empty line written to it. It can be used to capture the output of an
+// external command e.g. cmd.Stderr = m.Writer(WarnMsg).
+type LineWriter struct {
+	// MaxLineLen is the maximum length in bytes of a line; longer lines
+	// are truncated with an ellipsis. Lines are not truncated if this is
+	// zero or negative.
+	MaxLineLen int
+
+	mu     sync.Mutex
+	msgs   *Msgs
+	mtype  MsgType
+	buf    []byte
+	over   bool // current line exceeds MaxLineLen; rest of it is dropped
+	closed bool
+}
+
+// Writer returns a LineWriter that appends one message of the provided
+// type for every non empty line written to it. Partial lines are
+// buffered across writes till a newline is written or the writer is
+// closed.
+func (m *Msgs) Writer(t MsgType) *LineWriter {
+	m.mustNotBeNil("Writer")
+	return &LineWriter{MaxLineLen: DefaultMaxLineLen, msgs: m, mtype: t}
+}
+
+// Write appends a message for every complete line in the provided bytes
+// and buffers the trailing partial line if any
+func (w *LineWriter) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, errWriterClosed
+	}
+	for rest := p; len(rest) != 0; {
+		i := bytes.IndexByte(rest, '\n')
+		if i < 0 {
+			w.buffer(rest)
+			break
+		}
+		w.buffer(rest[:i])
+		w.flush()
+		rest = rest[i+1:]
+	}
+	return len(p), nil
+}
+
+// Close appends the buffered partial line if any. Writes after Close
+// fail.
+func (w *LineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.flush()
+	w.closed = true
+	return nil
+}
+
+// buffer appends the provided bytes to the current line. Bytes beyond
+// MaxLineLen are not retained since the line gets truncated anyway.
+func (w *LineWriter) buffer(p []byte) {
+	if w.over {
+		return
+	}
+	w.buf = append(w.buf, p...)
+	// one extra byte is retained to account for a trailing carriage
+	// return that is stripped on flush
+	if w.MaxLineLen > 0 && len(w.buf) > w.MaxLineLen+1 {
+		w.buf, w.over = w.buf[:w.MaxLineLen+1], true
+	}
+}
+
+// flush appends the current line as a message if it is not empty and
+// resets the buffer
+func (w *LineWriter) flush() {
+	line := strings.TrimSuffix(string(w.buf), "\r")
+	w.buf, w.over = w.buf[:0], false
+	if len(strings.TrimSpace(line)) == 0 {
+		return
+	}
+	line = truncateLine(line, w.MaxLineLen)
+	item := &msg{Mtype: w.mtype, Desc: line}
+	if w.mtype == ErrMsg {
+		item.Err = errors.New(line)
+	}
+	w.msgs.add(item)
+}
+
+// truncateLine shortens the provided line to at most max bytes including
+// the ellipsis without splitting a multi-byte character
+func truncateLine(line string, max int) string {
+	if max <= 0 || len(line) <= max {
+		return line
+	}
+	cut := max - len(ellipsis)
+	if cut < 0 {
+		cut = 0
+	}
+	for cut > 0 && !utf8.RuneStart(line[cut]) {
+		cut--
+	}
+	return line[:cut] + ellipsis
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"io"
+	"testing"
+)
+
+func TestMsgsWriter(t *testing.T) {
+	tests := map[string]struct {
+		writes      []string
+		beforeClose string
+		expected    string
+	}{
+		"101": {[]string{"l1\nl2\nl3\n"}, "l1,l2,l3", "l1,l2,l3"},
+		"102": {[]string{"l1\nl", "2\nl3\n"}, "l1,l2,l3", "l1,l2,l3"},
+		"103": {[]string{"l1\nl2"}, "l1", "l1,l2"},
+		"104": {[]string{"l1\r\nl2\r", "\nl3\r\n"}, "l1,l2,l3", "l1,l2,l3"},
+		"105": {[]string{"\n\r\n  \nl1\n\n"}, "l1", "l1"},
+		"106": {[]string{"", "l1"}, "", "l1"},
+		"107": {[]string{"abcdefghij\n"}, "abcde…", "abcde…"},
+		"108": {[]string{"abcd", "efgh", "ijkl\nl2\n"}, "abcde…,l2", "abcde…,l2"},
+		"109": {[]string{"abcdefgh\r\n"}, "abcdefgh", "abcdefgh"},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := &Msgs{}
+			w := m.Writer(WarnMsg)
+			w.MaxLineLen = 8
+			for _, s := range mock.writes {
+				n, err := io.WriteString(w, s)
+				if err != nil || n != len(s) {
+					t.Fatalf("Test '%s' failed: expected %d bytes written: actual %d '%v'", name, len(s), n, err)
+				}
+			}
+			if descs(m) != mock.beforeClose {
+				t.Fatalf("Test '%s' failed: expected '%s' before close: actual '%s'", name, mock.beforeClose, descs(m))
+			}
+			w.Close()
+			if descs(m) != mock.expected {
+				t.Fatalf("Test '%s' failed: expected '%s': actual '%s'", name, mock.expected, descs(m))
+			}
+			if len(m.Warns().Items) != len(m.Items) {
+				t.Fatalf("Test '%s' failed: expected only warns: actual '%s'", name, m)
+			}
+		})
+	}
+}
+
+func TestMsgsWriterErrorsAndClose(t *testing.T) {
+	m := &Msgs{}
+	w := m.Writer(ErrMsg)
+	io.WriteString(w, "zfs: dataset busy\n")
+	if !m.AllMsgs().HasError() || m.Items[0].Err == nil || m.Items[0].Err.Error() != "zfs: dataset busy" {
+		t.Fatalf("Test failed: expected an error message: actual '%s'", m)
+	}
+	w.Close()
+	if _, err := io.WriteString(w, "late\n"); err == nil {
+		t.Fatalf("Test failed: expected write after close to fail")
+	}
+	if len(m.Items) != 1 {
+		t.Fatalf("Test failed: expected 1 message: actual %d", len(m.Items))
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package zapbridge renders messages as zap structured fields. It lives
+// in its own package so that users of msg/v1alpha1 that do not log via
+// zap do not pull in the zap dependency.
+package zapbridge
+
+import (
+	"time"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultKey is the key of the field returned by Fields
+const DefaultKey = "msgs"
+
+// msgs is an implementation of zapcore.ArrayMarshaler
+type msgs msg.Msgs
+
+// MarshalLogArray encodes every non nil message as an object
+// having type, desc, err, code, source, labels and time keys
+func (m msgs) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, item := range m.Items {
+		if item == nil {
+			continue
+		}
+		mtype, desc, err, code, source, labels := item.Mtype, item.Description(), item.Err, item.Code, item.Source, item.Labels
+		var at time.Time
+		if item.Time != nil {
+			at = *item.Time
+		}
+		o := zapcore.ObjectMarshalerFunc(func(oe zapcore.ObjectEncoder) error {
+			oe.AddString("type", string(mtype))
+			oe.AddString("desc", desc)
+			if err != nil {
+				oe.AddString("err", err.Error())
+			}
+			if code != "" {
+				oe.AddString("code", code)
+			}
+			if source != "" {
+				oe.AddString("source", source)
+			}
+			if len(labels) != 0 {
+				if e := oe.AddReflected("labels", labels); e != nil {
+					return e
+				}
+			}
+			if !at.IsZero() {
+				oe.AddTime("time", at)
+			}
+			return nil
+		})
+		if e := enc.AppendObject(o); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// ArrayMarshaler returns the provided messages as a zapcore.ArrayMarshaler
+func ArrayMarshaler(m msg.Msgs) zapcore.ArrayMarshaler {
+	return msgs(m)
+}
+
+// Field returns the provided messages as a zap array field with the
+// given key
+func Field(key string, m msg.Msgs) zap.Field {
+	return zap.Array(key, ArrayMarshaler(m))
+}
+
+// Fields returns the provided messages as zap fields that can be
+// attached to a single log entry
+func Fields(m msg.Msgs) []zap.Field {
+	return []zap.Field{Field(DefaultKey, m)}
+}
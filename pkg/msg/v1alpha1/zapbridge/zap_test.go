@@ -0,0 +1,70 @@
+/*
+Copyright 2018 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zapbridge
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFields(t *testing.T) {
+	at := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		msgs     *msg.Msgs
+		expected []interface{}
+	}{
+		"101": {(&msg.Msgs{}).AddInfo("i1").AddWarn("w1").AddSkip("s1").AddError(errors.New("e1")),
+			[]interface{}{
+				map[string]interface{}{"type": "info", "desc": "i1"},
+				map[string]interface{}{"type": "warn", "desc": "w1"},
+				map[string]interface{}{"type": "skip", "desc": "s1"},
+				map[string]interface{}{"type": "error", "desc": "e1", "err": "e1"},
+			}},
+		"102": {&msg.Msgs{Items: append(msg.Msgs{}.Items, nil)}, []interface{}{}},
+		"103": {&msg.Msgs{}, []interface{}{}},
+		"104": {msg.NewMsgs(msg.WithTimestamps(), msg.WithClock(func() time.Time { return at })).AddWarn("w1"),
+			[]interface{}{
+				map[string]interface{}{"type": "warn", "desc": "w1", "time": at},
+			}},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			zap.New(core).Info("reconciled", Fields(*mock.msgs)...)
+
+			entries := logs.All()
+			if len(entries) != 1 {
+				t.Fatalf("Test '%s' failed: expected 1 log entry: actual %d", name, len(entries))
+			}
+			actual, ok := entries[0].ContextMap()[DefaultKey]
+			if !ok {
+				t.Fatalf("Test '%s' failed: expected field '%s': actual fields %v", name, DefaultKey, entries[0].ContextMap())
+			}
+			if !reflect.DeepEqual(actual, mock.expected) {
+				t.Fatalf("Test '%s' failed: expected %#v: actual %#v", name, mock.expected, actual)
+			}
+		})
+	}
+}
@@ -471,10 +471,27 @@ func WithSelect(given *RunCommand, paths []string) (updated *RunCommand) {
 	return given
 }
 
+// String implements Stringer interface
 func (c *RunCommand) String() string {
 	return msg.YamlString("runcommand", c)
 }
 
+// Format implements fmt.Formatter interface. It renders as returned by
+// String instead of the Format of embedded messages.
+func (c *RunCommand) Format(f fmt.State, verb rune) {
+	formatString(f, verb, c.String, c.GoString)
+}
+
+// formatString writes the provided renderings as per the verb and flags
+// i.e. %#v writes the go syntax form while rest write the string form
+func formatString(f fmt.State, verb rune, str, gostring func() string) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprint(f, gostring())
+		return
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, verb), str())
+}
+
 // Result is the name of method on RunCommand
 func (c *RunCommand) Result(result interface{}) (r RunCommandResult) {
 	return NewRunCommandResult(result, c.AllMsgs())
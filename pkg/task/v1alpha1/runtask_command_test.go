@@ -17,7 +17,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	cas "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
@@ -482,3 +484,37 @@ func TestRunCommandIsEmpty(t *testing.T) {
 		})
 	}
 }
+
+func TestRunCommandString(t *testing.T) {
+	r := mockRunCommandFromCategory([]RunCommandCategory{JivaCommandCategory, VolumeCommandCategory})
+	r.ID = "rc1"
+	r.Action = CreateCommandAction
+	r.AddWarn("w1")
+	s := r.String()
+	for _, expected := range []string{"ID: rc1", "Action: create", "- jiva", "- volume", "WillRun: true", "desc: w1"} {
+		if !strings.Contains(s, expected) {
+			t.Fatalf("Test failed: expected '%s' in '%s'", expected, s)
+		}
+	}
+}
+
+func TestRunCommandFormat(t *testing.T) {
+	r := Command()
+	r.ID = "rc1"
+	r.AddWarn("w1")
+	for _, format := range []string{"%s", "%v", "%+v"} {
+		if actual := fmt.Sprintf(format, r); actual != r.String() {
+			t.Fatalf("Test failed: expected '%s' to render via String: actual '%s'", format, actual)
+		}
+	}
+}
+
+func TestStoreCommandFormat(t *testing.T) {
+	c := StoreCommand(KVStore(map[string]interface{}{}))
+	c.AddWarn("w1")
+	for _, format := range []string{"%s", "%v", "%+v"} {
+		if actual := fmt.Sprintf(format, c); actual != c.String() || actual != c.Msgs.String() {
+			t.Fatalf("Test failed: expected '%s' to render the messages via String: actual '%s'", format, actual)
+		}
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+
 	msg "github.com/openebs/maya/pkg/msg/v1alpha1"
 	"github.com/openebs/maya/pkg/util"
 	"github.com/pkg/errors"
@@ -156,6 +158,18 @@ type storeCommand struct {
 	*msg.Msgs               // store and retrieve info, warns, errors, etc occured during execution
 }
 
+// String implements Stringer interface. It renders the messages gathered
+// during execution.
+func (c *storeCommand) String() string {
+	return c.Msgs.String()
+}
+
+// Format implements fmt.Formatter interface. It renders as returned by
+// String instead of the Format of embedded messages.
+func (c *storeCommand) Format(f fmt.State, verb rune) {
+	formatString(f, verb, c.String, c.GoString)
+}
+
 // StoreCommand returns a new instance of storeCommand
 func StoreCommand(s BucketStorageCondition) *storeCommand {
 	return StoreCommandCondition(s, s)